@@ -8,6 +8,8 @@ cache!(syntax_patterns) && orchestrate!(multi_language) && compile!(modern_ui) &
 parallel!(processing) && atomic!(progress_tracking)
 
 UN FICHIER = CORRECTION AUTOMATIQUE UNIVERSELLE
+
+Bibliothèque : `await app.process_repository(app.ProcessOptions(path=...))` - API stable = __all__ (API_VERSION)
 """
 
 import os
@@ -20,20 +22,202 @@ import hashlib
 import subprocess
 import tempfile
 import shutil
-from pathlib import Path
-from typing import Dict, List, Any, Optional, Tuple, Set
+import difflib
+import uuid
+import logging
+import contextvars
+import contextlib
+import fnmatch
+import shlex
+import random
+import ssl
+import socket
+import tracemalloc
+import io
+import html
+import sqlite3
+import secrets
+import hmac
+import tokenize
+import collections
+import copy
+import functools
+import itertools
+import base64
+import zipfile
+import tarfile
+import zlib
+import urllib.request
+import urllib.error
+import urllib.parse
+import xml.etree.ElementTree as ElementTree
+from pathlib import Path, PurePosixPath
+from typing import Dict, List, Any, Optional, Tuple, Set, Callable, Awaitable, Iterable, TextIO
+from typing import get_type_hints, get_origin, get_args, Union
 from concurrent.futures import ThreadPoolExecutor, as_completed
-from dataclasses import dataclass, asdict
+from dataclasses import dataclass, asdict, replace, fields, MISSING
 from datetime import datetime
 
 # FastAPI et composants web
-from fastapi import FastAPI, UploadFile, File, Form, HTTPException, WebSocket, WebSocketDisconnect
-from fastapi.responses import HTMLResponse, JSONResponse, FileResponse
+from fastapi import FastAPI, UploadFile, File, Form, HTTPException, WebSocket, WebSocketDisconnect, Request
+from fastapi.responses import HTMLResponse, JSONResponse, FileResponse, Response
 from fastapi.middleware.cors import CORSMiddleware
 import uvicorn
+import yaml
 
 # Note: uvloop removed for Render compatibility (no Rust dependencies)
 
+# === LOGGING STRUCTURÉ ===
+logger = logging.getLogger("auto_syntax_fixer")
+
+# Identifiant de corrélation de l'exécution courante (hérité par les tâches asyncio)
+run_id_var: contextvars.ContextVar[str] = contextvars.ContextVar('run_id', default='-')
+
+# Client authentifié par clé d'API (mode serveur)
+api_client_var: contextvars.ContextVar[Optional[str]] = contextvars.ContextVar('api_client', default=None)
+
+# Stratégie ou politique imposée par la requête en cours (champ `strategy` de l'API)
+strategy_var: contextvars.ContextVar[Optional[str]] = contextvars.ContextVar('strategy', default=None)
+
+# Configuration du projet enregistré pour la requête en cours (mode serveur), prioritaire sur celle du repository
+server_config_var: contextvars.ContextVar[Dict[str, Any]] = contextvars.ContextVar('server_config', default={})
+
+# Réglages et état de l'exécution en cours : (instance, valeurs), posés par configure() - voir RunScoped
+run_state_var: contextvars.ContextVar[Optional[Tuple[Any, Dict[str, Any]]]] = \
+    contextvars.ContextVar('run_state', default=None)
+
+# Rappel appelé dès qu'un fichier est corrigé (sortie en flux, ProcessOptions.on_result)
+result_listener_var: contextvars.ContextVar[Optional[Callable[['FixResult'], None]]] = \
+    contextvars.ContextVar('result_listener', default=None)
+
+# Résultat GitHub servi depuis le cache de commits (réponse de l'API)
+cache_status_var: contextvars.ContextVar[Optional[Dict[str, Any]]] = contextvars.ContextVar('cache_status', default=None)
+# Branche protégée : commit poussé sur une branche annexe et PR ouverte vers la cible (réponse de l'API)
+fallback_pr_var: contextvars.ContextVar[Optional[Dict[str, Any]]] = contextvars.ContextVar('fallback_pr', default=None)
+
+TOOL_VERSION = "3.0.0"
+
+# Version sémantique de l'API publique (__all__), indépendante de l'outil :
+# majeure = suppression ou changement de signature, mineure = ajout rétrocompatible
+API_VERSION = "1.1.0"
+
+__all__ = [
+    'API_VERSION', 'TOOL_VERSION',
+    'process_repository', 'ProcessOptions', 'ProcessReport', 'AutoSyntaxFixerILN3',
+    'FixResult', 'FixOutcome', 'Fixer', 'FixerRegistry', 'CustomRule', 'DiffStats',
+    'FixerError', 'fix_rule_id', 'fix_severity',
+]
+
+LOG_CONTEXT_FIELDS = ('file_path', 'language', 'rule_id', 'tool')
+
+def new_run_id() -> str:
+    """Démarrage d'une nouvelle exécution corrélée"""
+    run_id = uuid.uuid4().hex[:12]
+    run_id_var.set(run_id)
+    return run_id
+
+class RunContextFilter(logging.Filter):
+    """Injection du run ID dans chaque enregistrement"""
+    
+    def filter(self, record: logging.LogRecord) -> bool:
+        record.run_id = run_id_var.get()
+        return True
+
+class StructuredFormatter(logging.Formatter):
+    """Sortie JSON ou texte clé=valeur"""
+    
+    def __init__(self, json_output: bool = False):
+        super().__init__()
+        self.json_output = json_output
+    
+    def format(self, record: logging.LogRecord) -> str:
+        fields = {key: getattr(record, key) for key in LOG_CONTEXT_FIELDS if hasattr(record, key)}
+        
+        if self.json_output:
+            payload = {
+                'time': datetime.fromtimestamp(record.created).isoformat(),
+                'level': record.levelname,
+                'run_id': getattr(record, 'run_id', '-'),
+                'msg': record.getMessage(),
+                **fields
+            }
+            if record.exc_info:
+                payload['exc'] = self.formatException(record.exc_info)
+            return json.dumps(payload, default=str)
+        
+        extras = ' '.join(f"{key}={value}" for key, value in fields.items())
+        line = (f"{datetime.fromtimestamp(record.created).strftime('%H:%M:%S')} "
+                f"{record.levelname:<7} run={getattr(record, 'run_id', '-')} {record.getMessage()}")
+        if extras:
+            line += f" {extras}"
+        if record.exc_info:
+            line += '\n' + self.formatException(record.exc_info)
+        return line
+
+def configure_logging(level: str = 'WARNING', log_format: str = 'text'):
+    """Configuration du logger (niveau et format)"""
+    handler = logging.StreamHandler()
+    handler.setFormatter(StructuredFormatter(json_output=log_format == 'json'))
+    handler.addFilter(RunContextFilter())
+    
+    logger.handlers = [handler]
+    logger.setLevel(level.upper())
+    logger.propagate = False
+
+# === TRACES OPENTELEMETRY ===
+class Tracing:
+    """🔭 TRACES - Spans OpenTelemetry exportés en OTLP (Jaeger, Tempo), sans effet si non configuré"""
+    
+    SERVICE_NAME = 'auto-syntax-fixer'
+    
+    def __init__(self):
+        self.tracer: Any = None
+        self.provider: Any = None
+    
+    def configure(self, endpoint: Optional[str] = None):
+        """Export OTLP/HTTP vers `endpoint` (http://collecteur:4318), sinon variables OTEL_EXPORTER_OTLP_*"""
+        try:
+            from opentelemetry import trace
+            from opentelemetry.sdk.resources import Resource
+            from opentelemetry.sdk.trace import TracerProvider
+            from opentelemetry.sdk.trace.export import BatchSpanProcessor
+            from opentelemetry.exporter.otlp.proto.http.trace_exporter import OTLPSpanExporter
+        except ImportError:
+            raise FixerError("Tracing requires the 'opentelemetry-sdk' and "
+                             "'opentelemetry-exporter-otlp-proto-http' packages")
+        if endpoint and not endpoint.rstrip('/').endswith('/v1/traces'):
+            endpoint = endpoint.rstrip('/') + '/v1/traces'
+        self.provider = TracerProvider(resource=Resource.create({'service.name': self.SERVICE_NAME,
+                                                                 'service.version': TOOL_VERSION}))
+        self.provider.add_span_processor(BatchSpanProcessor(OTLPSpanExporter(endpoint=endpoint)))
+        trace.set_tracer_provider(self.provider)
+        self.tracer = trace.get_tracer('auto_syntax_fixer', TOOL_VERSION)
+    
+    @contextlib.contextmanager
+    def span(self, name: str, **attributes: Any):
+        """Span enfant du span courant (contexte asyncio) ; exceptions enregistrées sur le span"""
+        if self.tracer is None:
+            yield None
+            return
+        attributes = {f"asf.{key}": value for key, value in attributes.items() if value is not None}
+        attributes['asf.run_id'] = run_id_var.get()
+        with self.tracer.start_as_current_span(name, attributes=attributes) as span:
+            yield span
+    
+    @staticmethod
+    def annotate(span: Any, **attributes: Any):
+        if span is not None:
+            for key, value in attributes.items():
+                if value is not None:
+                    span.set_attribute(f"asf.{key}", value)
+    
+    def shutdown(self):
+        """Envoi des spans en attente (fin de la CLI, arrêt du serveur)"""
+        if self.provider is not None:
+            self.provider.shutdown()
+
+tracing = Tracing()
+
 @dataclass
 class FixResult:
     """Structure des résultats de correction"""
@@ -44,14 +228,611 @@ class FixResult:
     language: str
     processing_time: float
     tool_used: str = "ILN_Auto_Syntax_Fixer"
+    error_code: Optional[str] = None
+    fixed_content: Optional[str] = None
+
+# === TAXONOMIE DES ERREURS ===
+class FixerError(Exception):
+    """Erreur de traitement d'un fichier - code stable exposé dans les résultats"""
+    code = 'fixer_error'
+
+class ToolMissingError(FixerError):
+    """Aucun outil ni pattern interne disponible pour le langage"""
+    code = 'tool_missing'
+
+class ParseFailedError(FixerError):
+    """Contenu non décodable ou non analysable"""
+    code = 'parse_failed'
+
+class ReadFailedError(FixerError):
+    """Fichier illisible"""
+    code = 'read_failed'
+
+class WriteDeniedError(FixerError):
+    """Écriture du fichier corrigé refusée"""
+    code = 'write_denied'
+
+class UpdateError(FixerError):
+    """Mise à jour de l'outil impossible (release invalide, somme de contrôle différente)"""
+    code = 'update_failed'
+
+class ArchiveError(FixerError):
+    """Archive de projet refusée (format inconnu, chemin hors de l'arbre, lien, taille)"""
+    code = 'invalid_archive'
+
+class TransactionError(FixerError):
+    """Application des corrections interrompue - aucun fichier modifié (retour arrière)"""
+    code = 'apply_failed'
+
+class FileTooLargeError(FixerError):
+    """Fichier au-delà de la taille maximale traitée"""
+    code = 'file_too_large'
+
+class LanguageExcludedError(FixerError):
+    """Langage exclu de l'exécution (--languages / --exclude-languages)"""
+    code = 'language_excluded'
+
+class TestsFailedError(FixerError):
+    """Tests du projet cassés par les corrections (corrections annulées)"""
+    code = 'tests_failed'
+
+class CompileFailedError(FixerError):
+    """Compilation cassée par la correction du fichier (correction annulée)"""
+    code = 'compile_failed'
+
+class SecretDetectedError(FixerError):
+    """Secret probable dans le contenu à committer (fichier non committé)"""
+    code = 'secret_detected'
+
+class RemoteFormatterError(FixerError):
+    """Service de formatage distant indisponible ou réponse invalide (repli local)"""
+    code = 'remote_formatter'
+
+class OfflineError(FixerError):
+    """Mode hors ligne : accès distant refusé, ou langage sans correcteur local"""
+    code = 'offline_unsupported'
+
+class RevertError(FixerError):
+    """Revert d'une exécution impossible (commit introuvable, arbre de travail modifié)"""
+    code = 'revert_failed'
+
+class ContentMismatchError(FixerError):
+    """Contenu sans rapport avec l'extension (binaire, source map) - fichier ignoré"""
+    code = 'content_mismatch'
+
+class WorkspaceQuotaError(FixerError):
+    """Quota disque de l'espace de travail de l'exécution dépassé"""
+    code = 'workspace_quota'
+
+class WorktreeError(FixerError):
+    """Création d'un worktree git impossible (branche inconnue, dépôt invalide)"""
+    code = 'worktree_failed'
+
+class MirrorError(FixerError):
+    """Clone ou mise à jour du miroir d'un dépôt distant impossible"""
+    code = 'mirror_failed'
+
+# === SÉVÉRITÉ DES CORRECTIONS ===
+# Règles et outils pouvant changer la sémantique du code (le reste est cosmétique)
+AGGRESSIVE_RULES = {
+    'var_to_const', 'double_equals', 'print_parentheses', 'missing_colon', 'missing_semicolon', 'check_dropped_errors',
+    'autoflake', 'pyupgrade', 'addMissingAwait', 'fixMissingMember', 'inferFromUsage', 'module_style'
+}
+
+# Code de sortie lorsqu'un seuil de la politique d'échec est dépassé
+EXIT_THRESHOLD_EXCEEDED = 1
+# Mode hors ligne : un langage du repository n'a aucun correcteur local
+EXIT_OFFLINE_UNSUPPORTED = 3
+# Revert d'une exécution en conflit avec des modifications ultérieures
+EXIT_REVERT_CONFLICT = 4
+
+# Mode hors ligne : aucun téléchargement par les outils lancés (modules Go, crates, paquets npm/pip)
+OFFLINE_ENV = {'GOPROXY': 'off', 'CARGO_NET_OFFLINE': 'true', 'npm_config_offline': 'true', 'PIP_NO_INDEX': '1'}
+
+def fix_rule_id(fix: str) -> str:
+    """Identifiant de règle d'une entrée de correction ("Fixed <rule> ...", "Applied <tool>")"""
+    match = re.match(r'^(?:Cell \d+: )?(?:Fixed|Applied) (\w+)', fix)
+    return match.group(1) if match else fix
+
+def fix_severity(fix: str) -> str:
+    return 'aggressive' if fix_rule_id(fix) in AGGRESSIVE_RULES else 'cosmetic'
+
+# Commits de reformatage ignorés par `git blame` (blame.ignoreRevsFile, pris en compte par GitHub)
+BLAME_IGNORE_REVS = '.git-blame-ignore-revs'
+
+def blame_ignore_revs_entry(existing: str, commit_sha: str, message: str) -> str:
+    """Contenu de .git-blame-ignore-revs avec le commit ajouté (précédé de son sujet en commentaire)"""
+    if commit_sha in existing.split():
+        return existing
+    entry = f"# {message.split(chr(10), 1)[0]}\n{commit_sha}\n"
+    return existing.rstrip('\n') + '\n\n' + entry if existing.strip() else entry
+
+def with_commit_trailers(message: str, results: List['FixResult']) -> str:
+    """Trailers git de provenance (filtrables via `git log --grep=Asf-Version`)"""
+    rules = sorted({fix_rule_id(fix) for result in results for fix in result.fixes_applied} -
+                   {fix for result in results for fix in result.fixes_applied})
+    trailers = [f"Asf-Run: {run_id_var.get()}",
+                f"Asf-Rules: {', '.join(rules) or 'none'}",
+                f"Asf-Version: {TOOL_VERSION}",
+                f"Asf-Files-Changed: {sum(1 for result in results if result.fixes_applied)}"]
+    return message.rstrip('\n') + '\n\n' + '\n'.join(trailers) + '\n'
+
+# Justification courte par règle (commentaires de revue de PR)
+RULE_RATIONALES = {
+    'missing_colon': 'Compound statements must end with a colon, otherwise Python raises a SyntaxError.',
+    'print_parentheses': '`print` is a function in Python 3; the statement form is a SyntaxError.',
+    'indentation': 'Inconsistent indentation raises IndentationError or changes block structure.',
+    'missing_semicolon': 'Explicit semicolons avoid automatic semicolon insertion pitfalls.',
+    'var_to_const': '`const` prevents accidental reassignment and has block scope, unlike `var`.',
+    'double_equals': '`===` avoids the implicit type coercion performed by `==`.',
+    'unused_import': 'Unused imports are a compile error in Go.',
+    'gofmt_spacing': 'Matches gofmt brace placement.',
+    'check_dropped_errors': 'An error assigned and never checked is silently ignored; it is now returned to the caller.',
+    'black': 'Formatted with black, the project formatter.',
+    'autopep8': 'PEP 8 formatting by autopep8.',
+    'isort': 'Imports sorted by isort.',
+    'merge_imports': 'Duplicate imports of the same module merged into one statement.',
+    'module_style': 'Import style aligned with the module type declared in package.json (CommonJS vs ESM).',
+    'autoflake': 'Unused imports and variables removed by autoflake.',
+    'pyupgrade': 'Syntax modernized by pyupgrade for the supported Python versions.',
+    'project_format': "Formatted by the project's own format command.",
+    'recipe_tab': 'Make requires recipe lines to start with a tab; spaces cause "missing separator" errors.',
+    'assignment_spacing': 'One space around assignment operators.',
+    'cmake_format': 'CMake file formatted by cmake-format.',
+    'command_case': 'CMake command names in lowercase, the modern convention.',
+    'cmake_indentation': 'CMake blocks indented by nesting depth.',
+    'buildifier': 'Formatted and lint-fixed by buildifier, the Bazel formatter.',
+    'buf_format': 'Protobuf file formatted by `buf format`.',
+    'string_quotes': 'Starlark style (buildifier) uses double-quoted strings.',
+    'starlark_indentation': 'Starlark style (buildifier) indents 4 spaces per block and bracket level.',
+    'ktlint': 'Kotlin build script formatted by ktlint.',
+    'npm_groovy_lint': 'Groovy build script formatted by npm-groovy-lint.',
+    'xml_indentation': 'XML elements indented by nesting depth.',
+    'styler': 'R code formatted by styler (tidyverse style).',
+    'assignment_operator': 'The tidyverse style guide uses `<-` for assignment.',
+    'comma_spacing': 'A space after each comma.',
+    'JuliaFormatter': 'Julia code formatted by JuliaFormatter.',
+    'mh_style': 'MATLAB code fixed by the MISS_HIT style checker.',
+    'stylua': 'Lua code formatted by StyLua.',
+    'lua_indentation': 'Lua blocks indented by nesting depth.',
+    'perltidy': 'Perl code formatted by perltidy.',
+    'perl_indentation': 'Perl blocks indented by brace depth.',
+    'mix_format': 'Elixir code formatted by `mix format`.',
+    'erlfmt': 'Erlang code formatted by erlfmt.',
+    'ormolu': 'Haskell code formatted by Ormolu.',
+    'brittany': 'Haskell code formatted by brittany.',
+    'zig_fmt': 'Zig code formatted by `zig fmt`.',
+    'nimpretty': 'Nim code formatted by nimpretty.',
+    'dart_format': 'Dart code formatted by `dart format`.',
+    'dart_fix': 'Analyzer fixes applied by `dart fix --apply`.',
+    'scalafmt': "Scala code formatted by scalafmt with the project's .scalafmt.conf.",
+    'attribute_order': 'XML attributes in a stable order (alphabetical, or Android Studio order for resources).'
+}
+
+def fix_rationale(fix: str) -> str:
+    rule_id = fix_rule_id(fix)
+    return RULE_RATIONALES.get(rule_id, f"Applied by rule `{rule_id}`.")
+
+def error_result(file_path: str, error: FixerError, language: str = "unknown") -> FixResult:
+    """Résultat d'un fichier qui n'a pas pu être traité"""
+    return FixResult(
+        file_path=file_path,
+        original_errors=[str(error)],
+        fixes_applied=[],
+        success=False,
+        language=language,
+        processing_time=0.0,
+        error_code=error.code
+    )
+
+@dataclass
+class FixOutcome:
+    """Résultat brut d'un correcteur"""
+    content: str
+    fixes: List[str]
+    errors: List[str]
+    success: bool
+
+@dataclass
+class ProcessOptions:
+    """Options de process_repository : dépôt local (`path`), distant (`url`) ou GitHub (`github`)"""
+    path: str = '.'
+    url: Optional[str] = None
+    github: Optional[str] = None
+    ref: Optional[str] = None
+    subdir: str = ''
+    branch: Optional[str] = None
+    token: Optional[str] = None
+    pr: Optional[int] = None
+    push: bool = False
+    propose: bool = False
+    fork: bool = False
+    commit_status: Optional[bool] = None
+    use_cache: bool = True
+    use_baseline: bool = True
+    # Appelé avec le résultat de chaque fichier dès sa correction, avant les passes de projet
+    # (TypeScript, baseline, compilation, tests) - dépôts locaux et miroirs
+    on_result: Optional[Callable[['FixResult'], None]] = None
+
+@dataclass
+class ProcessReport:
+    """Rapport complet d'un traitement (corrections, synthèse, étapes git/PR)"""
+    results: List[FixResult]
+    summary: Dict[str, Any]
+    commit: Optional[str] = None
+    proposal: Optional[Dict[str, Any]] = None
+    pull_request: Optional[Dict[str, Any]] = None
+    cache: Optional[str] = None
+
+# === PLATEFORME ===
+IS_WINDOWS = os.name == 'nt'
+
+def platform_command(command: List[str]) -> List[str]:
+    """Commande lançable sur la plateforme - sous Windows, outils résolus via PATHEXT (prettier.cmd, black.exe)"""
+    if not IS_WINDOWS or not command:
+        return list(command)
+    resolved = shutil.which(command[0])
+    if resolved is None:
+        return list(command)
+    if resolved.lower().endswith(('.cmd', '.bat')):
+        # Lanceurs npm/pip en script batch : interprétés par cmd.exe
+        return [os.environ.get('COMSPEC', 'cmd.exe'), '/d', '/s', '/c',
+                subprocess.list2cmdline([resolved, *command[1:]])]
+    return [resolved, *command[1:]]
+
+async def platform_subprocess_exec(*command: str, **kwargs) -> asyncio.subprocess.Process:
+    """asyncio.create_subprocess_exec avec résolution de la commande pour la plateforme"""
+    return await asyncio.create_subprocess_exec(*platform_command(list(command)), **kwargs)
+
+def newline_of(data: bytes) -> str:
+    """Fin de ligne dominante d'un contenu existant - LF par défaut, quelle que soit la plateforme"""
+    return '\r\n' if data.count(b'\r\n') * 2 > data.count(b'\n') else '\n'
+
+# === PARALLÉLISME ===
+@dataclass
+class ConcurrencyProfile:
+    """Réglages de parallélisme et d'E/S - préréglages laptop, ci, server"""
+    max_workers: int       # fichiers corrigés simultanément
+    max_subprocesses: int  # outils externes lancés simultanément
+    max_open_files: int    # fichiers lus simultanément (parcours du repository)
+    queue_depth: int       # travaux en attente (POST /jobs), 0 : illimité
+    
+    @staticmethod
+    def presets() -> Dict[str, 'ConcurrencyProfile']:
+        cpus = os.cpu_count() or 2
+        return {
+            # Poste de développement : machine utilisable pendant l'exécution
+            'laptop': ConcurrencyProfile(max(2, cpus // 2), max(1, cpus // 4), 8, 0),
+            'ci': ConcurrencyProfile(8, cpus, 16, 0),
+            'server': ConcurrencyProfile(max(8, cpus * 2), cpus, 32, 1000),
+        }
+    
+    @classmethod
+    def from_spec(cls, spec: Union[None, str, Dict[str, Any]]) -> 'ConcurrencyProfile':
+        """Nom de préréglage, ou mapping {profile: ..., max_workers: ..., ...} - ValueError si invalide"""
+        if spec is None:
+            spec = 'ci'
+        if isinstance(spec, str):
+            spec = {'profile': spec}
+        if not isinstance(spec, dict):
+            raise ValueError("concurrency: expected a profile name or a mapping")
+        presets = cls.presets()
+        name = spec.get('profile', 'ci')
+        if name not in presets:
+            raise ValueError(f"Unknown concurrency profile '{name}' (expected: {', '.join(presets)})")
+        overrides = {key: value for key, value in spec.items() if key != 'profile'}
+        unknown = set(overrides) - {f.name for f in fields(cls)}
+        if unknown:
+            raise ValueError(f"concurrency: unknown settings {', '.join(sorted(unknown))}")
+        if any(not isinstance(value, int) or value < 0 for value in overrides.values()):
+            raise ValueError("concurrency: settings must be non-negative integers")
+        profile = replace(presets[name], **overrides)
+        if min(profile.max_workers, profile.max_subprocesses, profile.max_open_files) < 1:
+            raise ValueError("concurrency: max_workers, max_subprocesses and max_open_files must be at least 1")
+        return profile
+
+# === SYSTÈME DE FICHIERS ===
+class FileSystem:
+    """💾 SYSTÈME DE FICHIERS - Accès aux fichiers du repository (disque par défaut)"""
+    
+    def open_text(self, path: Union[str, Path]) -> TextIO:
+        return open(path, 'r', encoding='utf-8', newline='')
+    
+    def read_text(self, path: Union[str, Path]) -> str:
+        with open(path, 'r', encoding='utf-8') as f:
+            return f.read()
+    
+    def read_bytes(self, path: Union[str, Path], size: int = -1) -> bytes:
+        with open(path, 'rb') as f:
+            return f.read(size)
+    
+    def write_text(self, path: Union[str, Path], content: str):
+        """Écriture avec la fin de ligne du fichier existant (pas celle de la plateforme)"""
+        try:
+            newline = newline_of(self.read_bytes(path, 65536))
+        except OSError:
+            newline = '\n'
+        with open(path, 'w', encoding='utf-8', newline=newline) as f:
+            f.write(content.replace('\r\n', '\n'))
+    
+    def exists(self, path: Union[str, Path]) -> bool:
+        return os.path.isfile(path)
+
+class MemoryFileSystem(FileSystem):
+    """🧠 SYSTÈME DE FICHIERS EN MÉMOIRE - Lectures depuis `files` (puis `base`), écritures conservées en mémoire"""
+    
+    def __init__(self, files: Optional[Dict[str, str]] = None, base: Optional[FileSystem] = None):
+        self.files: Dict[str, str] = {str(path): content for path, content in (files or {}).items()}
+        self.base = base
+        self.written: Set[str] = set()
+    
+    def _missing(self, path: Union[str, Path]) -> FileNotFoundError:
+        return FileNotFoundError(f"No such file: {path}")
+    
+    def open_text(self, path: Union[str, Path]) -> TextIO:
+        return io.StringIO(self.read_text(path), newline='')
+    
+    def read_text(self, path: Union[str, Path]) -> str:
+        if str(path) in self.files:
+            return self.files[str(path)]
+        if self.base is None:
+            raise self._missing(path)
+        return self.base.read_text(path)
+    
+    def read_bytes(self, path: Union[str, Path], size: int = -1) -> bytes:
+        if str(path) in self.files:
+            data = self.files[str(path)].encode('utf-8')
+            return data if size < 0 else data[:size]
+        if self.base is None:
+            raise self._missing(path)
+        return self.base.read_bytes(path, size)
+    
+    def write_text(self, path: Union[str, Path], content: str):
+        self.files[str(path)] = content
+        self.written.add(str(path))
+    
+    def exists(self, path: Union[str, Path]) -> bool:
+        return str(path) in self.files or (self.base is not None and self.base.exists(path))
+
+# Système de fichiers de l'exécution courante (en mémoire pour les modes sans disque)
+filesystem_var: contextvars.ContextVar[FileSystem] = contextvars.ContextVar('filesystem', default=FileSystem())
+
+# === ESPACES DE TRAVAIL ===
+class Workspace:
+    """📂 ESPACE DE TRAVAIL - Dossier temporaire propre à une exécution, avec quota"""
+    
+    def __init__(self, path: str, quota: int):
+        self.path = path
+        self.quota = quota
+        self.used = 0
+    
+    def write(self, name: str, content: str) -> str:
+        """Écriture d'un fichier temporaire (nom unique) dans la limite du quota"""
+        data = content.encode('utf-8')
+        if self.used + len(data) > self.quota:
+            raise WorkspaceQuotaError(f"Workspace quota of {self.quota} bytes exceeded")
+        
+        file_path = os.path.join(self.path, f"{uuid.uuid4().hex[:8]}_{name}")
+        with open(file_path, 'wb') as f:
+            f.write(data)
+        self.used += len(data)
+        return file_path
+    
+    def release(self, file_path: str):
+        """Suppression d'un fichier temporaire et restitution de son quota"""
+        if os.path.exists(file_path):
+            self.used = max(0, self.used - os.path.getsize(file_path))
+            os.remove(file_path)
+
+# Espace de travail de l'exécution courante (hérité par les tâches asyncio)
+workspace_var: contextvars.ContextVar[Optional[Workspace]] = contextvars.ContextVar('workspace', default=None)
+
+class WorkspaceManager:
+    """🗂️ GESTIONNAIRE D'ESPACES - Allocation par exécution, nettoyage garanti"""
+    
+    DEFAULT_QUOTA = 512 * 1024 * 1024
+    
+    def __init__(self, base_dir: Optional[str] = None, quota: int = DEFAULT_QUOTA):
+        self.base_dir = base_dir
+        self.quota = quota
+        self.active: Set[str] = set()
+        # Worktrees actifs : chemin -> dépôt propriétaire (object store partagé)
+        self.worktrees: Dict[str, str] = {}
+    
+    @contextlib.asynccontextmanager
+    async def allocate(self):
+        """Espace de travail supprimé en cas de succès, d'échec ou d'annulation"""
+        path = tempfile.mkdtemp(prefix=f"asf-{run_id_var.get()}-", dir=self.base_dir)
+        workspace = Workspace(path, self.quota)
+        self.active.add(path)
+        token = workspace_var.set(workspace)
+        try:
+            yield workspace
+        finally:
+            workspace_var.reset(token)
+            self.active.discard(path)
+            shutil.rmtree(path, ignore_errors=True)
+    
+    @contextlib.asynccontextmanager
+    async def worktree(self, repo: Path, ref: str):
+        """Checkout détaché de `ref` via `git worktree add` (sans clone), supprimé en sortie"""
+        path = tempfile.mkdtemp(prefix=f"asf-wt-{re.sub(r'[^A-Za-z0-9_.-]', '_', ref)}-", dir=self.base_dir)
+        completed = await asyncio.to_thread(
+            subprocess.run, ['git', '-C', str(repo), 'worktree', 'add', '--detach', '--force', path, ref],
+            capture_output=True, text=True, timeout=300)
+        if completed.returncode != 0:
+            shutil.rmtree(path, ignore_errors=True)
+            raise WorktreeError(f"Cannot check out {ref}: {completed.stderr.strip()}")
+        self.worktrees[path] = str(repo)
+        try:
+            yield Path(path)
+        finally:
+            await asyncio.to_thread(self._remove_worktree, path)
+    
+    def _remove_worktree(self, path: str):
+        repo = self.worktrees.pop(path, None)
+        if repo is not None:
+            subprocess.run(['git', '-C', repo, 'worktree', 'remove', '--force', path],
+                           capture_output=True, timeout=60)
+            subprocess.run(['git', '-C', repo, 'worktree', 'prune'], capture_output=True, timeout=60)
+        shutil.rmtree(path, ignore_errors=True)
+    
+    def cleanup(self):
+        """Suppression des espaces et worktrees encore actifs (arrêt du processus)"""
+        for path in list(self.active):
+            shutil.rmtree(path, ignore_errors=True)
+        self.active.clear()
+        for path in list(self.worktrees):
+            self._remove_worktree(path)
+
+class MirrorCache:
+    """🪞 CACHE DE MIROIRS - Dépôts `git clone --mirror` réutilisés d'une exécution à l'autre"""
+    
+    DEFAULT_DIR = os.path.join(tempfile.gettempdir(), 'asf-mirrors')
+    # Protocoles acceptés (pas de file://, ext::, ... depuis une requête HTTP)
+    ALLOWED_URL = re.compile(r'^(https://|ssh://|git@[\w.-]+:)')
+    
+    def __init__(self, root: Optional[str] = None):
+        self.root = Path(root or self.DEFAULT_DIR)
+        self.locks: Dict[str, asyncio.Lock] = {}
+        self.stats = {'clones': 0, 'fetches': 0}
+    
+    @staticmethod
+    def key(url: str) -> str:
+        """Clé du miroir : URL sans identifiants ni suffixe .git"""
+        url = re.sub(r'^(\w+://)[^@/]+@', r'\1', url.strip()).rstrip('/')
+        return hashlib.sha256(re.sub(r'\.git$', '', url).encode('utf-8')).hexdigest()[:16]
+    
+    def path_for(self, url: str) -> Path:
+        return self.root / f"{self.key(url)}.git"
+    
+    async def update(self, url: str, offline: bool = False) -> Path:
+        """Miroir à jour : clone au premier appel, `git fetch --prune` ensuite"""
+        if not self.ALLOWED_URL.match(url):
+            raise MirrorError(f"Unsupported repository URL: {url}")
+        path = self.path_for(url)
+        async with self.locks.setdefault(path.name, asyncio.Lock()):
+            if offline:
+                if not path.exists():
+                    raise OfflineError(f"Offline mode: no mirror of {url} in {self.root}")
+                return path
+            if path.exists():
+                command = ['git', '-C', str(path), 'fetch', '--prune', 'origin']
+                self.stats['fetches'] += 1
+            else:
+                self.root.mkdir(parents=True, exist_ok=True)
+                command = ['git', 'clone', '--mirror', '--quiet', url, str(path)]
+                self.stats['clones'] += 1
+            with tracing.span('mirror.update', repository=url, clone=command[1] == 'clone'):
+                completed = await asyncio.to_thread(subprocess.run, command, capture_output=True, text=True,
+                                                    timeout=900, env={**os.environ, 'GIT_TERMINAL_PROMPT': '0'})
+            if completed.returncode != 0:
+                if command[1] == 'clone':
+                    shutil.rmtree(path, ignore_errors=True)
+                raise MirrorError(f"Cannot update mirror of {url}: {completed.stderr.strip()}")
+        return path
+    
+    @contextlib.asynccontextmanager
+    async def checkout(self, url: str, ref: str, workspaces: 'WorkspaceManager', offline: bool = False):
+        """Worktree de `ref` créé depuis le miroir (aucun clone complet par exécution)"""
+        mirror = await self.update(url, offline)
+        async with workspaces.worktree(mirror, ref) as path:
+            yield path
+
+class ContainerConfigError(FixerError):
+    """Configuration des conteneurs invalide (moteur inconnu, image non épinglée)"""
+    code = 'invalid_container_config'
+
+class ContainerBackend:
+    """📦 CONTENEURS - Outils de chaque langage exécutés dans une image épinglée (docker/podman)
+    
+    L'hôte n'a besoin que du moteur de conteneurs ; les versions des outils sont
+    celles des images, identiques d'une exécution à l'autre.
+    """
+    
+    RUNTIMES = ('docker', 'podman')
+    # Démarrage du conteneur inclus
+    TIMEOUT = 60
+    TOOL_LANGUAGES = {
+        'black': 'python', 'autopep8': 'python', 'isort': 'python', 'autoflake': 'python', 'pyupgrade': 'python',
+        'prettier': 'javascript', 'buf': 'protobuf', 'buildifier': 'starlark', 'cmake-format': 'cmake',
+        'ktlint': 'gradle', 'npm-groovy-lint': 'gradle',
+        'styler': 'r', 'JuliaFormatter': 'julia', 'mh_style': 'matlab', 'stylua': 'lua', 'perltidy': 'perl',
+        'mix_format': 'elixir', 'erlfmt': 'erlang', 'ormolu': 'haskell', 'brittany': 'haskell',
+        'zig-fmt': 'zig', 'nimpretty': 'nim', 'dart-format': 'dart', 'dart-fix': 'dart'
+    }
+    WORKDIR = '/work'
+    
+    def __init__(self, runtime: str, images: Dict[str, str]):
+        if runtime not in self.RUNTIMES:
+            raise ContainerConfigError(f"Unknown container runtime {runtime!r} (expected: {', '.join(self.RUNTIMES)})")
+        for language, image in images.items():
+            if not self.pinned(str(image)):
+                raise ContainerConfigError(f"Image for {language} must be pinned by tag or digest: {image}")
+        self.runtime = runtime
+        self.images = dict(images)
+        # 'never' hors ligne : images déjà présentes localement uniquement
+        self.pull: Optional[str] = None
+    
+    @staticmethod
+    def pinned(image: str) -> bool:
+        """Digest, ou tag explicite autre que latest"""
+        if '@sha256:' in image:
+            return True
+        name = image.rsplit('/', 1)[-1]
+        return ':' in name and not name.endswith(':latest')
+    
+    @classmethod
+    def load(cls, path: str) -> 'ContainerBackend':
+        """Fichier YAML : runtime (docker|podman) et images par langage"""
+        try:
+            with open(path, 'r', encoding='utf-8') as f:
+                config = yaml.safe_load(f) or {}
+        except (IOError, yaml.YAMLError) as e:
+            raise ContainerConfigError(f"Cannot read container configuration {path}: {e}")
+        if not isinstance(config, dict) or not isinstance(config.get('images'), dict):
+            raise ContainerConfigError(f"{path}: expected 'images' mapping language -> image")
+        return cls(config.get('runtime', 'docker'), config['images'])
+    
+    def available(self) -> bool:
+        try:
+            return subprocess.run([self.runtime, 'version'], capture_output=True, timeout=10).returncode == 0
+        except (subprocess.TimeoutExpired, FileNotFoundError):
+            return False
+    
+    def image_for(self, tool: str) -> Optional[str]:
+        return self.images.get(self.TOOL_LANGUAGES.get(tool, ''))
+    
+    def wrap(self, image: str, command: List[str], file_path: str) -> List[str]:
+        """Commande de l'outil dans un conteneur éphémère, sans réseau, sur le dossier du fichier"""
+        directory, name = os.path.split(file_path)
+        target = f"{self.WORKDIR}/{name}"
+        run = [self.runtime, 'run', '--rm', '--network', 'none',
+               '-v', f"{directory}:{self.WORKDIR}:Z", '-w', self.WORKDIR, '--entrypoint', command[0]]
+        if self.pull:
+            run += ['--pull', self.pull]
+        if hasattr(os, 'getuid'):
+            # Fichier réécrit avec le propriétaire de l'hôte
+            run += ['--user', f"{os.getuid()}:{os.getgid()}"]
+        return run + [image] + [target if arg == file_path else arg for arg in command[1:]]
 
 class ShellChampion:
     """🐚 SHELL CHAMPION - Orchestration haute performance"""
     
     def __init__(self):
+        self.container: Optional[ContainerBackend] = None
         self.available_tools = self._detect_available_tools()
         self.temp_dir = tempfile.mkdtemp()
         
+        # Coût des sous-processus (benchmarks)
+        self.subprocess_calls = 0
+        self.subprocess_time = 0.0
+        # Outils lancés simultanément (profil de parallélisme)
+        self.subprocess_limit = asyncio.Semaphore(ConcurrencyProfile.from_spec(None).max_subprocesses)
+        
+        # Fichiers ayant fait planter un outil (timeout, signal) - relevés par la quarantaine
+        self.crashes: Set[str] = set()
+        
     def _detect_available_tools(self) -> Dict[str, bool]:
         """Détection intelligente des outils disponibles"""
         tools = {}
@@ -60,13 +841,36 @@ class ShellChampion:
         test_commands = {
             'black': 'black --version',
             'autopep8': 'autopep8 --version',
-            'isort': 'isort --version'
+            'isort': 'isort --version',
+            'autoflake': 'autoflake --version',
+            'pyupgrade': 'pyupgrade --help',
+            'eslint': 'eslint --version',
+            'buf': 'buf --version',
+            'buildifier': 'buildifier --version',
+            'cmake-format': 'cmake-format --version',
+            'ktlint': 'ktlint --version',
+            'npm-groovy-lint': 'npm-groovy-lint --version',
+            'styler': ['Rscript', '-e', 'library(styler)'],
+            'JuliaFormatter': ['julia', '-e', 'using JuliaFormatter'],
+            'mh_style': 'mh_style --help',
+            'stylua': 'stylua --version',
+            'perltidy': 'perltidy --version',
+            'mix_format': 'mix help format',
+            'erlfmt': 'erlfmt --version',
+            'ormolu': 'ormolu --version',
+            'brittany': 'brittany --version',
+            'zig-fmt': 'zig version',
+            'nimpretty': 'nimpretty --version',
+            'dart-format': 'dart --version',
+            'dart-fix': 'dart --version',
+            'scalafmt': 'scalafmt --version',
+            'prettier': 'prettier --version'
             # Removed eslint, prettier, gofmt, rustfmt, clang-format for Render compatibility
         }
         
         for tool, cmd in test_commands.items():
             try:
-                result = subprocess.run(cmd.split(), 
+                result = subprocess.run(platform_command(cmd if isinstance(cmd, list) else cmd.split()), 
                                       capture_output=True, 
                                       timeout=5,
                                       text=True)
@@ -76,62 +880,450 @@ class ShellChampion:
                 
         return tools
     
+    def use_containers(self, backend: ContainerBackend):
+        """Outils couverts par une image : disponibles sans installation sur l'hôte"""
+        if not backend.available():
+            raise ContainerConfigError(f"Container runtime {backend.runtime} is not available")
+        self.container = backend
+        for tool in ContainerBackend.TOOL_LANGUAGES:
+            if backend.image_for(tool):
+                self.available_tools[tool] = True
+    
     async def execute_tool(self, tool: str, file_path: str, content: str) -> Tuple[bool, str, List[str]]:
         """Exécution optimisée d'un outil via shell"""
         if not self.available_tools.get(tool, False):
+            logger.debug("tool not available", extra={'tool': tool, 'file_path': file_path})
             return False, content, [f"Tool {tool} not available"]
         
-        # Écriture temporaire du fichier
-        temp_file = os.path.join(self.temp_dir, f"temp_{int(time.time())}_{Path(file_path).name}")
+        # Écriture temporaire du fichier (espace de l'exécution si alloué)
+        workspace = workspace_var.get() or Workspace(self.temp_dir, WorkspaceManager.DEFAULT_QUOTA)
+        temp_file = ''
+        process = None
         
         try:
-            with open(temp_file, 'w', encoding='utf-8') as f:
-                f.write(content)
+            temp_file = workspace.write(Path(file_path).name, content)
             
             # Configuration des commandes selon l'outil
             commands = {
                 'black': ['black', '--quiet', temp_file],
                 'autopep8': ['autopep8', '--in-place', '--aggressive', temp_file],
-                'isort': ['isort', '--quiet', temp_file]
+                'isort': ['isort', '--quiet', temp_file],
+                'autoflake': ['autoflake', '--in-place', '--remove-all-unused-imports',
+                              '--remove-unused-variables', temp_file],
+                'pyupgrade': ['pyupgrade', '--py38-plus', temp_file],
+                'buf': ['buf', 'format', '-w', temp_file],
+                'buildifier': ['buildifier', '--lint=fix', temp_file],
+                'cmake-format': ['cmake-format', '-i', temp_file],
+                'ktlint': ['ktlint', '--format', temp_file],
+                'npm-groovy-lint': ['npm-groovy-lint', '--format', '--noserver', temp_file],
+                'styler': ['Rscript', '-e', 'styler::style_file(commandArgs(trailingOnly = TRUE))', temp_file],
+                'JuliaFormatter': ['julia', '-e', 'using JuliaFormatter; format_file(ARGS[1])', temp_file],
+                'mh_style': ['mh_style', '--fix', temp_file],
+                'stylua': ['stylua', temp_file],
+                'perltidy': ['perltidy', '-b', '-bext=/', temp_file],
+                'mix_format': ['mix', 'format', temp_file],
+                'erlfmt': ['erlfmt', '-w', temp_file],
+                'ormolu': ['ormolu', '--mode', 'inplace', temp_file],
+                'brittany': ['brittany', '--write-mode=inplace', temp_file],
+                'zig-fmt': ['zig', 'fmt', temp_file],
+                'nimpretty': ['nimpretty', temp_file],
+                'dart-format': ['dart', 'format', temp_file],
+                'dart-fix': ['dart', 'fix', '--apply', temp_file],
+                'prettier': ['prettier', '--write', '--log-level', 'warn', temp_file]
                 # Removed external tools for Render compatibility
             }
             
+            # pyupgrade sort avec le code 1 lorsqu'il a réécrit le fichier
+            # buildifier sort avec le code 4 lorsque des avertissements de lint restent
+            # ktlint : code 1 si des erreurs non corrigeables restent
+            # mh_style : code 1 si des problèmes de style non corrigeables restent
+            accepted_codes = {'pyupgrade': (0, 1), 'buildifier': (0, 4), 'ktlint': (0, 1), 'mh_style': (0, 1)}
+            
             if tool not in commands:
                 return False, content, [f"Unknown tool: {tool}"]
             
-            # Exécution avec timeout
-            process = await asyncio.create_subprocess_exec(
-                *commands[tool],
-                stdout=asyncio.subprocess.PIPE,
-                stderr=asyncio.subprocess.PIPE
-            )
+            command, timeout = commands[tool], 10
+            image = self.container.image_for(tool) if self.container is not None else None
+            if image:
+                command, timeout = self.container.wrap(image, command, temp_file), ContainerBackend.TIMEOUT
+                logger.debug("running in container %s", image, extra={'tool': tool, 'file_path': file_path})
             
-            stdout, stderr = await asyncio.wait_for(process.communicate(), timeout=10)
+            # Exécution avec timeout
+            async with self.subprocess_limit:
+                started = time.perf_counter()
+                self.subprocess_calls += 1
+                with tracing.span('subprocess', tool=tool, image=image, bytes=len(content.encode('utf-8'))) as span:
+                    try:
+                        process = await platform_subprocess_exec(
+                            *command,
+                            stdout=asyncio.subprocess.PIPE,
+                            stderr=asyncio.subprocess.PIPE
+                        )
+                        
+                        stdout, stderr = await asyncio.wait_for(process.communicate(), timeout=timeout)
+                        tracing.annotate(span, exit_code=process.returncode)
+                    finally:
+                        self.subprocess_time += time.perf_counter() - started
             
             # Lecture du contenu corrigé
             if os.path.exists(temp_file):
                 with open(temp_file, 'r', encoding='utf-8') as f:
                     fixed_content = f.read()
                 
-                success = process.returncode == 0
+                success = process.returncode in accepted_codes.get(tool, (0,))
                 errors = stderr.decode().split('\n') if stderr else []
+                if process.returncode < 0:
+                    # Outil tué par un signal (segfault, OOM)
+                    self.crashes.add(file_path)
+                if not success:
+                    logger.warning("tool exited with code %s", process.returncode,
+                                   extra={'tool': tool, 'file_path': file_path})
                 
                 return success, fixed_content, errors
             
             return False, content, ["Failed to process file"]
             
         except asyncio.TimeoutError:
+            await self._kill_process(process)
+            self.crashes.add(file_path)
+            logger.warning("tool execution timeout", extra={'tool': tool, 'file_path': file_path})
             return False, content, ["Tool execution timeout"]
+        except asyncio.CancelledError:
+            # Annulation (Ctrl-C, requête abandonnée) - pas de processus orphelin
+            await self._kill_process(process)
+            raise
+        except WorkspaceQuotaError as e:
+            logger.warning("%s", e, extra={'tool': tool, 'file_path': file_path})
+            return False, content, [str(e)]
         except Exception as e:
+            self.crashes.add(file_path)
+            logger.error("tool execution failed: %s", e, extra={'tool': tool, 'file_path': file_path})
             return False, content, [str(e)]
         finally:
             # Nettoyage
-            if os.path.exists(temp_file):
-                os.remove(temp_file)
+            workspace.release(temp_file)
+    
+    async def _kill_process(self, process: Optional[asyncio.subprocess.Process]):
+        """Arrêt d'un sous-processus encore actif"""
+        if process is None or process.returncode is not None:
+            return
+        try:
+            process.kill()
+            await process.wait()
+        except ProcessLookupError:
+            pass
+    
+    def cleanup(self):
+        """Suppression du dossier temporaire"""
+        shutil.rmtree(self.temp_dir, ignore_errors=True)
+
+class ESLintRunner:
+    """🟨 ESLINT - `eslint --fix` avec la configuration du projet"""
+    
+    CONFIG_FILES = ('eslint.config.js', 'eslint.config.mjs', 'eslint.config.cjs',
+                    '.eslintrc.js', '.eslintrc.cjs', '.eslintrc.yaml', '.eslintrc.yml',
+                    '.eslintrc.json', '.eslintrc')
+    # Configurations exécutables : chargées par ESLint, donc code du repository
+    JS_CONFIG_FILES = ('eslint.config.js', 'eslint.config.mjs', 'eslint.config.cjs',
+                       '.eslintrc.js', '.eslintrc.cjs')
+    LANGUAGES = ('javascript', 'typescript')
+    
+    def __init__(self, shell_champion: ShellChampion):
+        self.shell_champion = shell_champion
+        self.refused_configs: Set[Path] = set()
+    
+    def find_config(self, file_path: str, allow_js_config: bool = False) -> Optional[Path]:
+        """Recherche de la configuration ESLint en remontant depuis le fichier
+        
+        Sans `allow_js_config`, seules les configurations JSON/YAML sont acceptées : un
+        eslint.config.js ou .eslintrc.js sur le chemin (ESLint charge aussi les parents) désactive ESLint.
+        """
+        path = Path(file_path)
+        if not path.is_file():
+            # Fichier uploadé, sans projet sur disque
+            return None
+        
+        found = None
+        for directory in path.resolve().parents:
+            if not allow_js_config:
+                js_config = next((name for name in self.JS_CONFIG_FILES if (directory / name).is_file()), None)
+                if js_config is not None:
+                    if directory / js_config not in self.refused_configs:
+                        self.refused_configs.add(directory / js_config)
+                        logger.warning("eslint skipped: executable config %s requires --allow-repo-plugins",
+                                       directory / js_config, extra={'tool': 'eslint'})
+                    return None
+            if found is None and self._has_config(directory):
+                found = directory
+                if allow_js_config:
+                    return found
+            if (directory / '.git').exists():
+                break
+        return found
+    
+    def _has_config(self, directory: Path) -> bool:
+        if any((directory / name).is_file() for name in self.CONFIG_FILES):
+            return True
+        package_json = directory / 'package.json'
+        if package_json.is_file():
+            try:
+                with open(package_json, 'r', encoding='utf-8') as f:
+                    return 'eslintConfig' in json.load(f)
+            except (ValueError, IOError):
+                pass
+        return False
+    
+    async def run(self, file_path: str, content: str, language: str,
+                  allow_js_config: bool = False) -> Optional[FixOutcome]:
+        """Exécution sur le contenu via stdin - None si ESLint ne s'applique pas"""
+        if language not in self.LANGUAGES or not self.shell_champion.available_tools.get('eslint', False):
+            return None
+        project_dir = self.find_config(file_path, allow_js_config)
+        if project_dir is None:
+            return None
+        
+        process = None
+        try:
+            process = await platform_subprocess_exec(
+                'eslint', '--stdin', '--stdin-filename', str(Path(file_path).resolve()),
+                '--fix-dry-run', '--format', 'json',
+                cwd=str(project_dir),
+                stdin=asyncio.subprocess.PIPE,
+                stdout=asyncio.subprocess.PIPE,
+                stderr=asyncio.subprocess.PIPE
+            )
+            stdout, stderr = await asyncio.wait_for(process.communicate(content.encode('utf-8')), timeout=30)
+        except asyncio.TimeoutError:
+            await self.shell_champion._kill_process(process)
+            logger.warning("tool execution timeout", extra={'tool': 'eslint', 'file_path': file_path})
+            return FixOutcome(content, [], ["ESLint execution timeout"], False)
+        except asyncio.CancelledError:
+            await self.shell_champion._kill_process(process)
+            raise
+        
+        # 0 = propre, 1 = erreurs restantes, 2 = erreur de configuration / crash
+        if process.returncode not in (0, 1):
+            message = stderr.decode(errors='replace').strip()
+            logger.warning("eslint failed: %s", message, extra={'tool': 'eslint', 'file_path': file_path})
+            return FixOutcome(content, [], [f"ESLint failed: {message}"], False)
+        
+        try:
+            report = json.loads(stdout.decode('utf-8'))[0]
+        except (ValueError, IndexError) as e:
+            return FixOutcome(content, [], [f"Invalid ESLint output: {e}"], False)
+        
+        fixed_content = report.get('output', content)
+        fixes = ["Applied eslint --fix"] if fixed_content != content else []
+        errors = [f"Line {m.get('line', 0)}: {m.get('message', '')} ({m.get('ruleId') or 'eslint'})"
+                  for m in report.get('messages', [])]
+        return FixOutcome(fixed_content, fixes, errors, True)
+
+# Sidecar Node : service de langage TypeScript du projet → codefixes
+TS_CODEFIX_SIDECAR = r'''
+const fs = require('fs');
+const path = require('path');
+const projectDir = process.argv[1];
+const typescriptBase = process.argv[2];
+let ts;
+try {
+    ts = require(require.resolve('typescript', { paths: [typescriptBase] }));
+} catch (e) {
+    process.stderr.write('typescript not installed in ' + typescriptBase);
+    process.exit(3);
+}
+const input = JSON.parse(fs.readFileSync(0, 'utf8'));
+const allowed = new Set(input.fixes);
+const contents = new Map(Object.entries(input.files));
+const versions = new Map();
+
+let options = { noEmit: true };
+let fileNames = [];
+const configPath = ts.findConfigFile(projectDir, ts.sys.fileExists, 'tsconfig.json');
+if (configPath) {
+    const config = ts.readConfigFile(configPath, ts.sys.readFile);
+    const parsed = ts.parseJsonConfigFileContent(config.config, ts.sys, path.dirname(configPath));
+    options = parsed.options;
+    fileNames = parsed.fileNames;
+}
+for (const file of contents.keys()) {
+    if (!fileNames.includes(file)) fileNames.push(file);
+}
+
+const host = {
+    getScriptFileNames: () => fileNames,
+    getScriptVersion: (f) => String(versions.get(f) || 0),
+    getScriptSnapshot: (f) => {
+        const text = contents.has(f) ? contents.get(f) : (fs.existsSync(f) ? fs.readFileSync(f, 'utf8') : undefined);
+        return text === undefined ? undefined : ts.ScriptSnapshot.fromString(text);
+    },
+    getCurrentDirectory: () => projectDir,
+    getCompilationSettings: () => options,
+    getDefaultLibFileName: (o) => ts.getDefaultLibFilePath(o),
+    fileExists: ts.sys.fileExists,
+    readFile: ts.sys.readFile,
+    readDirectory: ts.sys.readDirectory,
+    directoryExists: ts.sys.directoryExists,
+    getDirectories: ts.sys.getDirectories,
+};
+const service = ts.createLanguageService(host, ts.createDocumentRegistry());
+
+const diagnosticsOf = (file) => service.getSyntacticDiagnostics(file).concat(service.getSemanticDiagnostics(file));
+const lineOf = (file, pos) => service.getProgram().getSourceFile(file).getLineAndCharacterOfPosition(pos).line + 1;
+
+const result = {};
+for (const file of contents.keys()) {
+    const applied = [];
+    for (let pass = 0; pass < 50; pass++) {
+        let changed = false;
+        for (const d of diagnosticsOf(file)) {
+            if (d.start === undefined) continue;
+            const fix = service.getCodeFixesAtPosition(file, d.start, d.start + d.length, [d.code], {}, {})
+                .find((f) => allowed.has(f.fixName) && f.changes.every((c) => c.fileName === file));
+            if (!fix) continue;
+
+            const line = lineOf(file, d.start);
+            let text = contents.get(file);
+            const edits = fix.changes.flatMap((c) => c.textChanges).sort((a, b) => b.span.start - a.span.start);
+            for (const e of edits) {
+                text = text.slice(0, e.span.start) + e.newText + text.slice(e.span.start + e.span.length);
+            }
+            contents.set(file, text);
+            versions.set(file, (versions.get(file) || 0) + 1);
+            applied.push({ line, fix: fix.fixName, description: fix.description });
+            changed = true;
+            break;
+        }
+        if (!changed) break;
+    }
+    const remaining = diagnosticsOf(file).map((d) => ({
+        line: d.start === undefined ? 0 : lineOf(file, d.start),
+        code: d.code,
+        message: ts.flattenDiagnosticMessageText(d.messageText, ' '),
+    }));
+    result[file] = { content: contents.get(file), applied, diagnostics: remaining };
+}
+process.stdout.write(JSON.stringify(result));
+'''
+
+class TypeScriptCodeFixer:
+    """🔷 TYPESCRIPT - Diagnostics du compilateur et codefixes du service de langage"""
+    
+    DEFAULT_FIXES = ['addMissingAwait', 'fixMissingMember', 'inferFromUsage']
+    # Installation TypeScript de l'opérateur (node_modules à côté d'app.py ou NODE_PATH)
+    OPERATOR_TYPESCRIPT_BASE = Path(__file__).resolve().parent
+    
+    def __init__(self, shell_champion: ShellChampion, fixes: Optional[List[str]] = None, timeout: float = 120):
+        self.shell_champion = shell_champion
+        self.fixes = fixes or []
+        self.timeout = timeout
+    
+    @property
+    def enabled(self) -> bool:
+        return bool(self.fixes)
+    
+    async def run(self, project_dir: Path, results: List[FixResult], allow_repo_exec: bool = False):
+        """Passe sur les résultats TypeScript (contenu corrigé mis à jour en place)
+        
+        Le module typescript du projet n'est chargé qu'avec `allow_repo_exec` ; sinon celui de l'opérateur.
+        """
+        targets = {str(Path(r.file_path).resolve()): r for r in results
+                   if r.language == 'typescript' and r.fixed_content is not None}
+        if not self.enabled or not targets or not shutil.which('node'):
+            return
+        
+        request = json.dumps({
+            'fixes': self.fixes,
+            'files': {path: r.fixed_content for path, r in targets.items()}
+        }).encode('utf-8')
+        project_dir = Path(project_dir).resolve()
+        typescript_base = project_dir if allow_repo_exec else self.OPERATOR_TYPESCRIPT_BASE
+        process = None
+        
+        try:
+            process = await platform_subprocess_exec(
+                'node', '-e', TS_CODEFIX_SIDECAR, str(project_dir), str(typescript_base),
+                stdin=asyncio.subprocess.PIPE,
+                stdout=asyncio.subprocess.PIPE,
+                stderr=asyncio.subprocess.PIPE
+            )
+            stdout, stderr = await asyncio.wait_for(process.communicate(request), timeout=self.timeout)
+        except asyncio.TimeoutError:
+            await self.shell_champion._kill_process(process)
+            logger.warning("typescript codefix timeout", extra={'tool': 'tsc'})
+            return
+        except asyncio.CancelledError:
+            await self.shell_champion._kill_process(process)
+            raise
+        
+        if process.returncode != 0:
+            logger.warning("typescript codefix pass failed: %s", stderr.decode(errors='replace').strip(),
+                           extra={'tool': 'tsc'})
+            return
+        
+        try:
+            report = json.loads(stdout.decode('utf-8'))
+        except ValueError as e:
+            logger.warning("invalid typescript codefix output: %s", e, extra={'tool': 'tsc'})
+            return
+        
+        for path, file_report in report.items():
+            result = targets.get(path)
+            if result is None:
+                continue
+            result.fixed_content = file_report['content']
+            for fix in file_report['applied']:
+                result.fixes_applied.append(f"Fixed {fix['fix']} on line {fix['line']}: {fix['description']}")
+            for diagnostic in file_report['diagnostics']:
+                result.original_errors.append(
+                    f"Line {diagnostic['line']}: TS{diagnostic['code']} {diagnostic['message']}")
 
 class LanguageDetector:
     """🎯 DÉTECTEUR INTELLIGENT DE LANGAGE"""
     
+    # Fichiers sans extension (ou à extension trompeuse) reconnus par leur nom exact
+    FILENAMES = {
+        'package.json': 'manifest',
+        'go.mod': 'manifest',
+        'Cargo.toml': 'manifest',
+        'pyproject.toml': 'manifest',
+        'pom.xml': 'manifest',
+        'pubspec.yaml': 'manifest',
+        # Starlark (Bazel, Buck) : buildifier si disponible
+        'BUILD': 'starlark',
+        'BUILD.bazel': 'starlark',
+        'BUCK': 'starlark',
+        'WORKSPACE': 'starlark',
+        'WORKSPACE.bazel': 'starlark',
+        'MODULE.bazel': 'starlark',
+        'Makefile': 'makefile',
+        'makefile': 'makefile',
+        'GNUmakefile': 'makefile',
+        'CMakeLists.txt': 'cmake',
+        # Hygiène seule
+        'Dockerfile': 'text',
+        'Containerfile': 'text',
+        'Jenkinsfile': 'text',
+        'Rakefile': 'text',
+        'Gemfile': 'text'
+    }
+    # Variantes suffixées : Dockerfile.prod, Jenkinsfile.release
+    FILENAME_PREFIXES = ('Dockerfile.', 'Containerfile.', 'Jenkinsfile.')
+    OBJECTIVE_C = re.compile(r'^\s*(#import|@interface|@implementation)\b', re.MULTILINE)
+    # Extensions doubles, prioritaires sur la dernière extension
+    FILENAME_SUFFIXES = {'.gradle.kts': 'gradle'}
+    
+    @classmethod
+    def special_filename(cls, file_name: str) -> Optional[str]:
+        """Langage d'un fichier reconnu par son nom, None sinon"""
+        if file_name in cls.FILENAMES:
+            return cls.FILENAMES[file_name]
+        if file_name.startswith(cls.FILENAME_PREFIXES):
+            return 'text'
+        for suffix, language in cls.FILENAME_SUFFIXES.items():
+            if file_name.endswith(suffix):
+                return language
+        return None
+    
     def __init__(self):
         self.extension_map = {
             '.py': 'python',
@@ -147,7 +1339,61 @@ class LanguageDetector:
             '.cxx': 'cpp',
             '.c': 'c',
             '.h': 'c',
-            '.hpp': 'cpp'
+            '.hpp': 'cpp',
+            '.html': 'html',
+            '.htm': 'html',
+            '.gohtml': 'html',
+            '.tmpl': 'html',
+            '.j2': 'html',
+            '.jinja': 'html',
+            '.jinja2': 'html',
+            '.erb': 'html',
+            '.proto': 'protobuf',
+            '.bzl': 'starlark',
+            '.star': 'starlark',
+            '.mk': 'makefile',
+            '.cmake': 'cmake',
+            '.gradle': 'gradle',
+            '.r': 'r',
+            '.jl': 'julia',
+            '.m': 'matlab',
+            '.lua': 'lua',
+            '.pl': 'perl',
+            '.pm': 'perl',
+            '.t': 'perl',
+            '.ex': 'elixir',
+            '.exs': 'elixir',
+            '.erl': 'erlang',
+            '.hrl': 'erlang',
+            '.hs': 'haskell',
+            '.zig': 'zig',
+            '.nim': 'nim',
+            '.nims': 'nim',
+            '.dart': 'dart',
+            '.scala': 'scala',
+            '.sbt': 'scala',
+            '.graphql': 'graphql',
+            '.gql': 'graphql',
+            '.ipynb': 'jupyter',
+            # Fichiers texte : hygiène seule (pseudo-langage "text")
+            '.txt': 'text',
+            '.md': 'text',
+            '.markdown': 'text',
+            '.rst': 'text',
+            '.yml': 'text',
+            '.yaml': 'text',
+            '.json': 'text',
+            '.toml': 'text',
+            '.ini': 'text',
+            '.cfg': 'text',
+            '.sh': 'text',
+            '.css': 'text',
+            '.xml': 'xml',
+            '.xsd': 'xml',
+            '.xsl': 'xml',
+            '.xslt': 'xml',
+            '.plist': 'xml',
+            '.sql': 'text'
         }
         
         self.content_patterns = {
@@ -158,10 +1404,21 @@ class LanguageDetector:
             'java': [r'^\s*public\s+class', r'^\s*package\s+', r'^\s*import\s+'],
         }
     
+    def extension_for(self, language: str) -> Optional[str]:
+        """Extension canonique d'un langage (première déclarée), None si inconnu"""
+        return next((ext for ext, lang in self.extension_map.items() if lang == language), None)
+    
     def detect_language(self, file_path: str, content: str = None) -> str:
         """Détection intelligente du langage de programmation"""
+        # Détection par nom de fichier
+        language = self.special_filename(Path(file_path).name)
+        if language is not None:
+            return language
+        
         # Détection par extension
         file_ext = Path(file_path).suffix.lower()
+        if file_ext == '.m' and content and self.OBJECTIVE_C.search(content):
+            return 'unknown'  # Objective-C, pas MATLAB
         if file_ext in self.extension_map:
             return self.extension_map[file_ext]
         
@@ -174,78 +1431,768 @@ class LanguageDetector:
                     return lang
         
         return 'unknown'
+    
+    def language_tree(self, repo_path: Path, walk: Optional['WalkPolicy'] = None) -> Dict[str, Any]:
+        """Langages par répertoire (nombres de fichiers cumulés, sous-répertoires imbriqués)"""
+        tree = {'path': '', 'files': 0, 'languages': {}, 'directories': {}}
+        for indexed in RepositoryIndex.build(repo_path, walk=walk).files:
+            language = self.detect_language(str(indexed.path))
+            if language == 'unknown':
+                continue
+            node = tree
+            parts = Path(indexed.relative_path).as_posix().split('/')
+            for depth in range(len(parts)):
+                if depth:
+                    node = node['directories'].setdefault(parts[depth - 1], {
+                        'path': '/'.join(parts[:depth]), 'files': 0, 'languages': {}, 'directories': {}})
+                node['files'] += 1
+                node['languages'][language] = node['languages'].get(language, 0) + 1
+        return tree
 
-class SyntaxAnalyzer:
-    """🧠 ANALYSEUR INTELLIGENT DE SYNTAXE"""
+class ContentSniffer:
+    """👃 RENIFLAGE DU CONTENU - Fichiers dont le contenu contredit l'extension
     
-    def __init__(self):
-        self.pattern_cache = {}
-        
-        # Patterns de correction par langage
-        self.fix_patterns = {
-            'python': {
-                'missing_colon': {
-                    'pattern': r'(if|elif|else|for|while|def|class|try|except|finally|with)\s+[^:]*$',
-                    'fix': lambda line: line.rstrip() + ':',
-                    'description': 'Missing colon'
-                },
-                'print_parentheses': {
-                    'pattern': r'print\s+[^(].*[^)]$',
-                    'fix': lambda line: re.sub(r'print\s+(.+)', r'print(\1)', line),
-                    'description': 'Print statement needs parentheses'
-                },
-                'indentation': {
-                    'pattern': r'^\s*(\S.*)',
-                    'fix': self._fix_python_indentation,
-                    'description': 'Indentation error'
-                }
-            },
-            'javascript': {
-                'missing_semicolon': {
-                    'pattern': r'[^;{}\s]$',
-                    'fix': lambda line: line.rstrip() + ';',
-                    'description': 'Missing semicolon'
-                },
-                'var_to_const': {
-                    'pattern': r'var\s+(\w+)\s*=\s*["\'\d\[\{]',
-                    'fix': lambda line: re.sub(r'var\s+', 'const ', line),
-                    'description': 'Use const instead of var'
-                },
-                'double_equals': {
-                    'pattern': r'([^=!])===?([^=])',
-                    'fix': lambda line: re.sub(r'([^=!])===?([^=])', r'\1===\2', line),
-                    'description': 'Use strict equality'
-                }
-            },
-            'go': {
-                'unused_import': {
-                    'pattern': r'import\s+"[^"]*"\s*$',
-                    'fix': self._fix_go_imports,
-                    'description': 'Unused import'
-                },
-                'gofmt_spacing': {
-                    'pattern': r'(\w+)\s*{\s*$',
-                    'fix': lambda line: re.sub(r'(\w+)\s*{\s*$', r'\1 {', line),
-                    'description': 'Go formatting'
-                }
-            }
-        }
+    Un .py qui est un pickle ou un .js qui est une source map ne doit pas
+    passer dans les transformations de texte.
+    """
     
-    def _fix_python_indentation(self, line: str) -> str:
-        """Correction intelligente de l'indentation Python"""
-        if line.strip():
-            # Logique simplifiée d'indentation
-            if re.match(r'^\s*(class|def|if|for|while|try|except|finally|with)', line):
-                return '    ' + line.strip()
-            elif re.match(r'^\s*(elif|else|except|finally)', line):
-                return line.strip()
-            else:
-                return '    ' + line.strip()
-        return line
+    # Signatures en tête de fichier
+    MAGIC = (
+        (b'\x89PNG\r\n\x1a\n', 'PNG image'),
+        (b'GIF87a', 'GIF image'),
+        (b'GIF89a', 'GIF image'),
+        (b'\xff\xd8\xff', 'JPEG image'),
+        (b'%PDF-', 'PDF document'),
+        (b'PK\x03\x04', 'ZIP archive'),
+        (b'\x1f\x8b', 'gzip archive'),
+        (b'\x7fELF', 'ELF binary'),
+        (b'\xca\xfe\xba\xbe', 'Java class file'),
+        (b'SQLite format 3\x00', 'SQLite database'),
+        (b'bplist00', 'binary property list')
+    )
+    HEAD_SIZE = 8192
+    # Pickle protocole 0/1 (texte) : (dp0 / (lp0 ... terminé par '.'
+    TEXT_PICKLE = re.compile(r'\A\((dp|lp)\d+\n')
+    SOURCE_MAP_LANGUAGES = {'javascript', 'typescript'}
     
-    def _fix_go_imports(self, line: str) -> str:
-        """Correction des imports Go"""
-        return line  # Placeholder - gofmt gère cela mieux
+    @classmethod
+    def sniff_bytes(cls, data: bytes) -> Optional[str]:
+        """Nature d'un contenu binaire, None s'il ressemble à du texte"""
+        head = data[:cls.HEAD_SIZE]
+        for magic, kind in cls.MAGIC:
+            if head.startswith(magic):
+                return kind
+        if len(head) > 1 and head[0] == 0x80 and 2 <= head[1] <= 5:
+            return 'Python pickle'
+        if b'\x00' in head:
+            return 'binary data'
+        return None
+    
+    @classmethod
+    def sniff_text(cls, content: str, language: str) -> Optional[str]:
+        """Nature d'un contenu décodé qui ne correspond pas au langage détecté"""
+        head = content[:cls.HEAD_SIZE]
+        if '\x00' in head:
+            return 'binary data'
+        if language == 'python' and cls.TEXT_PICKLE.match(head) and content.rstrip().endswith('.'):
+            return 'Python pickle'
+        if language in cls.SOURCE_MAP_LANGUAGES and head.lstrip().startswith('{') and '"mappings"' in head:
+            try:
+                data = json.loads(content)
+            except ValueError:
+                return None
+            if isinstance(data, dict) and 'mappings' in data and 'version' in data:
+                return 'source map'
+        return None
+    
+    @classmethod
+    def decode_error(cls, file_path: str, data: bytes, error: UnicodeDecodeError) -> FixerError:
+        """Erreur d'un fichier non décodable : contenu reconnu ou simple échec de décodage"""
+        kind = cls.sniff_bytes(data)
+        if kind is not None:
+            return cls.mismatch(file_path, kind)
+        return ParseFailedError(f"Cannot decode file: {error}")
+    
+    @staticmethod
+    def mismatch(file_path: str, kind: str) -> ContentMismatchError:
+        extension = Path(file_path).suffix or Path(file_path).name
+        return ContentMismatchError(f"Content is {kind}, not {extension} - skipped")
+
+def read_head(file_path: Union[str, Path]) -> bytes:
+    """Premiers octets d'un fichier (reniflage), vide si illisible"""
+    try:
+        return filesystem_var.get().read_bytes(file_path, ContentSniffer.HEAD_SIZE)
+    except OSError:
+        return b''
+
+@dataclass
+class IndexedFile:
+    """Entrée de l'index du repository"""
+    path: Path
+    relative_path: str
+    extension: str
+    size: int
+
+class WalkPolicy:
+    """🚶 PARCOURS - Dossiers ignorés et limites de profondeur / nombre de fichiers
+    
+    Configuration (.syntaxfixer.yml) :
+        walk:
+          skip_dirs: {add: [vendor, dist], remove: [__pycache__]}
+          max_depth: 6
+          max_files: 20000
+    """
+    
+    DEFAULT_SKIP_DIRS = frozenset({'node_modules', '__pycache__'})
+    
+    def __init__(self, skip_dirs: Optional[Set[str]] = None, max_depth: Optional[int] = None,
+                 max_files: Optional[int] = None):
+        self.skip_dirs = set(self.DEFAULT_SKIP_DIRS if skip_dirs is None else skip_dirs)
+        self.max_depth = max_depth
+        self.max_files = max_files
+    
+    @classmethod
+    def from_config(cls, value: Any) -> 'WalkPolicy':
+        if not isinstance(value, dict):
+            return cls()
+        skip_dirs = set(cls.DEFAULT_SKIP_DIRS)
+        changes = value.get('skip_dirs') or {}
+        if isinstance(changes, list):
+            # Liste simple : ajouts aux valeurs par défaut
+            changes = {'add': changes}
+        skip_dirs |= set(changes.get('add') or [])
+        skip_dirs -= set(changes.get('remove') or [])
+        limits = {}
+        for key in ('max_depth', 'max_files'):
+            try:
+                limits[key] = int(value[key]) if value.get(key) is not None else None
+            except (TypeError, ValueError):
+                logger.error("invalid walk.%s: %r", key, value[key])
+                limits[key] = None
+        return cls(skip_dirs, **limits)
+    
+    def skips(self, name: str) -> bool:
+        """Dossier caché ou ignoré - élagué sans y descendre"""
+        return name.startswith('.') or name in self.skip_dirs
+    
+    def allows(self, relative_path: str) -> bool:
+        """Chemin de fichier relatif hors des dossiers ignorés et dans la profondeur maximale"""
+        parts = Path(relative_path).as_posix().split('/')
+        if self.max_depth is not None and len(parts) - 1 > self.max_depth:
+            return False
+        return not any(self.skips(part) for part in parts)
+    
+    def limit(self, items: List[Any], path: Callable[[Any], str]) -> List[Any]:
+        """Troncature au nombre maximal de fichiers, les moins profonds d'abord (ordre des chemins conservé)"""
+        if self.max_files is None or len(items) <= self.max_files:
+            return items
+        logger.warning("walk limited to %d of %d files", self.max_files, len(items))
+        kept = sorted(items, key=lambda item: (path(item).count('/'), path(item)))[:self.max_files]
+        return sorted(kept, key=path)
+
+class RepositoryIndex:
+    """🗂️ INDEX DU REPOSITORY - Un seul parcours par exécution"""
+
+    SUPPORTED_EXTENSIONS = {'.py', '.js', '.jsx', '.ts', '.tsx', '.go', '.rs', '.java',
+                            '.cpp', '.cc', '.cxx', '.c', '.h',
+                            '.html', '.htm', '.gohtml', '.tmpl', '.j2', '.jinja', '.jinja2', '.erb',
+                            '.proto', '.bzl', '.star', '.mk', '.cmake', '.gradle', '.r', '.jl', '.m', '.lua', '.pl', '.pm', '.t',
+                            '.ex', '.exs', '.erl', '.hrl', '.hs', '.zig', '.nim', '.nims', '.dart', '.scala', '.sbt', '.graphql', '.gql', '.ipynb',
+                            '.txt', '.md', '.markdown', '.rst', '.yml', '.yaml', '.json', '.toml',
+                            '.ini', '.cfg', '.sh', '.css', '.xml', '.xsd', '.xsl', '.xslt', '.plist', '.sql'}
+
+    def __init__(self, root: Path, files: List[IndexedFile]):
+        self.root = root
+        self.files = files
+
+    @classmethod
+    def build(cls, root: Path, max_workers: int = 8, walk: Optional[WalkPolicy] = None) -> 'RepositoryIndex':
+        """Parcours unique de l'arborescence (os.scandir, répertoires en parallèle)"""
+        root = Path(root)
+        walk = walk or WalkPolicy()
+        files: List[IndexedFile] = []
+        pending = [root]
+        depth = 0
+
+        with ThreadPoolExecutor(max_workers=max_workers) as executor:
+            while pending:
+                # Chaque niveau de répertoires est scanné en parallèle
+                next_level = []
+                futures = [executor.submit(contextvars.copy_context().run, cls._scan_dir, (root, d, walk))
+                           for d in pending]
+                for future in futures:
+                    sub_dirs, sub_files = future.result()
+                    files.extend(sub_files)
+                    next_level.extend(sub_dirs)
+                depth += 1
+                if walk.max_depth is not None and depth > walk.max_depth:
+                    break
+                if walk.max_files is not None and len(files) >= walk.max_files:
+                    # Limite atteinte : niveaux plus profonds non parcourus
+                    break
+                pending = next_level
+
+        files.sort(key=lambda f: f.relative_path)
+        return cls(root, walk.limit(files, lambda f: Path(f.relative_path).as_posix()))
+
+    @classmethod
+    def _scan_dir(cls, job: Tuple[Path, Path, WalkPolicy]) -> Tuple[List[Path], List[IndexedFile]]:
+        """Scan d'un répertoire - élagage des dossiers ignorés sans y descendre"""
+        root, directory, walk = job
+        sub_dirs, found = [], []
+        try:
+            with os.scandir(directory) as entries:
+                for entry in entries:
+                    if entry.name.startswith('.'):
+                        continue
+                    if entry.is_dir(follow_symlinks=False):
+                        if not walk.skips(entry.name):
+                            sub_dirs.append(Path(entry.path))
+                    elif entry.is_file(follow_symlinks=False):
+                        ext = Path(entry.name).suffix.lower()
+                        found.append(IndexedFile(
+                            path=Path(entry.path),
+                            relative_path=os.path.relpath(entry.path, root),
+                            extension=ext,
+                            size=entry.stat(follow_symlinks=False).st_size
+                        ))
+        except OSError as e:
+            # Répertoire illisible - ignoré
+            logger.warning("cannot scan directory: %s", e, extra={'file_path': str(directory)})
+        return sub_dirs, found
+
+    def supported_files(self, extra_extensions: Set[str] = frozenset(),
+                        include: Optional[List[str]] = None) -> List[IndexedFile]:
+        """Fichiers traitables par les correcteurs (extensions des plugins incluses, globs optionnels)"""
+        supported = self.SUPPORTED_EXTENSIONS | set(extra_extensions)
+        return [f for f in self.files
+                if (f.extension in supported or LanguageDetector.special_filename(f.path.name) is not None)
+                and (not include or path_matches(f.relative_path, include))]
+
+    def by_extension(self, *extensions: str) -> List[IndexedFile]:
+        """Fichiers filtrés par extension"""
+        wanted = {e.lower() for e in extensions}
+        return [f for f in self.files if f.extension in wanted]
+
+def path_matches(file_path: str, patterns: List[str]) -> bool:
+    """Correspondance d'un chemin avec des globs (`**/` optionnel, ancrage sur n'importe quel suffixe)"""
+    parts = Path(file_path).as_posix().split('/')
+    candidates = ['/'.join(parts[i:]) for i in range(len(parts))]
+    for pattern in patterns:
+        variants = {pattern, pattern.replace('**/', '')}
+        if any(fnmatch.fnmatch(candidate, variant) for candidate in candidates for variant in variants):
+            return True
+    return False
+
+# Marqueurs de commentaire ligne par langage (masquage des règles personnalisées)
+COMMENT_MARKERS = {
+    'python': '#',
+    'javascript': '//', 'typescript': '//', 'go': '//', 'rust': '//',
+    'java': '//', 'cpp': '//', 'c': '//',
+    'r': '#', 'perl': '#'
+}
+
+def masked_spans(line: str, language: str, mask: Set[str]) -> List[Tuple[int, int]]:
+    """Plages d'une ligne couvertes par des chaînes ou commentaires"""
+    spans = []
+    comment = COMMENT_MARKERS.get(language)
+    i = 0
+    while i < len(line):
+        char = line[i]
+        if char in ('"', "'", '`'):
+            end = i + 1
+            while end < len(line) and line[end] != char:
+                end += 2 if line[end] == '\\' else 1
+            end = min(end + 1, len(line))
+            if 'strings' in mask:
+                spans.append((i, end))
+            i = end
+            continue
+        if comment and line.startswith(comment, i):
+            if 'comments' in mask:
+                spans.append((i, len(line)))
+            break
+        i += 1
+    return spans
+
+@dataclass
+class CustomRule:
+    """Règle find/replace définie dans la configuration"""
+    id: str
+    language: str
+    pattern: re.Pattern
+    replace: str
+    description: str
+    paths: List[str]
+    mask: Set[str]
+    
+    @classmethod
+    def from_config(cls, entry: Dict[str, Any]) -> 'CustomRule':
+        """Construction et validation d'une entrée `rules:` (snippet de test obligatoire)"""
+        mask = entry.get('mask', ['strings', 'comments'])
+        rule = cls(
+            id=entry['id'],
+            language=entry['language'],
+            pattern=re.compile(entry['pattern']),
+            replace=entry.get('replace', ''),
+            description=entry.get('description', entry['id']),
+            paths=list(entry.get('paths', [])),
+            mask=set(mask)
+        )
+        
+        test = entry.get('test')
+        if not isinstance(test, dict) or 'input' not in test or 'expected' not in test:
+            raise ValueError("a test snippet with 'input' and 'expected' is required")
+        actual, _ = rule.apply(test['input'])
+        if actual != test['expected']:
+            raise ValueError(f"test snippet failed: expected {test['expected']!r}, got {actual!r}")
+        return rule
+    
+    def applies_to(self, language: str, file_path: Optional[str]) -> bool:
+        if self.language != language:
+            return False
+        return not self.paths or (file_path is not None and path_matches(file_path, self.paths))
+    
+    def apply(self, content: str) -> Tuple[str, List[int]]:
+        """Application ligne par ligne, hors zones masquées - retourne les lignes modifiées"""
+        lines = content.split('\n')
+        changed = []
+        for i, line in enumerate(lines):
+            spans = masked_spans(line, self.language, self.mask)
+            pieces, last = [], 0
+            for match in self.pattern.finditer(line):
+                if any(start < match.end() and match.start() < end for start, end in spans):
+                    continue
+                pieces.append(line[last:match.start()])
+                pieces.append(match.expand(self.replace))
+                last = match.end()
+            if pieces:
+                new_line = ''.join(pieces) + line[last:]
+                if new_line != line:
+                    lines[i] = new_line
+                    changed.append(i + 1)
+        return '\n'.join(lines), changed
+
+# Exécution d'un module WASM sans aucun import : ni système de fichiers, ni réseau, ni horloge.
+# ABI : exports `memory`, `alloc(len) -> ptr`, `fix(ptr, len) -> i64` ((ptr << 32) | len de la sortie UTF-8)
+WASM_RUNNER = r'''
+const fs = require('fs');
+const chunks = [];
+process.stdin.on('data', chunk => chunks.push(chunk));
+process.stdin.on('end', async () => {
+  try {
+    const module = await WebAssembly.compile(fs.readFileSync(process.argv[1]));
+    const imports = WebAssembly.Module.imports(module);
+    if (imports.length) {
+      throw new Error('imports are not allowed: ' + imports.map(i => `${i.module}.${i.name}`).join(', '));
+    }
+    const { exports } = await WebAssembly.instantiate(module, {});
+    if (!(exports.memory instanceof WebAssembly.Memory) || typeof exports.alloc !== 'function'
+        || typeof exports.fix !== 'function') {
+      throw new Error('the module must export memory, alloc and fix');
+    }
+    const input = Buffer.concat(chunks);
+    const pointer = exports.alloc(input.length);
+    new Uint8Array(exports.memory.buffer, pointer, input.length).set(input);
+    const packed = BigInt.asUintN(64, BigInt(exports.fix(pointer, input.length)));
+    const output = new Uint8Array(exports.memory.buffer, Number(packed >> 32n), Number(packed & 0xffffffffn));
+    process.stdout.write(Buffer.from(output));
+  } catch (e) {
+    process.stderr.write(String(e && e.message || e));
+    process.exitCode = 1;
+  }
+});
+'''
+
+class WasmRule:
+    """🧩 RÈGLE WASM - Transformation tierce compilée en WebAssembly, exécutée en bac à sable
+    
+    Le module est instancié par le runtime WebAssembly de Node.js sans aucun import :
+    il ne reçoit que le contenu du fichier et ne peut ni lire de fichiers, ni ouvrir de
+    connexion, ni lancer de processus. Temps d'exécution borné par `timeout`. Snippet de
+    test obligatoire, comme pour les règles `rules:`.
+    """
+    
+    DEFAULT_TIMEOUT = 5.0
+    
+    def __init__(self, id: str, language: str, module: Path, description: str,
+                 paths: List[str], timeout: float = DEFAULT_TIMEOUT):
+        self.id = id
+        self.language = language
+        self.module = module
+        self.description = description
+        self.paths = paths
+        self.timeout = timeout
+    
+    @classmethod
+    def from_config(cls, entry: Dict[str, Any]) -> 'WasmRule':
+        """Construction et validation d'une entrée `wasm_rules:` (module chargé et testé)"""
+        if shutil.which('node') is None:
+            raise ValueError("node is required to run WASM rules")
+        rule = cls(
+            id=entry['id'],
+            language=entry['language'],
+            module=Path(entry['module']),
+            description=entry.get('description', entry['id']),
+            paths=list(entry.get('paths', [])),
+            timeout=float(entry.get('timeout', cls.DEFAULT_TIMEOUT))
+        )
+        if not rule.module.is_file():
+            raise ValueError(f"module not found: {rule.module}")
+        
+        test = entry.get('test')
+        if not isinstance(test, dict) or 'input' not in test or 'expected' not in test:
+            raise ValueError("a test snippet with 'input' and 'expected' is required")
+        actual = rule.run(test['input'])
+        if actual != test['expected']:
+            raise ValueError(f"test snippet failed: expected {test['expected']!r}, got {actual!r}")
+        return rule
+    
+    def applies_to(self, language: str, file_path: Optional[str]) -> bool:
+        if self.language != language:
+            return False
+        return not self.paths or (file_path is not None and path_matches(file_path, self.paths))
+    
+    def run(self, content: str) -> str:
+        """Contenu transformé par le module (ValueError si le module échoue)"""
+        try:
+            completed = subprocess.run(
+                ['node', '-e', WASM_RUNNER, str(self.module.resolve())],
+                input=content.encode('utf-8'), capture_output=True, timeout=self.timeout,
+                cwd=tempfile.gettempdir(), env={'PATH': os.environ.get('PATH', '')}
+            )
+        except subprocess.TimeoutExpired:
+            raise ValueError(f"WASM rule {self.id} timed out after {self.timeout}s")
+        if completed.returncode != 0:
+            raise ValueError(f"WASM rule {self.id} failed: {completed.stderr.decode('utf-8', 'replace').strip()}")
+        return completed.stdout.decode('utf-8')
+    
+    def apply(self, content: str) -> Tuple[str, List[int]]:
+        """Application au fichier entier - retourne les lignes modifiées (numérotation de la sortie)"""
+        try:
+            fixed = self.run(content)
+        except ValueError as e:
+            logger.warning("%s", e, extra={'rule_id': self.id})
+            return content, []
+        matcher = difflib.SequenceMatcher(None, content.split('\n'), fixed.split('\n'), autojunk=False)
+        changed = [line + 1 for tag, _, _, start, end in matcher.get_opcodes() if tag in ('replace', 'insert')
+                   for line in range(start, end)]
+        return fixed, changed
+
+class GoStructTagFixer:
+    """🔷 CORRECTEUR STRUCTURES GO - Alignement et tri des tags (opt-in)"""
+    
+    STRUCT_START = re.compile(r'^\s*(type\s+\w+\s+)?struct\s*{\s*$|^\s*\w+\s+struct\s*{\s*$')
+    TAGGED_FIELD = re.compile(r'^(\s*)(\w+(?:\s*,\s*\w+)*)\s+([^\s`].*?)\s+(`[^`]*`)\s*(//.*)?$')
+    TAG_PAIR = re.compile(r'(\w+):"((?:[^"\\]|\\.)*)"')
+    
+    def __init__(self, align: bool = False, sort: bool = False):
+        self.align = align
+        self.sort = sort
+    
+    @property
+    def enabled(self) -> bool:
+        return self.align or self.sort
+    
+    def fix(self, content: str) -> Tuple[str, List[str]]:
+        """Application aux blocs struct - retourne (contenu, corrections)"""
+        lines = content.split('\n')
+        fixes = []
+        depth = 0
+        run: List[int] = []
+        
+        for i, line in enumerate(lines):
+            if depth == 0:
+                if self.STRUCT_START.match(line):
+                    depth = 1
+                continue
+            
+            stripped = line.strip()
+            if stripped == '}' and depth == 1:
+                fixes.extend(self._flush(lines, run))
+                run = []
+                depth = 0
+                continue
+            
+            depth += stripped.count('{') - stripped.count('}')
+            if depth == 1 and self.TAGGED_FIELD.match(line):
+                if self.sort:
+                    lines[i], sorted_tag = self._sort_tag(line)
+                    if sorted_tag:
+                        fixes.append(f"Fixed sort_struct_tags on line {i+1}")
+                run.append(i)
+            else:
+                fixes.extend(self._flush(lines, run))
+                run = []
+        
+        return '\n'.join(lines), fixes
+    
+    def _sort_tag(self, line: str) -> Tuple[str, bool]:
+        """Tri des clés d'un tag `json:"a" db:"b"`"""
+        match = self.TAGGED_FIELD.match(line)
+        tag = match.group(4)[1:-1]
+        pairs = self.TAG_PAIR.findall(tag)
+        if not pairs or ' '.join(f'{k}:"{v}"' for k, v in pairs) != ' '.join(tag.split()):
+            return line, False
+        
+        sorted_tag = ' '.join(f'{k}:"{v}"' for k, v in sorted(pairs, key=lambda pair: pair[0]))
+        if sorted_tag == tag:
+            return line, False
+        start, end = match.span(4)
+        return line[:start] + f"`{sorted_tag}`" + line[end:], True
+    
+    def _flush(self, lines: List[str], run: List[int]) -> List[str]:
+        """Alignement en colonnes d'une suite de champs tagués (comme gofmt)"""
+        if not self.align or len(run) < 2:
+            return []
+        
+        fields = [self.TAGGED_FIELD.match(lines[i]).groups() for i in run]
+        name_width = max(len(f[1]) for f in fields)
+        type_width = max(len(f[2]) for f in fields)
+        
+        fixes = []
+        for i, (indent, names, field_type, tag, comment) in zip(run, fields):
+            aligned = f"{indent}{names.ljust(name_width)} {field_type.ljust(type_width)} {tag}"
+            if comment:
+                aligned += f" {comment}"
+            if aligned != lines[i]:
+                lines[i] = aligned
+                fixes.append(f"Fixed align_struct_tags on line {i+1}")
+        return fixes
+
+class GoErrorCheckFixer:
+    """🔷 ERREURS GO IGNORÉES - `if err != nil { return ..., err }` après une erreur jamais testée (opt-in)
+    
+    Cas simples uniquement : appel sur une seule ligne affectant `err`, instruction suivante
+    qui n'utilise pas `err`, fonction (sans fonction littérale) dont les résultats non nommés
+    finissent par `error` et ont tous une valeur zéro évidente.
+    """
+    
+    FUNC_START = re.compile(r'^func\s+(?:\([^)]*\)\s*)?\w+(?:\[[^\]]*\])?\s*\((?P<params>[^()]*)\)\s*(?P<results>.*?)\s*{\s*$')
+    ERR_ASSIGN = re.compile(r'^(?P<indent>\s+)(?:\w+\s*,\s*)*err\s*:?=\s*[\w.]+(?:\[[^\]]*\])?\(.*\)\s*$')
+    ERR_USE = re.compile(r'\berr\b')
+    ZERO_VALUES = {'string': '""', 'bool': 'false', 'error': 'nil', 'any': 'nil'}
+    NUMERIC = re.compile(r'^(u?int(8|16|32|64)?|uintptr|float(32|64)|complex(64|128)|byte|rune)$')
+    NIL_PREFIXES = ('*', '[]', 'map[', 'chan ', '<-chan ', 'chan<- ', 'interface{', 'func(')
+    
+    def __init__(self, enabled: bool = False):
+        self.enabled = enabled
+    
+    @classmethod
+    def zero_value(cls, type_name: str) -> Optional[str]:
+        if type_name in cls.ZERO_VALUES:
+            return cls.ZERO_VALUES[type_name]
+        if cls.NUMERIC.match(type_name):
+            return '0'
+        if type_name.startswith(cls.NIL_PREFIXES):
+            return 'nil'
+        # Type nommé (struct, interface, alias...) : valeur zéro inconnue sans analyse des types
+        return None
+    
+    @classmethod
+    def return_statement(cls, results: str) -> Optional[str]:
+        """`return ..., err` pour la liste de résultats d'une signature, None si hors des cas simples"""
+        results = results.strip()
+        if results.startswith('(') and results.endswith(')'):
+            results = results[1:-1]
+        if not results or '(' in results:
+            return None
+        types = [part.strip() for part in results.split(',')]
+        # Résultats nommés (`n int, err error`) ou dernier résultat autre que error
+        if types[-1] != 'error' or any(' ' in t and not t.startswith(cls.NIL_PREFIXES) for t in types):
+            return None
+        zeros = [cls.zero_value(t) for t in types[:-1]]
+        if any(zero is None for zero in zeros):
+            return None
+        return 'return ' + ', '.join(zeros + ['err'])
+    
+    def fix(self, content: str) -> Tuple[str, List[str]]:
+        """Insertion des vérifications - retourne (contenu, corrections)"""
+        lines = content.split('\n')
+        output: List[str] = []
+        fixes = []
+        statement: Optional[str] = None
+        nested = False
+        for i, line in enumerate(lines):
+            match = self.FUNC_START.match(line)
+            if match:
+                statement = self.return_statement(match.group('results'))
+                nested = False
+            elif line.startswith('}'):
+                statement = None
+            elif statement is not None and 'func(' in line:
+                # Fonction littérale : ses `return` ne sont pas ceux de la fonction englobante
+                nested = True
+            
+            output.append(line)
+            assign = self.ERR_ASSIGN.match(line) if statement is not None and not nested else None
+            if assign is None or line.count('(') != line.count(')'):
+                continue
+            following = next((l.strip() for l in lines[i + 1:] if l.strip() and not l.strip().startswith('//')), '')
+            if self.ERR_USE.search(following):
+                continue
+            indent = assign.group('indent')
+            unit = '\t' if indent.startswith('\t') else '    '
+            output.extend([f"{indent}if err != nil {{", f"{indent}{unit}{statement}", f"{indent}}}"])
+            fixes.append(f"Fixed check_dropped_errors on line {i+1}")
+        return '\n'.join(output), fixes
+
+JSX_BLOCK_START = re.compile(r'(^|\(|\breturn|=>|=|\?|:|&&|\|\|)\s*<([A-Za-z]|>)')
+JSX_OPEN_TAG = re.compile(r'<([A-Za-z][\w.:-]*|>)')
+JSX_CLOSE_TAG = re.compile(r'</([A-Za-z][\w.:-]*)?>|/>')
+
+def jsx_lines(lines: Iterable[str]) -> Set[int]:
+    """Indices des lignes appartenant à un bloc JSX (équilibre des balises ouvrantes/fermantes)
+    
+    Un seul passage sans retour arrière : utilisable sur un fichier lu en flux.
+    """
+    inside = set()
+    balance = 0
+    pending = None  # `return (` en attente de la prochaine ligne non vide
+    for i, line in enumerate(lines):
+        if pending is not None and line.strip():
+            if line.lstrip().startswith('<'):
+                inside.add(pending)
+            pending = None
+        if balance == 0 and not JSX_BLOCK_START.search(line):
+            # `return (` suivi d'une balise sur la ligne suivante
+            if line.rstrip().endswith('('):
+                pending = i
+            continue
+        inside.add(i)
+        balance += len(JSX_OPEN_TAG.findall(line)) - len(JSX_CLOSE_TAG.findall(line))
+        balance = max(balance, 0)
+    return inside
+
+class SyntaxAnalyzer:
+    """🧠 ANALYSEUR INTELLIGENT DE SYNTAXE"""
+    
+    # Groupes de règles sélectionnables par un seul identifiant (--rules)
+    RULE_GROUPS = {
+        'imports': ['unused_import', 'isort', 'autoflake', 'merge_imports'],
+        'formatting': ['black', 'autopep8', 'gofmt_spacing'],
+        'modernize': ['pyupgrade', 'var_to_const'],
+        'hygiene': ['trailing_whitespace', 'final_newline', 'mixed_indentation'],
+        'unicode': ['smart_quotes', 'invisible_chars', 'bom']
+    }
+    
+    # Patterns compilés une seule fois (appliqués à chaque ligne de chaque fichier)
+    PY_MISSING_COLON = re.compile(r'(if|elif|else|for|while|def|class|try|except|finally|with)\s+[^:]*$')
+    PY_PRINT_STATEMENT = re.compile(r'print\s+[^(].*[^)]$')
+    PY_PRINT_ARGS = re.compile(r'print\s+(.+)')
+    PY_INDENTED = re.compile(r'^\s*(\S.*)')
+    PY_BLOCK_KEYWORD = re.compile(r'^\s*(class|def|if|for|while|try|except|finally|with)')
+    PY_CONTINUATION_KEYWORD = re.compile(r'^\s*(elif|else|except|finally)')
+    JS_MISSING_SEMICOLON = re.compile(r'[^;{}\s]$')
+    JS_VAR_LITERAL = re.compile(r'var\s+(\w+)\s*=\s*["\'\d\[\{]')
+    JS_VAR = re.compile(r'var\s+')
+    JS_LOOSE_EQUALITY = re.compile(r'([^=!])===?([^=])')
+    GO_SINGLE_IMPORT = re.compile(r'import\s+"[^"]*"\s*$')
+    GO_BRACE_SPACING = re.compile(r'(\w+)\s*{\s*$')
+    
+    def __init__(self):
+        self.pattern_cache = {}
+        
+        # Règles actives (None : toutes)
+        self.enabled_rules: Optional[Set[str]] = None
+        
+        # Règles personnalisées issues de la configuration
+        self.custom_rules: List[CustomRule] = []
+        
+        # Patterns de correction par langage
+        self.fix_patterns = {
+            'python': {
+                'missing_colon': {
+                    'pattern': self.PY_MISSING_COLON,
+                    'fix': lambda line: line.rstrip() + ':',
+                    'description': 'Missing colon'
+                },
+                'print_parentheses': {
+                    'pattern': self.PY_PRINT_STATEMENT,
+                    'fix': lambda line: self.PY_PRINT_ARGS.sub(r'print(\1)', line),
+                    'description': 'Print statement needs parentheses'
+                },
+                'indentation': {
+                    'pattern': self.PY_INDENTED,
+                    'fix': self._fix_python_indentation,
+                    'description': 'Indentation error'
+                }
+            },
+            'javascript': {
+                'missing_semicolon': {
+                    'pattern': self.JS_MISSING_SEMICOLON,
+                    'fix': lambda line: line.rstrip() + ';',
+                    'description': 'Missing semicolon'
+                },
+                'var_to_const': {
+                    'pattern': self.JS_VAR_LITERAL,
+                    'fix': lambda line: self.JS_VAR.sub('const ', line),
+                    'description': 'Use const instead of var'
+                },
+                'double_equals': {
+                    'pattern': self.JS_LOOSE_EQUALITY,
+                    'fix': lambda line: self.JS_LOOSE_EQUALITY.sub(r'\1===\2', line),
+                    'description': 'Use strict equality'
+                }
+            },
+            'go': {
+                'unused_import': {
+                    'pattern': self.GO_SINGLE_IMPORT,
+                    'fix': self._fix_go_imports,
+                    'description': 'Unused import'
+                },
+                'gofmt_spacing': {
+                    'pattern': self.GO_BRACE_SPACING,
+                    'fix': lambda line: self.GO_BRACE_SPACING.sub(r'\1 {', line),
+                    'description': 'Go formatting'
+                }
+            }
+        }
+    
+    def has_custom_rules(self, language: str) -> bool:
+        return any(rule.language == language and self.rule_enabled(rule.id) for rule in self.custom_rules)
+    
+    def select_rules(self, rule_ids: Optional[List[str]]):
+        """Restriction aux règles demandées (identifiants ou groupes)"""
+        if not rule_ids:
+            self.enabled_rules = None
+        else:
+            self.enabled_rules = set()
+            for rule_id in rule_ids:
+                self.enabled_rules.update(self.RULE_GROUPS.get(rule_id, [rule_id]))
+        self.pattern_cache = {}
+    
+    def rule_enabled(self, rule_id: str) -> bool:
+        return self.enabled_rules is None or rule_id in self.enabled_rules
+    
+    def apply_custom_rules(self, content: str, language: str,
+                           file_path: Optional[str] = None) -> Tuple[List[str], List[str], str]:
+        """Application des règles personnalisées - (erreurs, corrections, contenu)"""
+        errors_found, fixes_applied = [], []
+        for rule in self.custom_rules:
+            if not rule.applies_to(language, file_path) or not self.rule_enabled(rule.id):
+                continue
+            content, changed_lines = rule.apply(content)
+            for line_number in changed_lines:
+                errors_found.append(f"Line {line_number}: {rule.description}")
+                fixes_applied.append(f"Fixed {rule.id} on line {line_number}")
+        return errors_found, fixes_applied, content
+    
+    def _fix_python_indentation(self, line: str) -> str:
+        """Correction intelligente de l'indentation Python"""
+        if line.strip():
+            # Logique simplifiée d'indentation
+            if self.PY_BLOCK_KEYWORD.match(line):
+                return '    ' + line.strip()
+            elif self.PY_CONTINUATION_KEYWORD.match(line):
+                return line.strip()
+            else:
+                return '    ' + line.strip()
+        return line
+    
+    def _fix_go_imports(self, line: str) -> str:
+        """Correction des imports Go"""
+        return line  # Placeholder - gofmt gère cela mieux
     
     async def analyze_syntax_errors(self, content: str, language: str) -> Tuple[List[str], str]:
         """Analyse intelligente et correction des erreurs de syntaxe"""
@@ -261,22 +2208,13 @@ class SyntaxAnalyzer:
         errors_found = []
         fixes_applied = []
         
-        patterns = self.fix_patterns[language]
+        # Les heuristiques ligne à ligne corrompent le JSX : lignes ignorées
+        skipped_lines = jsx_lines(lines) if language == 'javascript' else set()
+        rules = self.active_patterns(language)
         
         for i, line in enumerate(lines):
-            for fix_name, fix_config in patterns.items():
-                if re.search(fix_config['pattern'], line):
-                    errors_found.append(f"Line {i+1}: {fix_config['description']}")
-                    
-                    # Appliquer la correction
-                    try:
-                        if callable(fix_config['fix']):
-                            fixed_line = fix_config['fix'](line)
-                            if fixed_line != line:
-                                lines[i] = fixed_line
-                                fixes_applied.append(f"Fixed {fix_name} on line {i+1}")
-                    except Exception as e:
-                        fixes_applied.append(f"Attempted {fix_name} fix on line {i+1}: {str(e)}")
+            if i not in skipped_lines:
+                lines[i] = self._fix_line(i, line, language, rules, errors_found, fixes_applied)
         
         fixed_content = '\n'.join(lines)
         result = (errors_found + fixes_applied, fixed_content)
@@ -284,15 +2222,5343 @@ class SyntaxAnalyzer:
         # Mise en cache
         self.pattern_cache[cache_key] = result
         return result
+    
+    def analyze_stream(self, source: Callable[[], TextIO], language: str, out: TextIO) -> List[str]:
+        """Variante en flux pour les gros fichiers : lecture ligne à ligne, un seul tampon de sortie
+        
+        `source` ouvre le fichier (deux passages pour JavaScript : détection JSX puis corrections).
+        """
+        errors_found = []
+        fixes_applied = []
+        
+        skipped_lines = set()
+        if language == 'javascript':
+            with source() as f:
+                skipped_lines = jsx_lines(line.rstrip('\n') for line in f)
+        rules = self.active_patterns(language)
+        
+        with source() as f:
+            for i, raw in enumerate(f):
+                line = raw[:-1] if raw.endswith('\n') else raw
+                if i not in skipped_lines:
+                    line = self._fix_line(i, line, language, rules, errors_found, fixes_applied)
+                out.write(line)
+                if raw.endswith('\n'):
+                    out.write('\n')
+        return errors_found + fixes_applied
+    
+    def active_patterns(self, language: str) -> List[Tuple[str, Dict[str, Any]]]:
+        """Patterns actifs du langage, résolus une fois par fichier et non à chaque ligne"""
+        return [(fix_name, fix_config) for fix_name, fix_config in self.fix_patterns.get(language, {}).items()
+                if self.rule_enabled(fix_name)]
+    
+    def _fix_line(self, i: int, line: str, language: str, rules: List[Tuple[str, Dict[str, Any]]],
+                  errors_found: List[str], fixes_applied: List[str]) -> str:
+        """Application des patterns actifs à une ligne"""
+        fixed = line
+        for fix_name, fix_config in rules:
+            if fix_config['pattern'].search(line):
+                errors_found.append(f"Line {i+1}: {fix_config['description']}")
+                
+                # Appliquer la correction
+                try:
+                    if callable(fix_config['fix']):
+                        fixed_line = fix_config['fix'](line)
+                        if fixed_line != line:
+                            fixed = fixed_line
+                            fixes_applied.append(f"Fixed {fix_name} on line {i+1}")
+                except Exception as e:
+                    logger.warning("rule failed on line %d: %s", i + 1, e,
+                                   extra={'rule_id': fix_name, 'language': language})
+                    fixes_applied.append(f"Attempted {fix_name} fix on line {i+1}: {str(e)}")
+        return fixed
+
+# === CONFIGURATION ===
+CONFIG_FILENAME = '.syntaxfixer.yml'
+
+def load_config(repo_path: Path) -> Dict[str, Any]:
+    """Chargement de la configuration du repository (.syntaxfixer.yml)"""
+    return read_config_file(Path(repo_path) / CONFIG_FILENAME)
+
+def read_config_file(config_path: Path) -> Dict[str, Any]:
+    """Lecture et validation d'un fichier de configuration (repository ou utilisateur)"""
+    if not config_path.is_file():
+        return {}
+    
+    try:
+        text = config_path.read_text(encoding='utf-8')
+        config = yaml.safe_load(text) or {}
+    except (yaml.YAMLError, IOError, UnicodeDecodeError) as e:
+        logger.error("invalid configuration: %s", e, extra={'file_path': str(config_path)})
+        return {}
+    
+    if not isinstance(config, dict):
+        logger.error("configuration root must be a mapping", extra={'file_path': str(config_path)})
+        return {}
+    # Modules des règles WASM : chemins relatifs au fichier de configuration
+    for entry in config.get('wasm_rules') or []:
+        if isinstance(entry, dict) and isinstance(entry.get('module'), str):
+            entry['module'] = str(config_path.parent / entry['module'])
+    # Schéma : les erreurs sont signalées avec leur position, les valeurs invalides restent ignorées plus loin
+    for issue in ConfigValidator().validate_text(text):
+        log = logger.error if issue.severity == 'error' else logger.warning
+        log("%s", issue.format(), extra={'file_path': str(config_path)})
+    return config
+
+def config_section(properties: Dict[str, Dict[str, Any]], **extra: Any) -> Dict[str, Any]:
+    return {'type': 'object', 'properties': properties, 'additionalProperties': False, **extra}
+
+STRING_LIST = {'type': 'array', 'items': {'type': 'string'}}
+INDENT = {'type': 'integer', 'minimum': 1}
+COMMAND = {'type': ['boolean', 'object'], 'properties': {
+    'command': {'type': ['string', 'array'], 'items': {'type': 'string'}},
+    'timeout': {'type': 'number', 'minimum': 0},
+}, 'additionalProperties': False}
+
+# Schéma de .syntaxfixer.yml (sous-ensemble JSON Schema : type, properties, items, enum, minimum, required)
+CONFIG_SCHEMA = config_section({
+    'enabled_rules': STRING_LIST,
+    'languages': STRING_LIST,
+    'exclude_languages': STRING_LIST,
+    'fail_on': config_section({
+        'max_files_changed': {'type': 'integer', 'minimum': 0},
+        'aggressive': {'type': 'boolean'},
+    }),
+    'baseline': {'type': 'string'},
+    'history': {'type': 'boolean'},
+    'fix_log': {'type': ['boolean', 'string']},
+    'commit_trailers': {'type': 'boolean'},
+    'commit_status': {'type': 'boolean'},
+    'blame_ignore_revs': {'type': ['boolean', 'object'], 'properties': {
+        'min_files': {'type': 'integer', 'minimum': 1},
+    }, 'additionalProperties': False},
+    'blame': config_section({
+        'max_age_days': {'type': 'number', 'minimum': 0},
+        'authors': STRING_LIST,
+    }),
+    'walk': config_section({
+        'skip_dirs': {'type': ['array', 'object'], 'items': {'type': 'string'},
+                      'properties': {'add': STRING_LIST, 'remove': STRING_LIST}, 'additionalProperties': False},
+        'max_depth': {'type': 'integer', 'minimum': 0},
+        'max_files': {'type': 'integer', 'minimum': 1},
+    }),
+    'quarantine': {'type': 'boolean'},
+    'verify': COMMAND,
+    'compile_check': {'type': 'boolean'},
+    'project_format': COMMAND,
+    'hygiene': {'type': 'boolean'},
+    'tools': {'type': 'object', 'additionalProperties': config_section({'secondary': STRING_LIST})},
+    'typescript': config_section({'codefixes': {'type': ['boolean', 'array'], 'items': {'type': 'string'}}}),
+    'javascript': config_section({'module_style': {'type': 'string', 'enum': ['auto', 'esm', 'commonjs']}}),
+    'go': config_section({'align_struct_tags': {'type': 'boolean'}, 'sort_struct_tags': {'type': 'boolean'},
+                          'check_dropped_errors': {'type': 'boolean'}}),
+    'html': config_section({'indent': INDENT}),
+    'graphql': config_section({'indent': INDENT, 'sort_fields': {'type': 'boolean'}}),
+    'cmake': config_section({'indent': INDENT}),
+    'maven': config_section({'indent': INDENT, 'sort_dependencies': {'type': 'boolean'}}),
+    'xml': config_section({'indent': INDENT,
+                           'attribute_order': {'type': 'string', 'enum': ['alphabetical', 'android']}}),
+    'dart': config_section({'fix': {'type': 'boolean'}}),
+    'plugins': {'type': 'array', 'items': config_section({
+        'name': {'type': 'string'},
+        'command': {'type': ['string', 'array'], 'items': {'type': 'string'}},
+        'language': {'type': 'string'},
+        'extensions': STRING_LIST,
+        'timeout': {'type': 'number', 'minimum': 0},
+    }, required=['name', 'command'])},
+    'rules': {'type': 'array', 'items': config_section({
+        'id': {'type': 'string'},
+        'language': {'type': 'string'},
+        'pattern': {'type': 'string'},
+        'replace': {'type': 'string'},
+        'description': {'type': 'string'},
+        'paths': STRING_LIST,
+        'mask': {'type': 'array', 'items': {'type': 'string', 'enum': ['strings', 'comments']}},
+        'test': config_section({'input': {'type': 'string'}, 'expected': {'type': 'string'}},
+                               required=['input', 'expected']),
+    }, required=['id', 'language', 'pattern', 'test'])},
+    'wasm_rules': {'type': 'array', 'items': config_section({
+        'id': {'type': 'string'},
+        'language': {'type': 'string'},
+        'module': {'type': 'string'},
+        'description': {'type': 'string'},
+        'paths': STRING_LIST,
+        'timeout': {'type': 'number', 'minimum': 0},
+        'test': config_section({'input': {'type': 'string'}, 'expected': {'type': 'string'}},
+                               required=['input', 'expected']),
+    }, required=['id', 'language', 'module', 'test'])},
+})
+
+CONFIG_TEMPLATE = '''\
+# Auto-Syntax-Fixer - configuration du repository
+# Vérification : app.py --config-validate   |   toutes les clés sont optionnelles
+
+# Règles et langages (par défaut : tous)
+# enabled_rules: [missing_colon, print_parentheses]
+# languages: [python, javascript, go]
+# exclude_languages: [markdown]
+
+# Échec de l'exécution au-delà de ces seuils
+# fail_on:
+#   max_files_changed: 200
+#   aggressive: true
+
+# Historique des exécutions, journal des corrections, trailers des commits
+history: true
+fix_log: false              # true (.asf/fixes.log) ou chemin relatif, ex. CHANGELOG.md
+commit_trailers: true
+commit_status: false
+
+# Commit des corrections ajouté à .git-blame-ignore-revs au-delà de min_files fichiers
+blame_ignore_revs:
+  min_files: 20
+
+# Ne corriger que les lignes récentes ou de certains auteurs
+# blame:
+#   max_age_days: 30
+#   authors: [dev@example.com]
+
+# Parcours du repository
+# walk:
+#   skip_dirs: {add: [vendor, dist], remove: [__pycache__]}
+#   max_depth: 6
+#   max_files: 20000
+
+quarantine: true
+hygiene: true
+compile_check: false          # go build / cargo check / tsc / mvn - ignoré sauf --allow-repo-plugins
+
+# Tests / formateur du projet après correction
+verify: false               # true ou {command: "make test", timeout: 600} - ignoré sauf --allow-repo-plugins
+project_format: false       # true ou {command: "npm run format", timeout: 300} - idem
+
+# Options par langage
+# tools:
+#   python: {secondary: [isort]}
+# typescript: {codefixes: true}       # ignoré dans le repository sauf --allow-repo-plugins
+# javascript: {module_style: auto}     # auto | esm | commonjs
+# go: {align_struct_tags: false, sort_struct_tags: false, check_dropped_errors: false}
+# html: {indent: 2}
+# graphql: {indent: 2, sort_fields: false}
+# cmake: {indent: 2}
+# maven: {indent: 4, sort_dependencies: false}
+# xml: {indent: 2, attribute_order: alphabetical}   # alphabetical | android
+# dart: {fix: false}
+
+# Correcteurs externes (stdin -> stdout) - exécutent une commande : ignorés sauf --allow-repo-plugins
+# plugins:
+#   - name: sqlfmt
+#     command: sqlfmt -
+#     extensions: [.sql]
+#     timeout: 10
+
+# Règles personnalisées (snippet de test obligatoire)
+# rules:
+#   - id: no-print
+#     language: python
+#     pattern: '\\bprint\\('
+#     replace: 'logger.info('
+#     test: {input: "print(x)", expected: "logger.info(x)"}
+
+# Règles compilées en WebAssembly, exécutées sans accès fichiers ni réseau
+# wasm_rules:
+#   - id: house-style
+#     language: go
+#     module: rules/house_style.wasm   # relatif à ce fichier
+#     test: {input: "a\tb", expected: "a b"}
+'''
+
+@dataclass
+class ConfigIssue:
+    """Problème de configuration localisé (ligne et colonne à partir de 1)"""
+    severity: str  # error | warning
+    path: str
+    line: int
+    column: int
+    message: str
+
+    def format(self, source: str = CONFIG_FILENAME) -> str:
+        return f"{source}:{self.line}:{self.column}: {self.severity}: {self.path or '<root>'}: {self.message}"
+
+class ConfigValidator:
+    """📐 VALIDATION CONFIG - Vérification de .syntaxfixer.yml contre CONFIG_SCHEMA
+
+    Les nœuds YAML gardent leur position : chaque erreur de type indique la ligne et
+    la colonne fautives. Les clés inconnues sont des avertissements (avec suggestion).
+    """
+
+    TAG_TYPES = {
+        'tag:yaml.org,2002:str': 'string',
+        'tag:yaml.org,2002:int': 'integer',
+        'tag:yaml.org,2002:float': 'number',
+        'tag:yaml.org,2002:bool': 'boolean',
+        'tag:yaml.org,2002:null': 'null',
+    }
+
+    def __init__(self, schema: Optional[Dict[str, Any]] = None):
+        self.schema = schema or CONFIG_SCHEMA
+
+    def validate_text(self, text: str) -> List[ConfigIssue]:
+        try:
+            node = yaml.compose(text, Loader=yaml.SafeLoader)
+        except yaml.YAMLError as e:
+            mark = getattr(e, 'problem_mark', None)
+            return [ConfigIssue('error', '', mark.line + 1 if mark else 1, mark.column + 1 if mark else 1,
+                                f"invalid YAML: {getattr(e, 'problem', None) or e}")]
+        if node is None:
+            return []
+        issues: List[ConfigIssue] = []
+        self._check(node, self.schema, '', issues)
+        return issues
+
+    def validate_file(self, path: Path) -> List[ConfigIssue]:
+        return self.validate_text(Path(path).read_text(encoding='utf-8'))
+
+    def node_type(self, node: yaml.Node) -> str:
+        if isinstance(node, yaml.MappingNode):
+            return 'object'
+        if isinstance(node, yaml.SequenceNode):
+            return 'array'
+        return self.TAG_TYPES.get(node.tag, 'string')
+
+    @staticmethod
+    def _issue(severity: str, node: yaml.Node, path: str, message: str) -> ConfigIssue:
+        return ConfigIssue(severity, path, node.start_mark.line + 1, node.start_mark.column + 1, message)
+
+    def _check(self, node: yaml.Node, schema: Dict[str, Any], path: str, issues: List[ConfigIssue]):
+        expected = schema.get('type')
+        expected = [expected] if isinstance(expected, str) else list(expected or [])
+        actual = self.node_type(node)
+        accepted = actual in expected or (actual == 'integer' and 'number' in expected)
+        if expected and not accepted:
+            issues.append(self._issue('error', node, path,
+                                      f"expected {' or '.join(expected)}, got {actual} {self._preview(node)}"))
+            return
+
+        if actual == 'object':
+            self._check_mapping(node, schema, path, issues)
+        elif actual == 'array' and 'items' in schema:
+            for index, item in enumerate(node.value):
+                self._check(item, schema['items'], f"{path}[{index}]", issues)
+        elif isinstance(node, yaml.ScalarNode):
+            value = yaml.safe_load(node.value) if actual != 'string' else node.value
+            if 'enum' in schema and value not in schema['enum']:
+                issues.append(self._issue('error', node, path, f"{value!r} is not one of: "
+                                          f"{', '.join(map(str, schema['enum']))}"))
+            if 'minimum' in schema and actual in ('integer', 'number') and value < schema['minimum']:
+                issues.append(self._issue('error', node, path, f"{value} is less than {schema['minimum']}"))
+
+    def _check_mapping(self, node: yaml.MappingNode, schema: Dict[str, Any], path: str, issues: List[ConfigIssue]):
+        properties = schema.get('properties', {})
+        extra = schema.get('additionalProperties', True)
+        seen = set()
+        for key_node, value_node in node.value:
+            key = str(key_node.value)
+            key_path = f"{path}.{key}" if path else key
+            if key in seen:
+                issues.append(self._issue('warning', key_node, key_path, "duplicate key (the last value wins)"))
+            seen.add(key)
+            if key in properties:
+                self._check(value_node, properties[key], key_path, issues)
+            elif isinstance(extra, dict):
+                self._check(value_node, extra, key_path, issues)
+            elif extra is False:
+                suggestion = difflib.get_close_matches(key, list(properties), n=1)
+                hint = f" - did you mean '{suggestion[0]}'?" if suggestion else ''
+                issues.append(self._issue('warning', key_node, key_path, f"unknown key{hint}"))
+        for key in schema.get('required', []):
+            if key not in seen:
+                issues.append(self._issue('error', node, path, f"missing required key '{key}'"))
+
+    @staticmethod
+    def _preview(node: yaml.Node) -> str:
+        if isinstance(node, yaml.ScalarNode):
+            value = node.value if len(node.value) <= 30 else node.value[:27] + '...'
+            return f"({value!r})"
+        return ''
+
+# Valeurs par défaut appliquées par configure() (première couche de la résolution)
+DEFAULT_CONFIG = {
+    'history': True,
+    'fix_log': False,
+    'commit_trailers': True,
+    'commit_status': False,
+    'blame_ignore_revs': True,
+    'quarantine': True,
+    'verify': False,
+    'compile_check': False,
+    'project_format': False,
+    'hygiene': True,
+    'typescript': {'codefixes': False},
+    'go': {'align_struct_tags': False, 'sort_struct_tags': False, 'check_dropped_errors': False},
+    'html': {'indent': 2},
+    'graphql': {'indent': 2, 'sort_fields': False},
+    'cmake': {'indent': 2},
+    'maven': {'sort_dependencies': False},
+    'dart': {'fix': False},
+}
+
+# Clés qui exécutent du code du repository : ignorées dans son .syntaxfixer.yml (un repository non fiable
+# exécuterait son propre code) sauf --allow-repo-plugins / ASF_ALLOW_REPO_PLUGINS=1 ; les autres couches
+# sont celles de l'opérateur. S'applique aussi aux archives téléversées et aux repositories traités par le serveur.
+# plugins, verify, project_format : commandes déclarées ; typescript : service de langage (sidecar Node) ;
+# compile_check : go build, cargo check, tsc, mvn (scripts de build, macros, plugins Maven).
+# Même opt-in, hors configuration : configurations ESLint en JS (ESLintRunner.JS_CONFIG_FILES) et module
+# typescript du projet (TypeScriptCodeFixer), refusés sans --allow-repo-plugins.
+REPO_EXEC_KEYS = ('plugins', 'verify', 'project_format', 'typescript', 'compile_check')
+
+class ConfigLayers:
+    """🧅 COUCHES DE CONFIGURATION - défauts < repository < utilisateur < variables ASF_* < CLI
+
+    Configuration utilisateur : $ASF_USER_CONFIG, sinon $XDG_CONFIG_HOME/asf/config.yml
+    (~/.config/asf/config.yml). Variables d'environnement : ASF_<CLÉ>, `__` pour les
+    sections imbriquées (ASF_FAIL_ON__MAX_FILES_CHANGED=10) ; valeurs lues en YAML,
+    listes séparées par des virgules (ASF_LANGUAGES=python,go). Seules les clés du
+    schéma sont retenues : ASF_DB, ASF_WORKERS, ... ne sont pas des réglages de configuration.
+    
+    Les clés REPO_EXEC_KEYS de la couche repository ne sont retenues que si `allow_repo_exec`.
+    """
+
+    ORDER = ('default', 'repo', 'user', 'env', 'cli')
+    ENV_PREFIX = 'ASF_'
+
+    def __init__(self, repo_path: Path, cli: Optional[Dict[str, Any]] = None,
+                 environ: Optional[Dict[str, str]] = None, allow_repo_exec: bool = False,
+                 repo_config: Optional[Dict[str, Any]] = None):
+        self.repo_path = Path(repo_path)
+        # Configuration du repository déjà lue (mode GitHub : blob du commit traité)
+        self.repo_config = repo_config
+        self.cli = cli or {}
+        self.environ = os.environ if environ is None else environ
+        self.allow_repo_exec = allow_repo_exec
+        # Variable d'environnement à l'origine de chaque clé de la couche env
+        self.env_sources: Dict[str, str] = {}
+
+    def user_path(self) -> Path:
+        explicit = self.environ.get('ASF_USER_CONFIG')
+        if explicit:
+            return Path(explicit).expanduser()
+        base = self.environ.get('XDG_CONFIG_HOME') or str(Path('~/.config').expanduser())
+        return Path(base) / 'asf' / 'config.yml'
+
+    def env_layer(self) -> Dict[str, Any]:
+        layer: Dict[str, Any] = {}
+        self.env_sources = {}
+        for name, raw in sorted(self.environ.items()):
+            if not name.startswith(self.ENV_PREFIX):
+                continue
+            keys = name[len(self.ENV_PREFIX):].lower().split('__')
+            schema = self._schema_for(keys)
+            if schema is None:
+                continue
+            try:
+                value = yaml.safe_load(raw) if raw else None
+            except yaml.YAMLError:
+                value = raw
+            if isinstance(value, str) and 'array' in self._types(schema):
+                value = [item.strip() for item in value.split(',') if item.strip()]
+            target = layer
+            for key in keys[:-1]:
+                target = target.setdefault(key, {})
+                if not isinstance(target, dict):
+                    break
+            else:
+                target[keys[-1]] = value
+                self.env_sources['.'.join(keys)] = name
+        return layer
+
+    def repo_layer(self) -> Dict[str, Any]:
+        config = load_config(self.repo_path) if self.repo_config is None else self.repo_config
+        if self.allow_repo_exec:
+            return config
+        ignored = [key for key in REPO_EXEC_KEYS if config.get(key)]
+        if ignored:
+            logger.warning("ignoring %s from the repository configuration (code from the repository is "
+                           "not run without --allow-repo-plugins or ASF_ALLOW_REPO_PLUGINS=1)", ', '.join(ignored),
+                           extra={'file_path': str(self.repo_path / CONFIG_FILENAME)})
+        return {key: value for key, value in config.items() if key not in REPO_EXEC_KEYS}
+    
+    def layers(self) -> List[Tuple[str, Dict[str, Any]]]:
+        return [
+            ('default', DEFAULT_CONFIG),
+            ('repo', self.repo_layer()),
+            ('user', read_config_file(self.user_path())),
+            ('env', self.env_layer()),
+            ('cli', self.cli),
+        ]
+
+    def resolve(self) -> Tuple[Dict[str, Any], Dict[str, str]]:
+        """Configuration effective et couche d'origine de chaque valeur (clé pointée)"""
+        config: Dict[str, Any] = {}
+        sources: Dict[str, str] = {}
+        for name, layer in self.layers():
+            self._merge(config, layer, name, '', sources)
+        return config, sources
+
+    def source_label(self, path: str, layer: str) -> str:
+        if layer == 'env':
+            variable = next((self.env_sources[key] for key in self.env_sources
+                             if path == key or path.startswith(key + '.')), None)
+            return f"env {variable}" if variable else layer
+        if layer == 'repo':
+            return f"repo {CONFIG_FILENAME}"
+        if layer == 'user':
+            return f"user {self.user_path()}"
+        return layer
+
+    @classmethod
+    def _merge(cls, target: Dict[str, Any], layer: Dict[str, Any], name: str, prefix: str,
+               sources: Dict[str, str]):
+        # Sections fusionnées clé par clé ; toute autre valeur remplace la précédente
+        for key, value in layer.items():
+            path = f"{prefix}{key}"
+            if isinstance(value, dict) and isinstance(target.get(key), dict):
+                cls._merge(target[key], value, name, path + '.', sources)
+                continue
+            target[key] = copy.deepcopy(value)
+            for stale in [p for p in sources if p == path or p.startswith(path + '.')]:
+                del sources[stale]
+            for leaf in cls._leaves(value, path):
+                sources[leaf] = name
+
+    @classmethod
+    def _leaves(cls, value: Any, path: str) -> List[str]:
+        if isinstance(value, dict) and value:
+            return [leaf for key, child in value.items() for leaf in cls._leaves(child, f"{path}.{key}")]
+        return [path]
+
+    @staticmethod
+    def _types(schema: Dict[str, Any]) -> List[str]:
+        expected = schema.get('type')
+        return [expected] if isinstance(expected, str) else list(expected or [])
+
+    @classmethod
+    def _schema_for(cls, keys: List[str]) -> Optional[Dict[str, Any]]:
+        schema = CONFIG_SCHEMA
+        for key in keys:
+            properties = schema.get('properties', {})
+            extra = schema.get('additionalProperties', True)
+            if key in properties:
+                schema = properties[key]
+            elif isinstance(extra, dict):
+                schema = extra
+            else:
+                return None
+        return schema
+
+class Fixer:
+    """🔌 INTERFACE CORRECTEUR - Contrat commun des correcteurs de langage"""
+    
+    name = 'fixer'
+    # Passe d'hygiène du texte après correction
+    hygiene = True
+    
+    async def fix(self, file_path: str, content: str) -> FixOutcome:
+        raise NotImplementedError
+
+class ExecPluginFixer(Fixer):
+    """🧩 PLUGIN EXTERNE - Protocole JSON sur stdin/stdout
+    
+    Requête  : {"file_path": ..., "language": ..., "content": ...}
+    Réponse  : {"content": ..., "fixes": [...], "errors": [...]}
+    
+    Un plugin est une commande arbitraire : déclaré dans le .syntaxfixer.yml d'un repository,
+    il exécuterait le code de ce repository. Ceux du repository ne sont chargés qu'avec
+    --allow-repo-plugins ; la configuration utilisateur, ASF_PLUGINS et la CLI sont toujours lues.
+    """
+    
+    def __init__(self, name: str, command: List[str], language: str,
+                 extensions: List[str], timeout: float = 10):
+        self.name = name
+        self.command = command
+        self.language = language
+        self.extensions = [e.lower() if e.startswith('.') else f".{e.lower()}" for e in extensions]
+        self.timeout = timeout
+    
+    @classmethod
+    def from_config(cls, entry: Dict[str, Any]) -> 'ExecPluginFixer':
+        """Construction depuis une entrée `plugins:` de la configuration"""
+        command = entry['command']
+        if isinstance(command, str):
+            command = command.split()
+        name = entry['name']
+        return cls(
+            name=name,
+            command=command,
+            language=entry.get('language', name),
+            extensions=entry.get('extensions', []),
+            timeout=float(entry.get('timeout', 10))
+        )
+    
+    async def fix(self, file_path: str, content: str) -> FixOutcome:
+        """Exécution du plugin sur un fichier"""
+        request = json.dumps({
+            'file_path': file_path,
+            'language': self.language,
+            'content': content
+        }).encode('utf-8')
+        process = None
+        
+        try:
+            with tracing.span('subprocess', tool=self.name, bytes=len(request)):
+                process = await platform_subprocess_exec(
+                    *self.command,
+                    stdin=asyncio.subprocess.PIPE,
+                    stdout=asyncio.subprocess.PIPE,
+                    stderr=asyncio.subprocess.PIPE
+                )
+                stdout, stderr = await asyncio.wait_for(process.communicate(request), timeout=self.timeout)
+        except FileNotFoundError:
+            raise ToolMissingError(f"Plugin {self.name} command not found: {self.command[0]}")
+        except asyncio.TimeoutError:
+            process.kill()
+            await process.wait()
+            logger.warning("plugin timeout", extra={'tool': self.name, 'file_path': file_path})
+            return FixOutcome(content, [], [f"Plugin {self.name} timeout"], False)
+        except asyncio.CancelledError:
+            if process is not None and process.returncode is None:
+                process.kill()
+                await process.wait()
+            raise
+        
+        if process.returncode != 0:
+            message = stderr.decode(errors='replace').strip() or f"exit code {process.returncode}"
+            logger.warning("plugin failed: %s", message, extra={'tool': self.name, 'file_path': file_path})
+            return FixOutcome(content, [], [f"Plugin {self.name} failed: {message}"], False)
+        
+        try:
+            response = json.loads(stdout.decode('utf-8'))
+            fixed_content = response['content']
+            if not isinstance(fixed_content, str):
+                raise TypeError("content must be a string")
+        except (ValueError, KeyError, TypeError) as e:
+            raise ParseFailedError(f"Invalid response from plugin {self.name}: {e}")
+        
+        return FixOutcome(
+            content=fixed_content,
+            fixes=[str(fix) for fix in response.get('fixes', [])],
+            errors=[str(error) for error in response.get('errors', [])],
+            success=True
+        )
+
+class CircuitBreaker:
+    """⚡ DISJONCTEUR - Ouvert après N échecs consécutifs, nouvel essai après reset_timeout secondes"""
+    
+    def __init__(self, failure_threshold: int = 5, reset_timeout: float = 30):
+        self.failure_threshold = failure_threshold
+        self.reset_timeout = reset_timeout
+        self.state = 'closed'
+        self.consecutive_failures = 0
+        self.opened_at = 0.0
+        self.counters = {'requests': 0, 'failures': 0, 'short_circuited': 0, 'trips': 0}
+    
+    def allow(self) -> bool:
+        if self.state == 'open':
+            if time.monotonic() - self.opened_at < self.reset_timeout:
+                self.counters['short_circuited'] += 1
+                return False
+            self.state = 'half_open'  # Une requête d'essai
+        self.counters['requests'] += 1
+        return True
+    
+    def record_success(self):
+        self.state = 'closed'
+        self.consecutive_failures = 0
+    
+    def record_failure(self):
+        self.counters['failures'] += 1
+        self.consecutive_failures += 1
+        if self.state == 'half_open' or self.consecutive_failures >= self.failure_threshold:
+            if self.state != 'open':
+                self.counters['trips'] += 1
+            self.state = 'open'
+            self.opened_at = time.monotonic()
+    
+    def stats(self) -> Dict[str, Any]:
+        return {'state': self.state, 'consecutive_failures': self.consecutive_failures, **self.counters}
+
+class RemoteFormatter(Fixer):
+    """🛰️ FORMATAGE DISTANT - Service HTTPS par langage, requêtes signées, repli local via disjoncteur
+    
+    Requête  : {"language": ..., "file_name": ..., "content": ...}
+    Signature: X-ASF-Signature = HMAC-SHA256(secret, "<X-ASF-Timestamp>.<language>.<sha256(content)>")
+    Réponse  : {"content": ..., "fixes": [...], "sha256": <optionnel, du contenu renvoyé>}
+    """
+    
+    # Réponse au-delà : contenu rejeté (service défaillant ou compromis)
+    MAX_GROWTH = 2
+    
+    def __init__(self, language: str, url: str, secret: Optional[str] = None, timeout: float = 10,
+                 breaker: Optional[CircuitBreaker] = None):
+        host = urllib.parse.urlparse(url).hostname or ''
+        if not url.startswith('https://') and host not in ('localhost', '127.0.0.1'):
+            raise RemoteFormatterError(f"Remote formatter for {language} must use HTTPS: {url}")
+        self.name = host
+        self.language = language
+        self.url = url
+        self.secret = secret
+        self.http = ProviderHttpClient(timeout, max_retries=0)
+        self.breaker = breaker or CircuitBreaker()
+    
+    @classmethod
+    def load(cls, path: str) -> Dict[str, 'RemoteFormatter']:
+        """Fichier YAML : endpoints par langage, secret_env, timeout, failure_threshold, reset_timeout"""
+        try:
+            with open(path, 'r', encoding='utf-8') as f:
+                config = yaml.safe_load(f) or {}
+        except (IOError, yaml.YAMLError) as e:
+            raise RemoteFormatterError(f"Cannot read remote formatter configuration {path}: {e}")
+        if not isinstance(config, dict) or not isinstance(config.get('endpoints'), dict):
+            raise RemoteFormatterError(f"{path}: expected 'endpoints' mapping language -> URL")
+        secret = os.environ.get(config.get('secret_env', 'ASF_REMOTE_SECRET'))
+        return {language: cls(language, str(url), secret, float(config.get('timeout', 10)),
+                              CircuitBreaker(int(config.get('failure_threshold', 5)),
+                                             float(config.get('reset_timeout', 30))))
+                for language, url in config['endpoints'].items()}
+    
+    def sign(self, timestamp: str, content: str) -> str:
+        message = f"{timestamp}.{self.language}.{hashlib.sha256(content.encode('utf-8')).hexdigest()}"
+        return hmac.new(self.secret.encode('utf-8'), message.encode('utf-8'), hashlib.sha256).hexdigest()
+    
+    async def fix(self, file_path: str, content: str) -> FixOutcome:
+        if not self.breaker.allow():
+            raise RemoteFormatterError(f"Remote formatter {self.name} unavailable (circuit open)")
+        headers = {'Content-Type': 'application/json'}
+        if self.secret:
+            timestamp = str(int(time.time()))
+            headers.update({'X-ASF-Timestamp': timestamp, 'X-ASF-Signature': self.sign(timestamp, content)})
+        payload = {'language': self.language, 'file_name': Path(file_path).name, 'content': content}
+        try:
+            response = await asyncio.to_thread(self.http.request, 'POST', self.url, payload, headers)
+            outcome = self.validate(response, content)
+        except (ProviderHttpError, RemoteFormatterError) as e:
+            self.breaker.record_failure()
+            logger.warning("remote formatter failed, using local processing: %s", e,
+                           extra={'tool': self.name, 'file_path': file_path})
+            raise RemoteFormatterError(f"Remote formatter {self.name} failed: {e}")
+        self.breaker.record_success()
+        return outcome
+    
+    def validate(self, response: Any, content: str) -> FixOutcome:
+        """Réponse conforme au protocole, taille plausible, empreinte vérifiée si fournie"""
+        if not isinstance(response, dict) or not isinstance(response.get('content'), str):
+            raise RemoteFormatterError("response has no 'content' string")
+        fixed_content = response['content']
+        if len(fixed_content) > self.MAX_GROWTH * len(content) + 4096:
+            raise RemoteFormatterError("response content is implausibly large")
+        if content.strip() and not fixed_content.strip():
+            raise RemoteFormatterError("response content is empty")
+        digest = response.get('sha256')
+        if digest is not None and digest != hashlib.sha256(fixed_content.encode('utf-8')).hexdigest():
+            raise RemoteFormatterError("response checksum mismatch")
+        fixes = response.get('fixes', [])
+        if not isinstance(fixes, list):
+            raise RemoteFormatterError("'fixes' must be a list")
+        if not fixes and fixed_content != content:
+            fixes = [f"Applied remote formatter {self.name}"]
+        return FixOutcome(fixed_content, [str(fix) for fix in fixes], [], True)
+
+# Stratégies de correction d'un fichier : service distant, outils locaux, patterns internes seuls
+STRATEGIES = ('remote', 'local', 'pattern')
+
+class StrategyPolicy:
+    """🧭 POLITIQUE DE STRATÉGIE - Ordre d'essai des stratégies pour un fichier
+    
+    'local' et 'pattern' aboutissent toujours ; 'remote' peut échouer et passer la main.
+    Par défaut : service distant s'il est configuré, puis traitement local.
+    """
+    
+    name = 'default'
+    PARAMS: Dict[str, float] = {}
+    
+    def __init__(self, **params: float):
+        unknown = set(params) - set(self.PARAMS)
+        if unknown:
+            raise ValueError(f"Unknown parameters for policy {self.name}: {', '.join(sorted(unknown))}")
+        self.params = {**self.PARAMS, **params}
+    
+    @staticmethod
+    def registry() -> Dict[str, type]:
+        return {policy.name: policy for policy in (StrategyPolicy, SizeStrategyPolicy,
+                                                   LatencyStrategyPolicy, CostStrategyPolicy)}
+    
+    @staticmethod
+    def validate_override(value: Optional[str]) -> Optional[str]:
+        """Surcharge d'une requête : stratégie ou nom de politique (sans paramètres)"""
+        if value and value not in STRATEGIES and value not in StrategyPolicy.registry():
+            raise ValueError(f"Unknown strategy {value!r} (expected one of: "
+                             f"{', '.join(STRATEGIES + tuple(StrategyPolicy.registry()))})")
+        return value or None
+    
+    @staticmethod
+    def from_spec(spec: str) -> 'StrategyPolicy':
+        """"size", "cost:remote_per_kb=0.02,local_per_file=0.1", ..."""
+        name, _, options = spec.partition(':')
+        policies = StrategyPolicy.registry()
+        if name not in policies:
+            raise ValueError(f"Unknown strategy policy {name!r} (expected: {', '.join(policies)})")
+        params = {}
+        for option in filter(None, options.split(',')):
+            key, _, value = option.partition('=')
+            params[key.strip()] = float(value)
+        return policies[name](**params)
+    
+    def order(self, language: str, size: int, remote_available: bool) -> List[str]:
+        return ['remote', 'local'] if remote_available else ['local']
+    
+    def observe(self, strategy: str, language: str, seconds: float):
+        """Durée constatée d'une stratégie (politiques adaptatives)"""
+
+class SizeStrategyPolicy(StrategyPolicy):
+    """Petits fichiers : patterns seuls (pas de sous-processus) ; gros fichiers : service distant"""
+    
+    name = 'size'
+    PARAMS = {'pattern_max_bytes': 512, 'remote_min_bytes': 64 * 1024}
+    
+    def order(self, language: str, size: int, remote_available: bool) -> List[str]:
+        if size <= self.params['pattern_max_bytes']:
+            return ['pattern']
+        if remote_available and size >= self.params['remote_min_bytes']:
+            return ['remote', 'local']
+        return ['local']
+
+class LatencyStrategyPolicy(StrategyPolicy):
+    """Stratégie la plus rapide par langage (moyenne mobile exponentielle), chacune essayée au moins une fois"""
+    
+    name = 'latency'
+    PARAMS = {'alpha': 0.3}
+    
+    def __init__(self, **params: float):
+        super().__init__(**params)
+        self.latency: Dict[Tuple[str, str], float] = {}
+    
+    def order(self, language: str, size: int, remote_available: bool) -> List[str]:
+        if not remote_available:
+            return ['local']
+        remote = self.latency.get(('remote', language))
+        local = self.latency.get(('local', language))
+        if remote is None or (local is not None and remote <= local):
+            return ['remote', 'local']
+        return ['local']
+    
+    def observe(self, strategy: str, language: str, seconds: float):
+        key = (strategy, language)
+        previous = self.latency.get(key)
+        alpha = self.params['alpha']
+        self.latency[key] = seconds if previous is None else alpha * seconds + (1 - alpha) * previous
+
+class CostStrategyPolicy(StrategyPolicy):
+    """Stratégie la moins chère : service distant facturé au Ko, traitement local au fichier (unités libres)"""
+    
+    name = 'cost'
+    PARAMS = {'remote_per_kb': 0.01, 'local_per_file': 0.05}
+    
+    def order(self, language: str, size: int, remote_available: bool) -> List[str]:
+        if remote_available and size / 1024 * self.params['remote_per_kb'] < self.params['local_per_file']:
+            return ['remote', 'local']
+        return ['local']
+
+class HtmlFixer(Fixer):
+    """🌐 HTML - Indentation et guillemets d'attributs, délimiteurs de templates préservés
+    
+    Go html/template et Jinja2 ({{ }}, {% %}, {# #}) ainsi que ERB (<% %>) sont
+    masqués avant traitement puis restaurés tels quels.
+    """
+    
+    name = 'html'
+    
+    TEMPLATE_DELIMITERS = re.compile(r'{{.*?}}|{%.*?%}|{#.*?#}|<%.*?%>', re.DOTALL)
+    PLACEHOLDER = '\x00{}\x00'
+    TAG = re.compile(r'<(/?)([A-Za-z][\w:-]*)([^<>]*?)(/?)>')
+    UNQUOTED_ATTRIBUTE = re.compile(r'(\s[\w:@.-]+)=([^\s"\'<>=`]+)')
+    VOID_ELEMENTS = {'area', 'base', 'br', 'col', 'embed', 'hr', 'img', 'input', 'link',
+                     'meta', 'param', 'source', 'track', 'wbr'}
+    RAW_ELEMENTS = {'pre', 'script', 'style', 'textarea'}
+    
+    def __init__(self, indent: int = 2):
+        self.indent = indent
+    
+    async def fix(self, file_path: str, content: str) -> FixOutcome:
+        # Masquage des délimiteurs de templates
+        blocks: List[str] = []
+        def mask(match):
+            blocks.append(match.group(0))
+            return self.PLACEHOLDER.format(len(blocks) - 1)
+        masked = self.TEMPLATE_DELIMITERS.sub(mask, content)
+        
+        lines = masked.split('\n')
+        fixes = []
+        depth = 0
+        raw_element = None
+        
+        for i, line in enumerate(lines):
+            stripped = line.strip()
+            
+            # Contenu brut (<pre>, <script>, ...) conservé tel quel
+            if raw_element:
+                if re.search(rf'</{raw_element}\s*>', stripped, re.IGNORECASE):
+                    raw_element = None
+                    depth = max(depth - 1, 0)
+                    lines[i] = self._indented(stripped, depth) if stripped.lower().startswith('</') else line
+                continue
+            
+            if not stripped:
+                lines[i] = ''
+                continue
+            
+            # Guillemets des attributs
+            quoted = self.TAG.sub(lambda m: self._quote_attributes(m), stripped)
+            if quoted != stripped:
+                fixes.append(f"Fixed attribute_quoting on line {i+1}")
+                stripped = quoted
+            
+            opened, closed, leading_closes = self._tag_balance(stripped)
+            line_depth = max(depth - leading_closes, 0)
+            new_line = self._indented(stripped, line_depth)
+            if new_line != lines[i]:
+                if len(new_line) - len(stripped) != len(lines[i]) - len(lines[i].lstrip()):
+                    fixes.append(f"Fixed html_indentation on line {i+1}")
+                lines[i] = new_line
+            depth = max(depth + opened - closed, 0)
+            
+            for match in self.TAG.finditer(stripped):
+                name = match.group(2).lower()
+                if not match.group(1) and name in self.RAW_ELEMENTS and not re.search(
+                        rf'</{name}\s*>', stripped[match.end():], re.IGNORECASE):
+                    raw_element = name
+        
+        fixed = '\n'.join(lines)
+        fixed = re.sub('\x00(\\d+)\x00', lambda m: blocks[int(m.group(1))], fixed)
+        return FixOutcome(fixed, fixes, [], True)
+    
+    def _indented(self, stripped: str, depth: int) -> str:
+        return ' ' * (self.indent * depth) + stripped
+    
+    def _quote_attributes(self, match: re.Match) -> str:
+        slash, name, attributes, self_closing = match.groups()
+        if slash:
+            return match.group(0)
+        quoted = self.UNQUOTED_ATTRIBUTE.sub(r'\1="\2"', attributes)
+        return f"<{name}{quoted}{self_closing}>"
+    
+    def _tag_balance(self, line: str) -> Tuple[int, int, int]:
+        """Balises ouvertes, fermées, et fermetures en début de ligne"""
+        opened = closed = leading_closes = 0
+        leading = True
+        position = 0
+        for match in self.TAG.finditer(line):
+            if line[position:match.start()].strip():
+                leading = False
+            position = match.end()
+            
+            slash, name, _, self_closing = match.groups()
+            if slash:
+                closed += 1
+                if leading:
+                    leading_closes += 1
+            else:
+                leading = False
+                if not self_closing and name.lower() not in self.VOID_ELEMENTS:
+                    opened += 1
+        return opened, closed, leading_closes
+
+class FormatterToolFixer(Fixer):
+    """🔧 FORMATEUR EXTERNE - Outil du langage si disponible, sinon repli natif (hygiène seule par défaut)"""
+    
+    name = 'formatter'
+    tool = ''
+    
+    def __init__(self, shell_champion: ShellChampion):
+        self.shell_champion = shell_champion
+    
+    async def fix(self, file_path: str, content: str) -> FixOutcome:
+        if self.shell_champion.available_tools.get(self.tool, False):
+            success, formatted, errors = await self.shell_champion.execute_tool(self.tool, file_path, content)
+            if success:
+                fixes = [f"Applied {self.tool.replace('-', '_')}"] if formatted != content else []
+                return FixOutcome(formatted, fixes, [], True)
+            logger.info("%s failed, using fallback", self.tool, extra={'tool': self.tool, 'file_path': file_path})
+        return self.fallback(content)
+    
+    def fallback(self, content: str) -> FixOutcome:
+        return FixOutcome(content, [], [], True)
+
+class RFixer(FormatterToolFixer):
+    """📊 R - styler (via Rscript) si disponible
+    
+    Repli natif : affectation `<-` en début d'instruction, espaces autour de
+    `<-` et après les virgules (hors chaînes et commentaires).
+    """
+    
+    name = 'r'
+    tool = 'styler'
+    
+    ASSIGNMENT = re.compile(r'^(\s*)([A-Za-z.][\w.]*)\s*=(?!=)\s*')
+    SPACING_RULES = (
+        CustomRule('assignment_spacing', 'r', re.compile(r'(?<=\S)[ \t]*(<<?-)[ \t]*(?=\S)'), r' \1 ',
+                   'Spaces around <-', [], {'strings', 'comments'}),
+        CustomRule('comma_spacing', 'r', re.compile(r',(?=[^\s,\])])'), ', ',
+                   'Space after comma', [], {'strings', 'comments'})
+    )
+    
+    def fallback(self, content: str) -> FixOutcome:
+        lines = content.split('\n')
+        fixes = []
+        depth = 0
+        for i, line in enumerate(lines):
+            # `=` n'est une affectation qu'hors de tout appel (sinon argument nommé)
+            if depth == 0:
+                match = self.ASSIGNMENT.match(line)
+                if match and not any(start < match.end() for start, _ in masked_spans(line, 'r', {'strings', 'comments'})):
+                    lines[i] = f"{match.group(1)}{match.group(2)} <- " + line[match.end():]
+                    fixes.append(f"Fixed assignment_operator on line {i+1}")
+            code = line
+            for start, end in reversed(masked_spans(line, 'r', {'strings', 'comments'})):
+                code = code[:start] + code[end:]
+            depth = max(depth + code.count('(') + code.count('[') - code.count(')') - code.count(']'), 0)
+        
+        fixed = '\n'.join(lines)
+        for rule in self.SPACING_RULES:
+            fixed, changed = rule.apply(fixed)
+            fixes.extend(f"Fixed {rule.id} on line {line}" for line in changed)
+        return FixOutcome(fixed, fixes, [], True)
+
+class JuliaFixer(FormatterToolFixer):
+    """🔬 JULIA - JuliaFormatter si disponible, sinon hygiène seule"""
+    
+    name = 'julia'
+    tool = 'JuliaFormatter'
+
+class MatlabFixer(FormatterToolFixer):
+    """📐 MATLAB - `mh_style --fix` (MISS_HIT) si disponible, sinon hygiène seule"""
+    
+    name = 'matlab'
+    tool = 'mh_style'
+
+class ZigFixer(FormatterToolFixer):
+    """⚡ ZIG - `zig fmt` si disponible, sinon hygiène seule"""
+    
+    name = 'zig'
+    tool = 'zig-fmt'
+
+class NimFixer(FormatterToolFixer):
+    """👑 NIM - nimpretty si disponible, sinon hygiène seule"""
+    
+    name = 'nim'
+    tool = 'nimpretty'
+
+class DartFixer(Fixer):
+    """🎯 DART - `dart fix --apply` (optionnel) puis `dart format`, sinon hygiène seule"""
+    
+    name = 'dart'
+    
+    def __init__(self, shell_champion: ShellChampion, apply_fixes: bool = False):
+        self.shell_champion = shell_champion
+        self.apply_fixes = apply_fixes
+    
+    async def fix(self, file_path: str, content: str) -> FixOutcome:
+        tools = (['dart-fix'] if self.apply_fixes else []) + ['dart-format']
+        fixes, errors = [], []
+        for tool in tools:
+            if not self.shell_champion.available_tools.get(tool, False):
+                continue
+            success, formatted, tool_errors = await self.shell_champion.execute_tool(tool, file_path, content)
+            if not success:
+                logger.info("%s failed", tool, extra={'tool': tool, 'file_path': file_path})
+                errors.extend(line for line in tool_errors if line)
+                continue
+            if formatted != content:
+                fixes.append(f"Applied {tool.replace('-', '_')}")
+                content = formatted
+        return FixOutcome(content, fixes, errors, not errors or bool(fixes))
+
+class ScalaFixer(Fixer):
+    """🔺 SCALA - scalafmt avec le .scalafmt.conf du projet, sinon tri des imports
+    
+    scalafmt exige une configuration (clé `version`) : sans .scalafmt.conf
+    trouvé en remontant depuis le fichier, seul le repli natif s'applique.
+    """
+    
+    name = 'scala'
+    
+    CONFIG_FILE = '.scalafmt.conf'
+    IMPORT = re.compile(r'^import\s+[\w.`]+(\.\{[^{}]*\}|\._|\.\*)?\s*$')
+    TIMEOUT = 30
+    
+    def __init__(self, shell_champion: ShellChampion):
+        self.shell_champion = shell_champion
+    
+    def find_config(self, file_path: str) -> Optional[Path]:
+        """Recherche de .scalafmt.conf en remontant depuis le fichier (jusqu'à la racine git)"""
+        path = Path(file_path)
+        if not path.is_file():
+            return None
+        for directory in path.resolve().parents:
+            if (directory / self.CONFIG_FILE).is_file():
+                return directory / self.CONFIG_FILE
+            if (directory / '.git').exists():
+                break
+        return None
+    
+    async def fix(self, file_path: str, content: str) -> FixOutcome:
+        config = self.find_config(file_path)
+        if config is not None and self.shell_champion.available_tools.get('scalafmt', False):
+            outcome = await self._run_scalafmt(file_path, content, config)
+            if outcome.success:
+                return outcome
+            logger.info("scalafmt failed, using native fallback", extra={'tool': 'scalafmt', 'file_path': file_path})
+        return self._sort_imports(content)
+    
+    async def _run_scalafmt(self, file_path: str, content: str, config: Path) -> FixOutcome:
+        process = None
+        try:
+            process = await platform_subprocess_exec(
+                'scalafmt', '--stdin', '--non-interactive', '--quiet',
+                '--config', str(config), '--assume-filename', Path(file_path).name,
+                cwd=str(config.parent),
+                stdin=asyncio.subprocess.PIPE,
+                stdout=asyncio.subprocess.PIPE,
+                stderr=asyncio.subprocess.PIPE
+            )
+            stdout, stderr = await asyncio.wait_for(process.communicate(content.encode('utf-8')), timeout=self.TIMEOUT)
+        except asyncio.TimeoutError:
+            await self.shell_champion._kill_process(process)
+            logger.warning("tool execution timeout", extra={'tool': 'scalafmt', 'file_path': file_path})
+            return FixOutcome(content, [], ["scalafmt execution timeout"], False)
+        except asyncio.CancelledError:
+            await self.shell_champion._kill_process(process)
+            raise
+        
+        if process.returncode != 0:
+            return FixOutcome(content, [], [stderr.decode(errors='replace').strip()], False)
+        formatted = stdout.decode('utf-8')
+        fixes = ["Applied scalafmt"] if formatted != content else []
+        return FixOutcome(formatted, fixes, [], True)
+    
+    def _sort_imports(self, content: str) -> FixOutcome:
+        """Tri des suites consécutives d'imports sur une ligne (non indentés)"""
+        lines = content.split('\n')
+        fixes = []
+        i = 0
+        while i < len(lines):
+            if not self.IMPORT.match(lines[i]):
+                i += 1
+                continue
+            end = i
+            while end < len(lines) and self.IMPORT.match(lines[end]):
+                end += 1
+            ordered = sorted(lines[i:end], key=lambda l: l.split()[1].strip('`').lower())
+            if ordered != lines[i:end]:
+                lines[i:end] = ordered
+                fixes.append(f"Fixed sort_imports on lines {i+1}-{end}")
+            i = end
+        return FixOutcome('\n'.join(lines), fixes, [], True)
+
+class ExclusiveFormatterFixer(Fixer):
+    """🧱 FORMATEUR EXCLUSIF - Une seule commande de formatage, aucun repli par fichier
+    
+    Langages trop sensibles aux espaces pour des heuristiques : sans outil
+    (ou s'il échoue), le fichier reste inchangé, hygiène comprise.
+    """
+    
+    name = 'exclusive'
+    hygiene = False
+    # Premier outil disponible, par ordre de préférence
+    tools: Tuple[str, ...] = ()
+    
+    def __init__(self, shell_champion: ShellChampion):
+        self.shell_champion = shell_champion
+    
+    async def fix(self, file_path: str, content: str) -> FixOutcome:
+        tool = next((t for t in self.tools if self.shell_champion.available_tools.get(t, False)), None)
+        if tool is None:
+            logger.debug("no formatter available, file left unchanged", extra={'file_path': file_path})
+            return FixOutcome(content, [], [], True)
+        success, formatted, errors = await self.shell_champion.execute_tool(tool, file_path, content)
+        if not success:
+            logger.info("%s failed, file left unchanged", tool, extra={'tool': tool, 'file_path': file_path})
+            return FixOutcome(content, [], [line for line in errors if line], False)
+        fixes = [f"Applied {tool}"] if formatted != content else []
+        return FixOutcome(formatted, fixes, [], True)
+
+class ElixirFixer(ExclusiveFormatterFixer):
+    """💧 ELIXIR - `mix format`"""
+    
+    name = 'elixir'
+    tools = ('mix_format',)
+
+class ErlangFixer(ExclusiveFormatterFixer):
+    """📡 ERLANG - `erlfmt -w`"""
+    
+    name = 'erlang'
+    tools = ('erlfmt',)
+
+class HaskellFixer(ExclusiveFormatterFixer):
+    """λ HASKELL - ormolu, sinon brittany"""
+    
+    name = 'haskell'
+    tools = ('ormolu', 'brittany')
+
+class LuaFixer(FormatterToolFixer):
+    """🌙 LUA - stylua si disponible, contrôle d'équilibre des blocs (then/do/function ... end)
+    
+    Repli natif : indentation par profondeur de blocs, uniquement lorsque
+    les blocs sont équilibrés.
+    """
+    
+    name = 'lua'
+    tool = 'stylua'
+    
+    # Chaînes, chaînes longues [[...]] et commentaires (--[[ ]] / --) retirés avant le comptage
+    LITERALS = re.compile(r'--\[(=*)\[.*?\]\1\]|\[(=*)\[.*?\]\2\]|--[^\n]*|"(?:[^"\\\n]|\\.)*"|\'(?:[^\'\\\n]|\\.)*\'',
+                          re.DOTALL)
+    OPENERS = re.compile(r'\b(function|do|then|repeat)\b')
+    CLOSERS = re.compile(r'\b(end|until)\b')
+    
+    async def fix(self, file_path: str, content: str) -> FixOutcome:
+        outcome = await super().fix(file_path, content)
+        outcome.errors.extend(self.balance_errors(outcome.content))
+        return outcome
+    
+    def _code_lines(self, content: str) -> List[str]:
+        """Lignes sans littéraux ni commentaires (un littéral multiligne devient des lignes vides)"""
+        stripped = self.LITERALS.sub(lambda match: '\n' * match.group(0).count('\n'), content)
+        return stripped.split('\n')
+    
+    def _line_deltas(self, content: str) -> List[Tuple[int, int]]:
+        """Par ligne : fermetures en tête de ligne, puis variation nette de profondeur"""
+        deltas = []
+        for code in self._code_lines(content):
+            # `elseif ... then` poursuit le bloc du `if`
+            opened = len(self.OPENERS.findall(code)) - len(re.findall(r'\belseif\b', code))
+            closed = len(self.CLOSERS.findall(code))
+            leading = 1 if re.match(r'\s*(end|until|else|elseif)\b', code) else 0
+            deltas.append((leading, opened - closed))
+        return deltas
+    
+    def balance_errors(self, content: str) -> List[str]:
+        depth = 0
+        for i, (_, delta) in enumerate(self._line_deltas(content)):
+            depth += delta
+            if depth < 0:
+                return [f"Line {i+1}: unexpected 'end' (unbalanced block)"]
+        last_line = content.rstrip('\n').count('\n') + 1
+        return [f"Line {last_line}: {depth} unclosed block(s) - missing 'end'"] if depth else []
+    
+    def fallback(self, content: str) -> FixOutcome:
+        if self.balance_errors(content):
+            return FixOutcome(content, [], [], True)  # Blocs déséquilibrés : indentation impossible
+        lines = content.split('\n')
+        literal_lines = self._literal_lines(content)
+        fixes = []
+        depth = 0
+        for i, (leading, delta) in enumerate(self._line_deltas(content)):
+            stripped = lines[i].strip()
+            if stripped and i not in literal_lines:
+                new_line = '    ' * max(depth - leading, 0) + stripped
+                if new_line != lines[i]:
+                    lines[i] = new_line
+                    fixes.append(f"Fixed lua_indentation on line {i+1}")
+            depth += delta
+        return FixOutcome('\n'.join(lines), fixes, [], True)
+    
+    def _literal_lines(self, content: str) -> Set[int]:
+        """Lignes commençant à l'intérieur d'une chaîne longue ou d'un commentaire de bloc"""
+        inside = set()
+        for match in self.LITERALS.finditer(content):
+            first = content.count('\n', 0, match.start())
+            inside.update(range(first + 1, first + match.group(0).count('\n') + 1))
+        return inside
+
+class PerlFixer(FormatterToolFixer):
+    """🐪 PERL - perltidy si disponible
+    
+    Repli natif : indentation par accolades hors chaînes, regex, POD et
+    heredocs - ignorée dès que l'un d'eux est présent sur plusieurs lignes.
+    """
+    
+    name = 'perl'
+    tool = 'perltidy'
+    
+    MULTILINE_CONSTRUCTS = re.compile(r'^=\w+|<<~?\s*["\']?\w+|(?<![$@%&\w])(m|q[qwrx]?|s|tr|y)\s*[{(\[<]|__(END|DATA)__',
+                                      re.MULTILINE)
+    
+    def fallback(self, content: str) -> FixOutcome:
+        if self.MULTILINE_CONSTRUCTS.search(content):
+            return FixOutcome(content, [], [], True)
+        lines = content.split('\n')
+        fixes = []
+        depth = 0
+        for i, line in enumerate(lines):
+            code = line
+            for start, end in reversed(masked_spans(line, 'perl', {'strings', 'comments'})):
+                code = code[:start] + code[end:]
+            stripped = line.strip()
+            leading = 1 if code.lstrip().startswith('}') else 0
+            if stripped:
+                new_line = '    ' * max(depth - leading, 0) + stripped
+                if new_line != line:
+                    lines[i] = new_line
+                    fixes.append(f"Fixed perl_indentation on line {i+1}")
+            depth += code.count('{') - code.count('}')
+            if depth < 0:
+                # Accolades dans une regex ou un littéral non reconnu : contenu d'origine conservé
+                return FixOutcome(content, [], [], True)
+        return FixOutcome('\n'.join(lines), fixes, [], True)
+
+class StarlarkFixer(Fixer):
+    """🏗️ STARLARK - Fichiers BUILD/BUCK/WORKSPACE et .bzl : `buildifier --lint=fix` si disponible
+    
+    Repli natif : indentation de 4 espaces par bloc et par niveau de
+    parenthèses (style buildifier), chaînes simples entre guillemets doubles.
+    """
+    
+    name = 'starlark'
+    
+    INDENT = 4
+    CLOSING = (')', ']', '}')
+    
+    def __init__(self, shell_champion: ShellChampion):
+        self.shell_champion = shell_champion
+    
+    async def fix(self, file_path: str, content: str) -> FixOutcome:
+        if self.shell_champion.available_tools.get('buildifier', False):
+            success, formatted, errors = await self.shell_champion.execute_tool('buildifier', file_path, content)
+            if success:
+                fixes = ["Applied buildifier"] if formatted != content else []
+                return FixOutcome(formatted, fixes, [line for line in errors if line], True)
+            logger.info("buildifier failed, using native fallback", extra={'tool': 'buildifier', 'file_path': file_path})
+        return self._format_native(content)
+    
+    def _format_native(self, content: str) -> FixOutcome:
+        try:
+            tokens = list(tokenize.generate_tokens(io.StringIO(content).readline))
+        except (tokenize.TokenError, IndentationError, SyntaxError) as e:
+            return FixOutcome(content, [], [f"Cannot parse Starlark: {e}"], False)
+        
+        lines = content.split('\n')
+        fixes = []
+        # Ligne -> indentation attendue ; lignes dans une chaîne multiligne ou après '\' absentes
+        expected: Dict[int, int] = {}
+        quotes: Dict[int, List[Tuple[int, int, str]]] = {}
+        level = depth = 0
+        for kind, text, (row, col), (end_row, _), _ in tokens:
+            if kind == tokenize.INDENT:
+                level += 1
+                continue
+            if kind == tokenize.DEDENT:
+                level -= 1
+                continue
+            if kind in (tokenize.NL, tokenize.NEWLINE, tokenize.ENDMARKER):
+                continue
+            if row not in expected and (row < 2 or not lines[row - 2].rstrip().endswith('\\')):
+                closes = kind == tokenize.OP and text in self.CLOSING
+                expected[row] = self.INDENT * (level + max(depth - (1 if closes else 0), 0))
+            if kind == tokenize.OP and text in ('(', '[', '{'):
+                depth += 1
+            elif kind == tokenize.OP and text in self.CLOSING:
+                depth = max(depth - 1, 0)
+            elif (kind == tokenize.STRING and row == end_row and text.startswith("'")
+                  and not text.startswith("'''") and '"' not in text and '\\' not in text):
+                quotes.setdefault(row, []).append((col, col + len(text), f'"{text[1:-1]}"'))
+            for inner in range(row + 1, end_row + 1):
+                expected.pop(inner, None)  # Contenu d'une chaîne multiligne
+        
+        for row, replacements in quotes.items():
+            line = lines[row - 1]
+            for start, end, replacement in sorted(replacements, reverse=True):
+                line = line[:start] + replacement + line[end:]
+            lines[row - 1] = line
+            fixes.append(f"Fixed string_quotes on line {row}")
+        for row, indent in sorted(expected.items()):
+            line = lines[row - 1]
+            stripped = line.lstrip(' \t')
+            if stripped and line[:len(line) - len(stripped)] != ' ' * indent:
+                lines[row - 1] = ' ' * indent + stripped
+                fixes.append(f"Fixed starlark_indentation on line {row}")
+        return FixOutcome('\n'.join(lines), fixes, [], True)
+
+class MakefileFixer(Fixer):
+    """🛠️ MAKEFILE - Recettes indentées par tabulation, espacement des affectations
+    
+    Une recette indentée par des espaces est une vraie erreur de syntaxe
+    ("missing separator"). Blocs define/endef, continuations et fichiers
+    utilisant .RECIPEPREFIX laissés intacts.
+    """
+    
+    name = 'makefile'
+    
+    ASSIGNMENT = re.compile(r'^(?:(export|override)\s+)?([A-Za-z_][\w.-]*)[ \t]*(::=|:::=|:=|\?=|\+=|!=|=)[ \t]*(.*)$')
+    # Ligne de règle "cible: prérequis" (hors affectation :=)
+    RULE = re.compile(r'^[^\s#=][^=]*?(?<!:):(?![=:]*=)')
+    CONDITIONAL = re.compile(r'^\s*(ifeq|ifneq|ifdef|ifndef|else|endif)\b')
+    
+    async def fix(self, file_path: str, content: str) -> FixOutcome:
+        if re.search(r'^\.RECIPEPREFIX', content, re.MULTILINE):
+            return FixOutcome(content, [], [], True)
+        
+        lines = content.split('\n')
+        fixes = []
+        in_recipe = in_define = continued = False
+        for i, line in enumerate(lines):
+            stripped = line.strip()
+            previous_continued, continued = continued, line.rstrip().endswith('\\')
+            if in_define:
+                in_define = not stripped.startswith('endef')
+                continue
+            if previous_continued:
+                continue
+            if re.match(r'^(override\s+|export\s+)?define\b', stripped):
+                in_define = True
+                continue
+            
+            if line.startswith('\t'):
+                continue
+            if not stripped:
+                in_recipe = False
+                continue
+            if line[0] == ' ' and in_recipe and not self.CONDITIONAL.match(line):
+                lines[i] = '\t' + line.lstrip(' ')
+                fixes.append(f"Fixed recipe_tab on line {i+1}")
+                continue
+            if self.CONDITIONAL.match(line) or stripped.startswith('#'):
+                continue
+            
+            match = self.ASSIGNMENT.match(line)
+            if match and not line[0].isspace():
+                in_recipe = False
+                prefix, name, operator, value = match.groups()
+                normalized = f"{prefix + ' ' if prefix else ''}{name} {operator}{' ' + value if value else ''}"
+                if normalized != line:
+                    lines[i] = normalized
+                    fixes.append(f"Fixed assignment_spacing on line {i+1}")
+                continue
+            in_recipe = bool(self.RULE.match(line))
+        return FixOutcome('\n'.join(lines), fixes, [], True)
+
+class CMakeFixer(Fixer):
+    """🧱 CMAKE - Commandes en minuscules, indentation des blocs (if, foreach, function...)
+    
+    `cmake-format` si disponible, sinon formatage natif. Arguments entre
+    crochets et chaînes sur plusieurs lignes laissés intacts.
+    """
+    
+    name = 'cmake'
+    
+    COMMAND = re.compile(r'^([A-Za-z_]\w*)[ \t]*\(')
+    OPENERS = {'if', 'foreach', 'while', 'macro', 'function', 'block'}
+    CLOSERS = {'endif', 'endforeach', 'endwhile', 'endmacro', 'endfunction', 'endblock'}
+    MIDDLES = {'else', 'elseif'}
+    BRACKET_OPEN = re.compile(r'\[(=*)\[')
+    
+    def __init__(self, shell_champion: ShellChampion, indent: int = 2):
+        self.shell_champion = shell_champion
+        self.indent = indent
+    
+    async def fix(self, file_path: str, content: str) -> FixOutcome:
+        if self.shell_champion.available_tools.get('cmake-format', False):
+            success, formatted, errors = await self.shell_champion.execute_tool('cmake-format', file_path, content)
+            if success:
+                fixes = ["Applied cmake_format"] if formatted != content else []
+                return FixOutcome(formatted, fixes, [], True)
+            logger.info("cmake-format failed, using native fallback",
+                        extra={'tool': 'cmake-format', 'file_path': file_path})
+        return self._format_native(content)
+    
+    def _format_native(self, content: str) -> FixOutcome:
+        lines = content.split('\n')
+        fixes = []
+        depth = parens = 0
+        closing: Optional[str] = None  # Fin de l'argument entre crochets ou de la chaîne en cours
+        
+        for i, line in enumerate(lines):
+            if closing is not None:
+                # Contenu littéral : jamais réindenté
+                end = self._literal_end(line, 0, closing)
+                if end >= 0:
+                    parens, closing = self._scan(line, end, parens)
+                continue
+            stripped = line.strip()
+            if not stripped:
+                continue
+            
+            command = self.COMMAND.match(stripped) if parens == 0 else None
+            name = command.group(1).lower() if command else None
+            if command and command.group(0) != f"{name}(":
+                stripped = f"{name}(" + stripped[command.end():]
+                fixes.append(f"Fixed command_case on line {i+1}")
+            
+            level = depth - (1 if name in self.CLOSERS or name in self.MIDDLES else 0) + (1 if parens else 0)
+            indent = ' ' * (self.indent * max(level, 0))
+            if line[:len(line) - len(line.lstrip())] != indent:
+                fixes.append(f"Fixed cmake_indentation on line {i+1}")
+            lines[i] = indent + stripped
+            
+            if name in self.OPENERS:
+                depth += 1
+            elif name in self.CLOSERS:
+                depth = max(depth - 1, 0)
+            parens, closing = self._scan(stripped, 0, parens)
+        return FixOutcome('\n'.join(lines), fixes, [], True)
+    
+    def _scan(self, line: str, start: int, parens: int) -> Tuple[int, Optional[str]]:
+        """Profondeur de parenthèses en fin de ligne, et littéral resté ouvert"""
+        i = start
+        while i < len(line):
+            char = line[i]
+            bracket = self.BRACKET_OPEN.match(line, i + 1 if char == '#' else i)
+            if char == '#' and not bracket:
+                break  # Commentaire de fin de ligne
+            if bracket or char == '"':
+                closing = f"]{bracket.group(1)}]" if bracket else '"'
+                end = self._literal_end(line, bracket.end() if bracket else i + 1, closing)
+                if end < 0:
+                    return parens, closing
+                i = end
+                continue
+            if char == '(':
+                parens += 1
+            elif char == ')':
+                parens = max(parens - 1, 0)
+            i += 1
+        return parens, None
+    
+    @staticmethod
+    def _literal_end(line: str, start: int, closing: str) -> int:
+        """Position suivant la fin du littéral (chaîne ou crochets), -1 s'il continue"""
+        if closing != '"':
+            end = line.find(closing, start)
+            return end + len(closing) if end >= 0 else -1
+        i = start
+        while i < len(line):
+            if line[i] == '\\':
+                i += 2
+                continue
+            if line[i] == '"':
+                return i + 1
+            i += 1
+        return -1
+
+class ProtoFixer(Fixer):
+    """📡 PROTOBUF - `buf format` si disponible, sinon formatage natif
+    
+    Repli natif : indentation par accolades, tri des imports et des options
+    de fichier, alignement des champs consécutifs.
+    """
+    
+    name = 'protobuf'
+    
+    IMPORT = re.compile(r'^import\s+(public\s+|weak\s+)?"[^"]+"\s*;')
+    FILE_OPTION = re.compile(r'^option\s+([\w.()]+)\s*=')
+    FIELD = re.compile(r'^((?:repeated\s+|optional\s+|required\s+)?[\w.<>, ]+?)\s+(\w+)\s*=\s*(\d+)\s*(.*)$')
+    
+    def __init__(self, shell_champion: ShellChampion, indent: int = 2):
+        self.shell_champion = shell_champion
+        self.indent = indent
+    
+    async def fix(self, file_path: str, content: str) -> FixOutcome:
+        if self.shell_champion.available_tools.get('buf', False):
+            success, formatted, errors = await self.shell_champion.execute_tool('buf', file_path, content)
+            if success:
+                fixes = ["Applied buf_format"] if formatted != content else []
+                return FixOutcome(formatted, fixes, [], True)
+            logger.info("buf format failed, using native fallback", extra={'tool': 'buf', 'file_path': file_path})
+        
+        return self._format_native(content)
+    
+    def _format_native(self, content: str) -> FixOutcome:
+        lines = content.split('\n')
+        fixes = []
+        
+        # Indentation par profondeur d'accolades
+        depth = 0
+        for i, line in enumerate(lines):
+            stripped = line.strip()
+            code = stripped.split('//')[0]
+            line_depth = max(depth - (1 if code.startswith('}') else 0), 0)
+            new_line = ' ' * (self.indent * line_depth) + stripped if stripped else ''
+            if new_line != line:
+                if stripped:
+                    fixes.append(f"Fixed proto_indentation on line {i+1}")
+                lines[i] = new_line
+            depth = max(depth + code.count('{') - code.count('}'), 0)
+        
+        fixes.extend(self._sort_runs(lines, self.IMPORT, lambda l: l, 'sort_imports'))
+        fixes.extend(self._sort_runs(lines, self.FILE_OPTION,
+                                     lambda l: self.FILE_OPTION.match(l).group(1), 'sort_options'))
+        fixes.extend(self._align_fields(lines))
+        return FixOutcome('\n'.join(lines), fixes, [], True)
+    
+    def _sort_runs(self, lines: List[str], pattern: re.Pattern, key, rule_id: str) -> List[str]:
+        """Tri des suites consécutives de lignes (non indentées) correspondant au pattern"""
+        fixes = []
+        i = 0
+        while i < len(lines):
+            if not pattern.match(lines[i]):
+                i += 1
+                continue
+            end = i
+            while end < len(lines) and pattern.match(lines[end]):
+                end += 1
+            ordered = sorted(lines[i:end], key=key)
+            if ordered != lines[i:end]:
+                lines[i:end] = ordered
+                fixes.append(f"Fixed {rule_id} on lines {i+1}-{end}")
+            i = end
+        return fixes
+    
+    def _align_fields(self, lines: List[str]) -> List[str]:
+        """Alignement des `=` pour les champs consécutifs de même indentation"""
+        fixes = []
+        run: List[Tuple[int, str, Tuple[str, ...]]] = []
+        
+        def flush():
+            if len(run) >= 2:
+                type_width = max(len(' '.join(g[0].split())) for _, _, g in run)
+                name_width = max(len(g[1]) for _, _, g in run)
+                for index, indent, (field_type, name, number, rest) in run:
+                    aligned = f"{indent}{' '.join(field_type.split()).ljust(type_width)} {name.ljust(name_width)} = {number}{rest}"
+                    if aligned != lines[index]:
+                        lines[index] = aligned
+                        fixes.append(f"Fixed align_fields on line {index+1}")
+            run.clear()
+        
+        for i, line in enumerate(lines):
+            stripped = line.strip()
+            indent = line[:len(line) - len(line.lstrip())]
+            match = self.FIELD.match(stripped)
+            if (not match or stripped.startswith(('option ', 'reserved ', 'extensions ')) or
+                    (run and run[-1][1] != indent)):
+                flush()
+            if match and not stripped.startswith(('option ', 'reserved ', 'extensions ')):
+                field_type, name, number, rest = match.groups()
+                rest = rest.strip()
+                run.append((i, indent, (field_type, name, number, rest if rest.startswith(';') else f" {rest}")))
+        flush()
+        return fixes
+
+class GraphQLFixer(Fixer):
+    """🕸️ GRAPHQL - prettier si disponible, sinon formatage natif
+    
+    Repli natif : indentation, descriptions normalisées en block strings,
+    tri optionnel des champs dans les définitions de types (jamais dans les opérations).
+    """
+    
+    name = 'graphql'
+    
+    TYPE_DEFINITION = re.compile(r'^(extend\s+)?(type|input|interface|enum)\b')
+    STRING_DESCRIPTION = re.compile(r'^"((?:[^"\\]|\\.)*)"$')
+    BLOCK_DESCRIPTION = re.compile(r'^"""\s*(.*?)\s*"""$')
+    SIMPLE_FIELD = re.compile(r'^\w+\s*(\(.*\))?\s*(:\s*[\w!\[\]]+)?(\s*@.*)?$')
+    
+    def __init__(self, shell_champion: ShellChampion, indent: int = 2, sort_fields: bool = False):
+        self.shell_champion = shell_champion
+        self.indent = indent
+        self.sort_fields = sort_fields
+    
+    async def fix(self, file_path: str, content: str) -> FixOutcome:
+        if self.shell_champion.available_tools.get('prettier', False):
+            success, formatted, errors = await self.shell_champion.execute_tool('prettier', file_path, content)
+            if success:
+                fixes = ["Applied prettier"] if formatted != content else []
+                return FixOutcome(formatted, fixes, [], True)
+            logger.info("prettier failed, using native fallback", extra={'tool': 'prettier', 'file_path': file_path})
+        
+        return self._format_native(content)
+    
+    def _format_native(self, content: str) -> FixOutcome:
+        lines = content.split('\n')
+        fixes = []
+        depth = 0
+        in_block_string = False
+        type_body_depth = None
+        run: List[List[int]] = []
+        pending_description: Optional[int] = None
+        
+        for i, line in enumerate(lines):
+            stripped = line.strip()
+            
+            # Descriptions multi-lignes conservées
+            if in_block_string:
+                if stripped.endswith('"""'):
+                    in_block_string = False
+                continue
+            if stripped.startswith('"""') and (stripped == '"""' or not stripped.endswith('"""')):
+                in_block_string = True
+            
+            # Normalisation des descriptions sur une ligne
+            description = self.STRING_DESCRIPTION.match(stripped) or self.BLOCK_DESCRIPTION.match(stripped)
+            if description:
+                normalized = f'"""{description.group(1).strip()}"""'
+                if normalized != stripped:
+                    fixes.append(f"Fixed description_style on line {i+1}")
+                    stripped = normalized
+            
+            code = stripped.split('#')[0]
+            line_depth = max(depth - (1 if code.startswith(('}', ')')) else 0), 0)
+            new_line = ' ' * (self.indent * line_depth) + stripped if stripped else ''
+            if new_line != line:
+                if stripped and len(new_line) - len(stripped) != len(line) - len(line.lstrip()):
+                    fixes.append(f"Fixed graphql_indentation on line {i+1}")
+                lines[i] = new_line
+            
+            # Suites de champs simples (avec leur description) dans une définition de type
+            in_type_body = self.sort_fields and type_body_depth is not None and depth == type_body_depth
+            if in_type_body and description and not in_block_string:
+                pending_description = i
+            elif in_type_body and self.SIMPLE_FIELD.match(code.strip()):
+                run.append([pending_description, i] if pending_description is not None else [i])
+                pending_description = None
+            else:
+                fixes.extend(self._sort_run(lines, run))
+                run = []
+                pending_description = None
+            
+            if self.TYPE_DEFINITION.match(code) and code.rstrip().endswith('{'):
+                type_body_depth = depth + 1
+            depth = max(depth + code.count('{') + code.count('(') - code.count('}') - code.count(')'), 0)
+            if type_body_depth is not None and depth < type_body_depth:
+                fixes.extend(self._sort_run(lines, run))
+                run = []
+                type_body_depth = None
+        
+        fixes.extend(self._sort_run(lines, run))
+        return FixOutcome('\n'.join(lines), fixes, [], True)
+    
+    def _sort_run(self, lines: List[str], run: List[List[int]]) -> List[str]:
+        """Tri d'une suite de groupes [description?, champ] contigus"""
+        if len(run) < 2:
+            return []
+        groups = [[lines[i] for i in group] for group in run]
+        ordered = sorted(groups, key=lambda group: group[-1].strip().lower())
+        if ordered == groups:
+            return []
+        start, end = run[0][0], run[-1][-1]
+        lines[start:end + 1] = [line for group in ordered for line in group]
+        return [f"Fixed sort_fields on lines {start+1}-{end+1}"]
+
+class NotebookFixer(Fixer):
+    """📓 JUPYTER - Correction Python cellule par cellule
+    
+    Sorties et métadonnées conservées ; les lignes magiques (%, !) sont
+    masquées pendant la correction puis restaurées.
+    """
+    
+    name = 'jupyter'
+    
+    MAGIC_LINE = re.compile(r'^\s*[%!]')
+    MAGIC_PLACEHOLDER = '# __asf_magic_{}__'
+    MAGIC_PLACEHOLDER_LINE = re.compile(r'# __asf_magic_(\d+)__')
+    
+    def __init__(self, fix_python: Callable[[str, str], Awaitable[FixResult]]):
+        self.fix_python = fix_python
+    
+    async def fix(self, file_path: str, content: str) -> FixOutcome:
+        try:
+            notebook = json.loads(content)
+            cells = notebook['cells']
+        except (ValueError, KeyError, TypeError) as e:
+            raise ParseFailedError(f"Invalid notebook: {e}")
+        
+        fixes, errors = [], []
+        changed = False
+        for number, cell in enumerate(cells, 1):
+            if cell.get('cell_type') != 'code':
+                continue
+            source = cell.get('source', '')
+            source = ''.join(source) if isinstance(source, list) else source
+            if not source.strip():
+                continue
+            
+            masked, magics = self._mask_magics(source)
+            result = await self.fix_python(f"{file_path}[cell {number}].py", masked)
+            errors.extend(f"Cell {number}: {error}" for error in result.original_errors)
+            if result.fixed_content is None:
+                continue
+            
+            fixed = self._restore_magics(result.fixed_content, magics)
+            if not source.endswith('\n'):
+                fixed = fixed.rstrip('\n')
+            if fixed != source:
+                cell['source'] = fixed.splitlines(keepends=True)
+                fixes.extend(f"Cell {number}: {fix}" for fix in result.fixes_applied)
+                changed = True
+        
+        if not changed:
+            return FixOutcome(content, fixes, errors, True)
+        # Format d'écriture standard de Jupyter
+        return FixOutcome(json.dumps(notebook, indent=1, ensure_ascii=False) + '\n', fixes, errors, True)
+    
+    def _mask_magics(self, source: str) -> Tuple[str, List[str]]:
+        lines = source.split('\n')
+        magics = []
+        for i, line in enumerate(lines):
+            if self.MAGIC_LINE.match(line):
+                lines[i] = self.MAGIC_PLACEHOLDER.format(len(magics))
+                magics.append(line)
+        return '\n'.join(lines), magics
+    
+    def _restore_magics(self, source: str, magics: List[str]) -> str:
+        lines = source.split('\n')
+        for i, line in enumerate(lines):
+            match = self.MAGIC_PLACEHOLDER_LINE.search(line)
+            if match:
+                lines[i] = magics[int(match.group(1))]
+        return '\n'.join(lines)
+
+class XmlFormatter:
+    """📐 XML - Réindentation par profondeur d'éléments, sans reconstruire le document
+    
+    Seuls les blancs entre deux balises sont réécrits : commentaires, CDATA,
+    instructions de traitement et textes restent tels quels. Documents en
+    xml:space="preserve" ou à contenu mixte laissés intacts.
+    """
+    
+    TOKEN = re.compile(r'<!--.*?-->|<!\[CDATA\[.*?\]\]>|<\?.*?\?>|<!DOCTYPE(?:[^>\[]|\[.*?\])*>|<[^>]*>|[^<]+',
+                       re.DOTALL)
+    DEPENDENCIES = re.compile(r'(<dependencies>)(.*?)(</dependencies>)', re.DOTALL)
+    DEPENDENCY = re.compile(r'<dependency>.*?</dependency>', re.DOTALL)
+    START_TAG = re.compile(r'<([\w:.-]+)(.*?)(/?>)\Z', re.DOTALL)
+    ATTRIBUTE = re.compile(r'([\w:.-]+)\s*=\s*("[^"]*"|\'[^\']*\')')
+    
+    @staticmethod
+    def parse_error(content: str) -> Optional[str]:
+        """Message d'erreur si le document est mal formé"""
+        try:
+            ElementTree.fromstring(content.encode('utf-8'))
+        except ElementTree.ParseError as e:
+            return str(e)
+        return None
+    
+    @staticmethod
+    def detect_indent(content: str, default: int = 4) -> int:
+        match = re.search(r'^( +)<', content, re.MULTILINE)
+        return len(match.group(1)) if match else default
+    
+    @classmethod
+    def indent(cls, content: str, indent: int) -> str:
+        if 'xml:space="preserve"' in content:
+            return content
+        tokens = cls.TOKEN.findall(content)
+        if cls._mixed_content(tokens):
+            return content
+        
+        out = []
+        depth = 0
+        for position, token in enumerate(tokens):
+            if token.startswith('<'):
+                closing = token.startswith('</')
+                if closing:
+                    depth = max(depth - 1, 0)
+                out.append(token)
+                if not closing and cls._opens(token):
+                    depth += 1
+                continue
+            following = tokens[position + 1] if position + 1 < len(tokens) else None
+            previous = tokens[position - 1] if position else None
+            if (token.strip() or previous is None or following is None
+                    or (cls._opens(previous) and following.startswith('</'))):
+                out.append(token)  # Texte, élément vide, ou blancs de début / fin de document
+                continue
+            level = depth - 1 if following.startswith('</') else depth
+            out.append('\n' + ' ' * (indent * max(level, 0)))
+        return ''.join(out)
+    
+    @classmethod
+    def sort_dependencies(cls, content: str) -> Tuple[str, int]:
+        """Tri des <dependency> de chaque bloc <dependencies> par groupId:artifactId - retourne le nombre de blocs triés"""
+        sorted_blocks = 0
+        
+        def sort_block(match: re.Match) -> str:
+            nonlocal sorted_blocks
+            inner = match.group(2)
+            dependencies = cls.DEPENDENCY.findall(inner)
+            separators = cls.DEPENDENCY.split(inner)
+            # Commentaires ou autres éléments entre les dépendances : ordre d'origine conservé
+            if not dependencies or any(separator.strip() for separator in separators):
+                return match.group(0)
+            ordered = sorted(dependencies, key=cls._coordinates)
+            if ordered == dependencies:
+                return match.group(0)
+            sorted_blocks += 1
+            body = ''.join(separator + dependency for separator, dependency in zip(separators, ordered))
+            return match.group(1) + body + separators[-1] + match.group(3)
+        
+        return cls.DEPENDENCIES.sub(sort_block, content), sorted_blocks
+    
+    @staticmethod
+    def indent_fixes(before: str, after: str) -> List[str]:
+        """Entrées "Fixed xml_indentation" des lignes réindentées"""
+        if before == after:
+            return []
+        old, new = before.split('\n'), after.split('\n')
+        if len(old) != len(new):
+            # Lignes ajoutées : seule la première différence est signalée
+            first = next(i for i, (a, b) in enumerate(zip(old + [''], new + [''])) if a != b)
+            return [f"Fixed xml_indentation on line {first + 1}"]
+        return [f"Fixed xml_indentation on line {i + 1}" for i, (a, b) in enumerate(zip(old, new)) if a != b]
+    
+    @classmethod
+    def order_attributes(cls, content: str, mode: str) -> Tuple[str, List[int]]:
+        """Attributs des balises ouvrantes réordonnés (blancs entre attributs conservés) - retourne les lignes modifiées"""
+        out, rows = [], []
+        position = 0
+        for match in cls.TOKEN.finditer(content):
+            token = match.group(0)
+            if token.startswith('<') and not token.startswith(('<!', '<?', '</')):
+                reordered = cls._reorder(token, mode)
+                if reordered != token:
+                    rows.append(content.count('\n', 0, match.start()) + 1)
+                    token = reordered
+            out.append(content[position:match.start()] + token)
+            position = match.end()
+        out.append(content[position:])
+        return ''.join(out), rows
+    
+    @classmethod
+    def _reorder(cls, tag: str, mode: str) -> str:
+        match = cls.START_TAG.match(tag)
+        if not match:
+            return tag
+        name, body, end = match.groups()
+        parts = cls.ATTRIBUTE.split(body)
+        # parts = [blanc, nom, valeur, blanc, nom, valeur, ..., reste]
+        separators, attributes = parts[0::3], list(zip(parts[1::3], parts[2::3]))
+        if len(attributes) < 2 or any(separator.strip() for separator in separators):
+            return tag  # Balise non reconnue entièrement : laissée intacte
+        ordered = sorted(attributes, key=cls._android_key if mode == 'android' else lambda a: a[0])
+        body = ''.join(separator + f"{attr}={value}" for separator, (attr, value) in zip(separators, ordered))
+        return f"<{name}{body}{separators[-1]}{end}"
+    
+    @staticmethod
+    def _android_key(attribute: Tuple[str, str]) -> Tuple[int, str]:
+        """Ordre d'Android Studio : xmlns, id, layout_width/height, autres layout_*, le reste"""
+        name = attribute[0]
+        for rank, prefix in enumerate(('xmlns', 'android:id', 'android:layout_width', 'android:layout_height',
+                                       'android:layout_', 'android:', 'app:', 'tools:')):
+            if name.startswith(prefix):
+                return rank, name
+        return 8, name
+    
+    @staticmethod
+    def _coordinates(dependency: str) -> Tuple[str, str]:
+        group = re.search(r'<groupId>\s*(.*?)\s*</groupId>', dependency, re.DOTALL)
+        artifact = re.search(r'<artifactId>\s*(.*?)\s*</artifactId>', dependency, re.DOTALL)
+        return (group.group(1) if group else '', artifact.group(1) if artifact else '')
+    
+    @staticmethod
+    def _opens(token: str) -> bool:
+        """Balise ouvrante d'un élément (ni fermante, ni auto-fermante, ni commentaire / PI / DOCTYPE)"""
+        return not token.startswith(('<!', '<?', '</')) and not token.endswith('/>')
+    
+    @classmethod
+    def _mixed_content(cls, tokens: List[str]) -> bool:
+        """Texte et éléments enfants mêlés dans un même élément (XHTML, DocBook...)"""
+        children: List[int] = [0]
+        texts: List[bool] = [False]
+        for token in tokens:
+            if token.startswith('</'):
+                if len(children) > 1:
+                    has_children, has_text = children.pop(), texts.pop()
+                    if has_children and has_text:
+                        return True
+                continue
+            if token.startswith('<'):
+                if token.startswith(('<!--', '<?', '<!DOCTYPE')):
+                    continue
+                if token.startswith('<![CDATA['):
+                    texts[-1] = True
+                    continue
+                children[-1] += 1
+                if cls._opens(token):
+                    children.append(0)
+                    texts.append(False)
+                continue
+            if token.strip():
+                texts[-1] = True
+        return False
+
+class XmlFixer(Fixer):
+    """🧾 XML - Indentation et ordre des attributs (.xml, .xsd, .xsl, .plist, ressources Android)
+    
+    Options (.syntaxfixer.yml) :
+        xml:
+          indent: 2                       # défaut : unité du fichier
+          attribute_order: alphabetical   # ou android (fichiers déclarant xmlns:android)
+    """
+    
+    name = 'xml'
+    
+    ATTRIBUTE_ORDERS = ('alphabetical', 'android')
+    
+    def __init__(self, indent: Optional[int] = None, attribute_order: Optional[str] = None):
+        if attribute_order is not None and attribute_order not in self.ATTRIBUTE_ORDERS:
+            logger.error("unknown xml.attribute_order %r (expected: %s)", attribute_order,
+                         ', '.join(self.ATTRIBUTE_ORDERS))
+            attribute_order = None
+        self.indent = indent
+        self.attribute_order = attribute_order
+    
+    async def fix(self, file_path: str, content: str) -> FixOutcome:
+        error = XmlFormatter.parse_error(content)
+        if error is not None:
+            return FixOutcome(content, [], [f"Invalid XML: {error}"], False)
+        
+        fixes = []
+        fixed = content
+        mode = self.attribute_order
+        if mode == 'android' and 'xmlns:android=' not in content:
+            mode = None
+        if mode is not None:
+            fixed, rows = XmlFormatter.order_attributes(fixed, mode)
+            fixes.extend(f"Fixed attribute_order on line {row}" for row in rows)
+        indented = XmlFormatter.indent(fixed, self.indent or XmlFormatter.detect_indent(content, default=2))
+        fixes.extend(XmlFormatter.indent_fixes(fixed, indented))
+        return FixOutcome(indented, fixes, [], True)
+
+class GradleFixer(Fixer):
+    """🐘 GRADLE - build.gradle (npm-groovy-lint) et build.gradle.kts (ktlint), formatage à la spotless
+    
+    Sans outil installé, seule la passe d'hygiène du texte s'applique.
+    """
+    
+    name = 'gradle'
+    
+    def __init__(self, shell_champion: ShellChampion):
+        self.shell_champion = shell_champion
+    
+    async def fix(self, file_path: str, content: str) -> FixOutcome:
+        tool = 'ktlint' if file_path.endswith('.kts') else 'npm-groovy-lint'
+        if not self.shell_champion.available_tools.get(tool, False):
+            return FixOutcome(content, [], [], True)
+        success, formatted, errors = await self.shell_champion.execute_tool(tool, file_path, content)
+        if not success:
+            logger.info("%s failed, hygiene only", tool, extra={'tool': tool, 'file_path': file_path})
+            return FixOutcome(content, [], [line for line in errors if line], False)
+        fixes = [f"Applied {tool.replace('-', '_')}"] if formatted != content else []
+        return FixOutcome(formatted, fixes, [], True)
+
+class ManifestFixer(Fixer):
+    """📦 MANIFESTES - Tri des dépendances (package.json, go.mod, Cargo.toml, pyproject.toml, pom.xml, pubspec.yaml)"""
+    
+    name = 'manifest'
+    
+    PACKAGE_JSON_SECTIONS = ('dependencies', 'devDependencies', 'peerDependencies', 'optionalDependencies')
+    CARGO_TABLES = ('dependencies', 'dev-dependencies', 'build-dependencies')
+    PYPROJECT_TABLES = ('tool.poetry.dependencies', 'tool.poetry.dev-dependencies')
+    TOML_HEADER = re.compile(r'^\s*\[\[?([^\]]+)\]\]?\s*$')
+    GO_REQUIRE_BLOCK = re.compile(r'^require\s*\($')
+    TOML_STRING_ITEM = re.compile(r'^\s*"[^"]*",?\s*$')
+    PUBSPEC_SECTIONS = ('dependencies', 'dev_dependencies', 'dependency_overrides')
+    YAML_KEY = re.compile(r'^([ \t]*)([\w.-]+)\s*:')
+    JSON_SEPARATORS = re.compile(r'[\s,]*')
+    JSON_COLON = re.compile(r'\s*:\s*')
+    JSON_SCALAR = re.compile(r'[^\s,\]}]+')
+    
+    def __init__(self, shell_champion: ShellChampion, pom_indent: Optional[int] = None,
+                 sort_pom_dependencies: bool = False):
+        self.shell_champion = shell_champion
+        self.pom_indent = pom_indent
+        self.sort_pom_dependencies = sort_pom_dependencies
+    
+    async def fix(self, file_path: str, content: str) -> FixOutcome:
+        name = Path(file_path).name
+        if name == 'pom.xml':
+            return self._fix_pom(content)
+        if name == 'package.json':
+            return self._fix_package_json(content)
+        if name == 'go.mod':
+            outcome = self._fix_go_mod(content)
+            outcome.errors.extend(await self._check_go_mod_tidy(file_path))
+            return outcome
+        if name == 'Cargo.toml':
+            lines = content.split('\n')
+            fixes = self._sort_toml_tables(lines, self.CARGO_TABLES)
+            return FixOutcome('\n'.join(lines), fixes, [], True)
+        if name == 'pubspec.yaml':
+            return self._fix_pubspec(content)
+        if name == 'pyproject.toml':
+            lines = content.split('\n')
+            fixes = self._sort_toml_tables(lines, self.PYPROJECT_TABLES, pinned=('python',))
+            fixes.extend(self._sort_toml_array(lines, 'project', 'dependencies'))
+            return FixOutcome('\n'.join(lines), fixes, [], True)
+        return FixOutcome(content, [], [], True)
+    
+    def _fix_pom(self, content: str) -> FixOutcome:
+        """Indentation XML (unité d'origine par défaut), tri optionnel des dépendances"""
+        error = XmlFormatter.parse_error(content)
+        if error is not None:
+            raise ParseFailedError(f"Invalid pom.xml: {error}")
+        
+        fixes = []
+        fixed = content
+        if self.sort_pom_dependencies:
+            fixed, count = XmlFormatter.sort_dependencies(fixed)
+            if count:
+                fixes.append(f"Fixed sort_dependencies in {count} <dependencies> blocks")
+        indent = self.pom_indent or XmlFormatter.detect_indent(content)
+        indented = XmlFormatter.indent(fixed, indent)
+        fixes.extend(XmlFormatter.indent_fixes(fixed, indented))
+        return FixOutcome(indented, fixes, [], True)
+    
+    def _fix_package_json(self, content: str) -> FixOutcome:
+        try:
+            manifest = json.loads(content)
+        except ValueError as e:
+            raise ParseFailedError(f"Invalid package.json: {e}")
+        if not isinstance(manifest, dict):
+            return FixOutcome(content, [], [], True)
+        
+        # Seules les entrées des sections triées sont déplacées : le reste du fichier est conservé à l'octet près
+        sections = {key: (value_start, end) for key, _, value_start, end
+                    in self._json_members(content, content.index('{'))}
+        fixes = []
+        edits = []
+        for section in self.PACKAGE_JSON_SECTIONS:
+            if section not in sections or not isinstance(manifest.get(section), dict):
+                continue
+            value_start, end = sections[section]
+            members = self._json_members(content, value_start)
+            keys = [key for key, _, _, _ in members]
+            if keys == sorted(keys):
+                continue
+            ordered = sorted(members, key=lambda member: member[0])
+            body = content[members[0][1]:members[-1][3]]
+            offset = members[0][1]
+            for member, replacement in reversed(list(zip(members, ordered))):
+                body = (body[:member[1] - offset] + content[replacement[1]:replacement[3]] +
+                        body[member[3] - offset:])
+            edits.append((members[0][1], members[-1][3], body))
+            fixes.append(f"Fixed sort_dependencies in {section}")
+        if not fixes:
+            return FixOutcome(content, [], [], True)
+        
+        fixed = content
+        for start, end, body in sorted(edits, reverse=True):
+            fixed = fixed[:start] + body + fixed[end:]
+        return FixOutcome(fixed, fixes, [], True)
+    
+    @classmethod
+    def _json_value_end(cls, text: str, i: int) -> int:
+        """Fin (exclue) de la valeur JSON commençant en i - texte déjà validé par json.loads"""
+        if text[i] == '"':
+            i += 1
+            while text[i] != '"':
+                i += 2 if text[i] == '\\' else 1
+            return i + 1
+        if text[i] in '{[':
+            depth = 0
+            while True:
+                if text[i] == '"':
+                    i = cls._json_value_end(text, i)
+                    continue
+                if text[i] in '{[':
+                    depth += 1
+                elif text[i] in '}]':
+                    depth -= 1
+                    if depth == 0:
+                        return i + 1
+                i += 1
+        return cls.JSON_SCALAR.match(text, i).end()
+    
+    @classmethod
+    def _json_members(cls, text: str, i: int) -> List[Tuple[str, int, int, int]]:
+        """Membres de l'objet JSON ouvert en i : (clé, début du membre, début de la valeur, fin)"""
+        members = []
+        i += 1
+        while True:
+            i = cls.JSON_SEPARATORS.match(text, i).end()
+            if text[i] == '}':
+                return members
+            key_end = cls._json_value_end(text, i)
+            value_start = cls.JSON_COLON.match(text, key_end).end()
+            end = cls._json_value_end(text, value_start)
+            members.append((json.loads(text[i:key_end]), i, value_start, end))
+            i = end
+    
+    def _fix_pubspec(self, content: str) -> FixOutcome:
+        """Tri des paquets (lint sort_pub_dependencies), entrées multi-lignes déplacées d'un bloc"""
+        try:
+            yaml.safe_load(content)
+        except yaml.YAMLError as e:
+            raise ParseFailedError(f"Invalid pubspec.yaml: {e}")
+        
+        lines = content.split('\n')
+        fixes = []
+        i = 0
+        while i < len(lines):
+            section = re.match(r'^([\w-]+):\s*$', lines[i])
+            i += 1
+            if not section or section.group(1) not in self.PUBSPEC_SECTIONS:
+                continue
+            
+            start = i
+            while i < len(lines) and (not lines[i].strip() or lines[i][0] in ' \t'):
+                i += 1
+            end = i
+            while end > start and not lines[end - 1].strip():
+                end -= 1
+            
+            # Commentaires et lignes vides : rattachement ambigu, section laissée telle quelle
+            block = lines[start:end]
+            if not block or any(not l.strip() or l.lstrip().startswith('#') for l in block):
+                continue
+            first = self.YAML_KEY.match(block[0])
+            if not first:
+                continue
+            entries = []
+            for line in block:
+                key = self.YAML_KEY.match(line)
+                if key and key.group(1) == first.group(1):
+                    entries.append([line])
+                elif len(line) - len(line.lstrip()) > len(first.group(1)):
+                    entries[-1].append(line)
+                else:
+                    entries = []
+                    break
+            ordered = sorted(entries, key=lambda e: self.YAML_KEY.match(e[0]).group(2).lower())
+            if entries and ordered != entries:
+                lines[start:end] = [line for entry in ordered for line in entry]
+                fixes.append(f"Fixed sort_dependencies in {section.group(1)}")
+        return FixOutcome('\n'.join(lines), fixes, [], True)
+    
+    def _fix_go_mod(self, content: str) -> FixOutcome:
+        """Tri des blocs require (commentaires // indirect conservés)"""
+        lines = content.split('\n')
+        fixes = []
+        i = 0
+        while i < len(lines):
+            if not self.GO_REQUIRE_BLOCK.match(lines[i].strip()):
+                i += 1
+                continue
+            start = i + 1
+            end = start
+            while end < len(lines) and lines[end].strip() != ')':
+                end += 1
+            block = lines[start:end]
+            if all(l.strip() and not l.strip().startswith('//') for l in block):
+                ordered = sorted(block, key=lambda l: l.strip().split()[0])
+                if ordered != block:
+                    lines[start:end] = ordered
+                    fixes.append(f"Fixed sort_requires on lines {start+1}-{end}")
+            i = end + 1
+        return FixOutcome('\n'.join(lines), fixes, [], True)
+    
+    async def _check_go_mod_tidy(self, file_path: str) -> List[str]:
+        """`go mod tidy -diff` si l'outil Go et le module sont disponibles"""
+        module_dir = Path(file_path).parent
+        if not shutil.which('go') or not Path(file_path).is_file():
+            return []
+        
+        process = None
+        try:
+            process = await platform_subprocess_exec(
+                'go', 'mod', 'tidy', '-diff',
+                cwd=str(module_dir),
+                stdout=asyncio.subprocess.PIPE,
+                stderr=asyncio.subprocess.PIPE
+            )
+            stdout, stderr = await asyncio.wait_for(process.communicate(), timeout=60)
+        except asyncio.TimeoutError:
+            await self.shell_champion._kill_process(process)
+            logger.warning("go mod tidy timeout", extra={'tool': 'go', 'file_path': file_path})
+            return []
+        except asyncio.CancelledError:
+            await self.shell_champion._kill_process(process)
+            raise
+        
+        if process.returncode == 0:
+            return []
+        if stdout.strip():
+            return ["go mod tidy would change go.mod/go.sum"]
+        logger.debug("go mod tidy check unavailable: %s", stderr.decode(errors='replace').strip(),
+                      extra={'tool': 'go', 'file_path': file_path})
+        return []
+    
+    def _sort_toml_tables(self, lines: List[str], tables: Tuple[str, ...],
+                          pinned: Tuple[str, ...] = ()) -> List[str]:
+        """Tri des clés des tables de dépendances (entrées sur une seule ligne uniquement)"""
+        fixes = []
+        i = 0
+        while i < len(lines):
+            header = self.TOML_HEADER.match(lines[i])
+            i += 1
+            if not header or header.group(1).strip() not in tables:
+                continue
+            
+            start = i
+            while i < len(lines) and not self.TOML_HEADER.match(lines[i]):
+                i += 1
+            end = i
+            while end > start and not lines[end - 1].strip():
+                end -= 1
+            
+            entries = lines[start:end]
+            if not entries or not all(self._single_line_entry(l) for l in entries):
+                continue
+            ordered = sorted(entries, key=lambda l: (l.split('=')[0].strip().strip('"') not in pinned,
+                                                      l.split('=')[0].strip().strip('"').lower()))
+            if ordered != entries:
+                lines[start:end] = ordered
+                fixes.append(f"Fixed sort_dependencies in [{header.group(1).strip()}]")
+        return fixes
+    
+    def _single_line_entry(self, line: str) -> bool:
+        stripped = line.strip()
+        if '=' not in stripped or stripped.startswith('#'):
+            return False
+        value = stripped.split('=', 1)[1]
+        return value.count('{') == value.count('}') and value.count('[') == value.count(']')
+    
+    def _sort_toml_array(self, lines: List[str], table: str, key: str) -> List[str]:
+        """Tri d'un tableau multi-lignes (ex. [project] dependencies)"""
+        current_table = None
+        for i, line in enumerate(lines):
+            header = self.TOML_HEADER.match(line)
+            if header:
+                current_table = header.group(1).strip()
+                continue
+            if current_table != table or not re.match(rf'^{re.escape(key)}\s*=\s*\[\s*$', line.strip()):
+                continue
+            
+            start = i + 1
+            end = start
+            while end < len(lines) and lines[end].strip() != ']':
+                end += 1
+            items = lines[start:end]
+            if end == len(lines) or not items or not all(self.TOML_STRING_ITEM.match(l) for l in items):
+                return []
+            
+            normalized = [l.rstrip().rstrip(',') + ',' for l in items]
+            ordered = sorted(normalized, key=lambda l: l.strip().lower())
+            if ordered != items:
+                lines[start:end] = ordered
+                return [f"Fixed sort_dependencies in [{table}] {key}"]
+            return []
+        return []
+
+class EditorConfig:
+    """📐 EDITORCONFIG - Propriétés d'un fichier (.editorconfig du plus lointain au plus proche)"""
+    
+    BRACES = re.compile(r'\{([^{}]*,[^{}]*)\}')
+    
+    def __init__(self):
+        self._cache: Dict[Path, Tuple[bool, List[Tuple[str, Dict[str, str]]]]] = {}
+    
+    def properties(self, file_path: str) -> Dict[str, str]:
+        path = Path(file_path).resolve()
+        chain = []
+        for directory in path.parents:
+            is_root, sections = self._load(directory / '.editorconfig')
+            chain.append((directory, sections))
+            if is_root:
+                break
+        
+        properties: Dict[str, str] = {}
+        for directory, sections in reversed(chain):
+            relative = path.relative_to(directory).as_posix()
+            for pattern, values in sections:
+                if self._matches(pattern, relative):
+                    properties.update(values)
+        return properties
+    
+    def _load(self, path: Path) -> Tuple[bool, List[Tuple[str, Dict[str, str]]]]:
+        if path not in self._cache:
+            is_root, sections, current = False, [], None
+            try:
+                with open(path, 'r', encoding='utf-8', errors='replace') as f:
+                    for line in f:
+                        line = line.strip()
+                        if not line or line[0] in '#;':
+                            continue
+                        if line.startswith('[') and line.endswith(']'):
+                            current = {}
+                            sections.append((line[1:-1], current))
+                        elif '=' in line:
+                            key, value = (part.strip().lower() for part in line.split('=', 1))
+                            if current is None:
+                                is_root = is_root or (key == 'root' and value == 'true')
+                            else:
+                                current[key] = value
+            except IOError:
+                pass
+            self._cache[path] = (is_root, sections)
+        return self._cache[path]
+    
+    @classmethod
+    def _matches(cls, pattern: str, relative: str) -> bool:
+        """Glob EditorConfig (`*`, `**`, `?`, `{a,b}`) - sans `/`, correspondance à tout niveau"""
+        variants = [pattern]
+        while any(cls.BRACES.search(v) for v in variants):
+            expanded = []
+            for variant in variants:
+                match = cls.BRACES.search(variant)
+                if match is None:
+                    expanded.append(variant)
+                    continue
+                for option in match.group(1).split(','):
+                    expanded.append(variant[:match.start()] + option + variant[match.end():])
+            variants = expanded
+        return any(CodeOwners._compile(variant).match(relative) for variant in variants)
+
+class TextHygieneFixer(Fixer):
+    """🧹 HYGIÈNE DU TEXTE - Espaces en fin de ligne, saut de ligne final, indentation mixte
+    
+    Appliqué à tout fichier texte ; les options viennent de .editorconfig
+    (trim_trailing_whitespace, insert_final_newline, indent_style, indent_size).
+    """
+    
+    name = 'hygiene'
+    
+    LEADING_INDENT = re.compile(r'[ \t]*')
+    
+    # Tabulations significatives : indentation jamais normalisée sans .editorconfig explicite
+    TAB_SENSITIVE_NAMES = {'Makefile', 'makefile', 'GNUmakefile'}
+    TAB_SENSITIVE_EXTENSIONS = {'.mk', '.go'}
+    MARKDOWN_EXTENSIONS = {'.md', '.markdown'}
+    
+    def __init__(self, editorconfig: Optional[EditorConfig] = None,
+                 rule_enabled: Callable[[str], bool] = lambda rule_id: True):
+        self.editorconfig = editorconfig or EditorConfig()
+        self.rule_enabled = rule_enabled
+    
+    async def fix(self, file_path: str, content: str) -> FixOutcome:
+        fixed, fixes = self.clean(file_path, content)
+        return FixOutcome(fixed, fixes, [], True)
+    
+    def clean(self, file_path: str, content: str) -> Tuple[str, List[str]]:
+        """Contenu nettoyé et corrections ("Fixed <règle> on line N")"""
+        rule_enabled = self.rule_enabled
+        if not content or '\x00' in content:
+            return content, []
+        
+        properties = self.editorconfig.properties(file_path)
+        path = Path(file_path)
+        markdown = path.suffix.lower() in self.MARKDOWN_EXTENSIONS
+        lines = content.split('\n')
+        fixes = []
+        
+        style, size = self._indent_style(path, lines, properties)
+        trim = properties.get('trim_trailing_whitespace') != 'false' and rule_enabled('trailing_whitespace')
+        normalize = style is not None and rule_enabled('mixed_indentation')
+        
+        for i, line in enumerate(lines):
+            eol = '\r' if line.endswith('\r') else ''
+            body = line[:-1] if eol else line
+            
+            if trim:
+                stripped = body.rstrip(' \t')
+                # Markdown : deux espaces finaux = saut de ligne forcé
+                if markdown and body.endswith('  ') and stripped:
+                    stripped += '  '
+                if stripped != body:
+                    body = stripped
+                    fixes.append(f"Fixed trailing_whitespace on line {i + 1}")
+            
+            if normalize:
+                indented = self._normalize_indent(body, style, size)
+                if indented != body:
+                    body = indented
+                    fixes.append(f"Fixed mixed_indentation on line {i + 1}")
+            
+            lines[i] = body + eol
+        
+        fixed = '\n'.join(lines)
+        if properties.get('insert_final_newline') != 'false' and rule_enabled('final_newline'):
+            newline = '\r\n' if '\r\n' in fixed else '\n'
+            with_final = fixed.rstrip('\r\n') + newline
+            if with_final != fixed and fixed.strip():
+                fixed = with_final
+                fixes.append("Fixed final_newline")
+        return fixed, fixes
+    
+    def _indent_style(self, path: Path, lines: List[str],
+                      properties: Dict[str, str]) -> Tuple[Optional[str], int]:
+        """Style d'indentation cible : .editorconfig, sinon majoritaire si le fichier est mixte"""
+        size_value = properties.get('indent_size')
+        if size_value == 'tab' or not (size_value or '').isdigit():
+            size_value = properties.get('tab_width', '4')
+        size = int(size_value) if size_value.isdigit() and int(size_value) > 0 else 4
+        
+        if properties.get('indent_style') in ('space', 'tab'):
+            return properties['indent_style'], size
+        if path.name in self.TAB_SENSITIVE_NAMES or path.suffix.lower() in self.TAB_SENSITIVE_EXTENSIONS:
+            return None, size
+        
+        tabs = sum(1 for line in lines if line.startswith('\t'))
+        spaces = sum(1 for line in lines if line.startswith(' ') and line.strip())
+        if not tabs or not spaces:
+            return None, size
+        return ('tab' if tabs > spaces else 'space'), size
+    
+    @classmethod
+    def _normalize_indent(cls, line: str, style: str, size: int) -> str:
+        indent = cls.LEADING_INDENT.match(line).group()
+        if not indent or (style == 'space' and '\t' not in indent) or (style == 'tab' and ' ' not in indent):
+            return line
+        
+        column = 0
+        for char in indent:
+            column = (column // size + 1) * size if char == '\t' else column + 1
+        new_indent = ' ' * column if style == 'space' else '\t' * (column // size) + ' ' * (column % size)
+        return new_indent + line[len(indent):]
+
+class UnicodeCleaner:
+    """✨ UNICODE - Guillemets typographiques et caractères invisibles hors chaînes et commentaires
+    
+    Fréquents après un copier-coller depuis une documentation : le compilateur
+    les rejette sans que les autres règles puissent les réparer.
+    """
+    
+    SMART_QUOTES = {'\u201c': '"', '\u201d': '"', '\u201e': '"', '\u2033': '"',
+                    '\u2018': "'", '\u2019': "'", '\u201a': "'", '\u2032': "'"}
+    SPACES = {'\u00a0': ' ', '\u2007': ' ', '\u202f': ' '}
+    INVISIBLE = {'\u200b', '\u200c', '\u200d', '\u2060', '\ufeff'}
+    BOM = '\ufeff'
+    
+    def __init__(self, rule_enabled: Callable[[str], bool] = lambda rule_id: True):
+        self.rule_enabled = rule_enabled
+    
+    def clean(self, content: str, language: str) -> Tuple[str, List[str]]:
+        if language not in COMMENT_MARKERS:
+            return content, []
+        fixes = []
+        
+        if content.startswith(self.BOM) and self.rule_enabled('bom'):
+            content = content[1:]
+            fixes.append("Fixed bom")
+        
+        replacements: Dict[str, str] = {}
+        if self.rule_enabled('smart_quotes'):
+            replacements.update(self.SMART_QUOTES)
+        if self.rule_enabled('invisible_chars'):
+            replacements.update(self.SPACES)
+            replacements.update({char: '' for char in self.INVISIBLE})
+        if not replacements or not any(char in content for char in replacements):
+            return content, fixes
+        
+        lines = content.split('\n')
+        for i, line in enumerate(lines):
+            if not any(char in line for char in replacements):
+                continue
+            spans = masked_spans(line, language, {'strings', 'comments'})
+            chars = list(line)
+            rules = set()
+            for position, char in enumerate(line):
+                if char in replacements and not any(start <= position < end for start, end in spans):
+                    chars[position] = replacements[char]
+                    rules.add('smart_quotes' if char in self.SMART_QUOTES else 'invisible_chars')
+            lines[i] = ''.join(chars)
+            fixes.extend(f"Fixed {rule} on line {i + 1}" for rule in sorted(rules))
+        return '\n'.join(lines), fixes
+
+class ImportMerger:
+    """🔗 IMPORTS - Fusion des imports dupliqués (Python, JS/TS) et détection des conflits d'alias
+    
+    Seuls les imports de premier niveau tenant sur une ligne sans commentaire
+    sont fusionnés ; les autres sont laissés tels quels.
+    """
+    
+    PY_FROM = re.compile(r'^from\s+([\w.]+)\s+import\s+\(?\s*([\w\s,]+?)\s*,?\s*\)?\s*$')
+    PY_IMPORT = re.compile(r'^import\s+([\w.]+(?:\s+as\s+\w+)?)\s*$')
+    JS_IMPORT = re.compile(r'^import\s+(?:(\w+)\s*(?:,\s*)?)?(?:\{([^{}]*)\})?\s*from\s+([\'"])([^\'"]+)\3\s*(;?)\s*$')
+    WHITESPACE_RUN = re.compile(r'\s+')
+    JS_REQUIRE = re.compile(r'^(?:const|let|var)\s+.+=\s*require\(\s*[\'"][^\'"]+[\'"]\s*\)\s*;?\s*$')
+    
+    def __init__(self, rule_enabled: Callable[[str], bool] = lambda rule_id: True):
+        self.rule_enabled = rule_enabled
+    
+    def merge(self, content: str, language: str) -> Tuple[str, List[str], List[str]]:
+        """(contenu, corrections, conflits)"""
+        if not self.rule_enabled('merge_imports'):
+            return content, [], []
+        if language == 'python':
+            return self._merge_python(content)
+        if language in ('javascript', 'typescript'):
+            return self._merge_javascript(content)
+        return content, [], []
+    
+    def _merge_python(self, content: str) -> Tuple[str, List[str], List[str]]:
+        lines = content.split('\n')
+        first_from: Dict[str, int] = {}
+        names: Dict[int, List[str]] = {}
+        seen_imports: Set[str] = set()
+        bindings: Dict[str, Tuple[str, int]] = {}
+        removed: Set[int] = set()
+        fixes, conflicts = [], []
+        
+        for i, line in enumerate(lines):
+            match = self.PY_FROM.match(line)
+            if match:
+                module = match.group(1)
+                items = [' '.join(item.split()) for item in match.group(2).split(',') if item.strip()]
+                for item in items:
+                    conflicts.extend(self._bind(bindings, item.split(' as ')[-1], f"{module}.{item.split(' as ')[0]}", i))
+                if module in first_from:
+                    target = first_from[module]
+                    names[target].extend(item for item in items if item not in names[target])
+                    removed.add(i)
+                    fixes.append(f"Fixed merge_imports on line {i + 1}")
+                else:
+                    first_from[module] = i
+                    names[i] = list(dict.fromkeys(items))
+                continue
+            
+            match = self.PY_IMPORT.match(line)
+            if match:
+                statement = ' '.join(match.group(1).split())
+                if statement in seen_imports:
+                    removed.add(i)
+                    fixes.append(f"Fixed merge_imports on line {i + 1}")
+                else:
+                    seen_imports.add(statement)
+                    module, _, alias = statement.partition(' as ')
+                    # `import a.b` lie `a`, comme `import a`
+                    root = module.split('.')[0]
+                    conflicts.extend(self._bind(bindings, alias or root, module if alias else root, i))
+        
+        if not removed:
+            return content, [], conflicts
+        for index, items in names.items():
+            module = self.PY_FROM.match(lines[index]).group(1)
+            lines[index] = f"from {module} import {', '.join(items)}"
+        return '\n'.join(l for i, l in enumerate(lines) if i not in removed), fixes, conflicts
+    
+    def _merge_javascript(self, content: str) -> Tuple[str, List[str], List[str]]:
+        lines = content.split('\n')
+        first_import: Dict[str, int] = {}
+        parts: Dict[int, Dict[str, Any]] = {}
+        seen_requires: Set[str] = set()
+        bindings: Dict[str, Tuple[str, int]] = {}
+        removed: Set[int] = set()
+        fixes, conflicts = [], []
+        
+        for i, line in enumerate(lines):
+            stripped = line.strip()
+            match = self.JS_IMPORT.match(stripped) if line == stripped else None
+            if match and (match.group(1) or match.group(2) is not None):
+                default, named, quote, module, semicolon = match.groups()
+                items = [' '.join(item.split()) for item in (named or '').split(',') if item.strip()]
+                for item in items:
+                    conflicts.extend(self._bind(bindings, item.split(' as ')[-1], f"{module}:{item.split(' as ')[0]}", i))
+                if default:
+                    conflicts.extend(self._bind(bindings, default, f"{module}:default", i))
+                
+                if module in first_import:
+                    target = parts[first_import[module]]
+                    if default and target['default'] and default != target['default']:
+                        continue  # Deux imports par défaut différents : laissés tels quels
+                    target['default'] = target['default'] or default
+                    target['named'].extend(item for item in items if item not in target['named'])
+                    removed.add(i)
+                    fixes.append(f"Fixed merge_imports on line {i + 1}")
+                else:
+                    first_import[module] = i
+                    parts[i] = {'default': default, 'named': list(dict.fromkeys(items)),
+                                'quote': quote, 'module': module, 'semicolon': semicolon}
+                continue
+            
+            if self.JS_REQUIRE.match(stripped) and line == stripped:
+                statement = self.WHITESPACE_RUN.sub(' ', stripped.rstrip(';'))
+                if statement in seen_requires:
+                    removed.add(i)
+                    fixes.append(f"Fixed merge_imports on line {i + 1}")
+                seen_requires.add(statement)
+        
+        if not removed:
+            return content, [], conflicts
+        for index, part in parts.items():
+            clauses = [part['default']] if part['default'] else []
+            if part['named']:
+                clauses.append('{ ' + ', '.join(part['named']) + ' }')
+            lines[index] = (f"import {', '.join(clauses)} from "
+                            f"{part['quote']}{part['module']}{part['quote']}{part['semicolon']}")
+        return '\n'.join(l for i, l in enumerate(lines) if i not in removed), fixes, conflicts
+    
+    @staticmethod
+    def _bind(bindings: Dict[str, Tuple[str, int]], name: str, source: str, index: int) -> List[str]:
+        """Enregistrement d'un nom local - conflit s'il désigne déjà autre chose"""
+        previous = bindings.get(name)
+        if previous is None:
+            bindings[name] = (source, index)
+            return []
+        if previous[0] == source:
+            return []
+        return [f"Line {index + 1}: Import alias conflict: '{name}' already imported "
+                f"from {previous[0]} on line {previous[1] + 1}"]
+
+class ModuleStyleConverter:
+    """🔀 MODULES JS - Conversion CommonJS ↔ ESM selon le type de module du projet (opt-in)
+    
+    `auto` suit le champ "type" du package.json le plus proche ; .mjs et .cjs
+    imposent leur style. Seules les instructions de premier niveau sont converties.
+    """
+    
+    REQUIRE = re.compile(r'^(?:const|let|var)\s+(\w+|\{[^{}]*\})\s*=\s*require\(\s*([\'"])([^\'"]+)\2\s*\)\s*(;?)\s*$')
+    IMPORT = re.compile(r'^import\s+(?:(\* as \w+|\w+|\{[^{}]*\})\s+from\s+)?([\'"])([^\'"]+)\2\s*(;?)\s*$')
+    
+    def __init__(self, style: str = 'auto'):
+        self.style = style
+        self._package_types: Dict[Path, Optional[str]] = {}
+    
+    def target_style(self, file_path: str) -> Optional[str]:
+        suffix = Path(file_path).suffix.lower()
+        if suffix == '.mjs':
+            return 'esm'
+        if suffix == '.cjs':
+            return 'commonjs'
+        if self.style in ('esm', 'commonjs'):
+            return self.style
+        package_type = self._package_type(Path(file_path).resolve().parent)
+        if package_type is None:
+            return None  # Hors d'un package : style indéterminé
+        return 'esm' if package_type == 'module' else 'commonjs'
+    
+    def _package_type(self, directory: Path) -> Optional[str]:
+        """Champ "type" du package.json le plus proche"""
+        if directory not in self._package_types:
+            package = directory / 'package.json'
+            if package.is_file():
+                try:
+                    with open(package, 'r', encoding='utf-8') as f:
+                        self._package_types[directory] = json.load(f).get('type', 'commonjs')
+                except (ValueError, IOError, AttributeError):
+                    self._package_types[directory] = 'commonjs'
+            elif directory.parent == directory:
+                self._package_types[directory] = None
+            else:
+                self._package_types[directory] = self._package_type(directory.parent)
+        return self._package_types[directory]
+    
+    def convert(self, file_path: str, content: str) -> Tuple[str, List[str]]:
+        target = self.target_style(file_path)
+        if target is None:
+            return content, []
+        lines = content.split('\n')
+        fixes = []
+        for i, line in enumerate(lines):
+            converted = self._to_esm(line) if target == 'esm' else self._to_commonjs(line)
+            if converted is not None and converted != line:
+                lines[i] = converted
+                fixes.append(f"Fixed module_style on line {i + 1}")
+        return '\n'.join(lines), fixes
+    
+    def _to_esm(self, line: str) -> Optional[str]:
+        match = self.REQUIRE.match(line)
+        if match is None:
+            return None
+        binding, quote, module, semicolon = match.groups()
+        if binding.startswith('{'):
+            # Déstructuration `{ a, b: c }` -> `{ a, b as c }`
+            binding = re.sub(r'(\w+)\s*:\s*(\w+)', r'\1 as \2', binding)
+        return f"import {binding} from {quote}{module}{quote}{semicolon}"
+    
+    def _to_commonjs(self, line: str) -> Optional[str]:
+        match = self.IMPORT.match(line)
+        if match is None:
+            return None
+        binding, quote, module, semicolon = match.groups()
+        require = f"require({quote}{module}{quote})"
+        if binding is None:
+            return f"{require}{semicolon}"
+        if binding.startswith('* as '):
+            binding = binding[5:]
+        elif binding.startswith('{'):
+            binding = re.sub(r'(\w+)\s+as\s+(\w+)', r'\1: \2', binding)
+        return f"const {binding} = {require}{semicolon}"
+
+class Baseline:
+    """📏 BASELINE - Constats existants acceptés, seuls les nouveaux sont rapportés"""
+    
+    DEFAULT_FILENAME = '.syntaxfixer-baseline.json'
+    VERSION = 1
+    
+    def __init__(self, findings: Optional[Dict[str, Dict[str, int]]] = None):
+        self.findings = findings or {}
+    
+    @staticmethod
+    def finding_key(error: str) -> str:
+        """Constat sans numéro de ligne (stable quand le code se décale)"""
+        return re.sub(r'^Line \d+: ', '', error)
+    
+    @classmethod
+    def from_results(cls, results: List[FixResult], root: Path) -> 'Baseline':
+        findings: Dict[str, Dict[str, int]] = {}
+        for result in results:
+            if not result.original_errors:
+                continue
+            counts = findings.setdefault(cls._relative(result.file_path, root), {})
+            for error in result.original_errors:
+                key = cls.finding_key(error)
+                counts[key] = counts.get(key, 0) + 1
+        return cls(findings)
+    
+    @classmethod
+    def load(cls, path: Path) -> Optional['Baseline']:
+        if not Path(path).is_file():
+            return None
+        try:
+            with open(path, 'r', encoding='utf-8') as f:
+                data = json.load(f)
+            return cls(data.get('findings', {}))
+        except (ValueError, IOError, AttributeError) as e:
+            logger.error("invalid baseline: %s", e, extra={'file_path': str(path)})
+            return None
+    
+    def save(self, path: Path):
+        with open(path, 'w', encoding='utf-8') as f:
+            json.dump({'version': self.VERSION, 'findings': self.findings}, f, indent=2, sort_keys=True)
+            f.write('\n')
+    
+    def filter(self, results: List[FixResult], root: Path) -> int:
+        """Retrait des constats déjà présents dans la baseline - retourne leur nombre"""
+        suppressed = 0
+        for result in results:
+            known = dict(self.findings.get(self._relative(result.file_path, root), {}))
+            if not known:
+                continue
+            
+            new_errors = []
+            for error in result.original_errors:
+                key = self.finding_key(error)
+                if known.get(key, 0) > 0:
+                    known[key] -= 1
+                    suppressed += 1
+                else:
+                    new_errors.append(error)
+            
+            result.original_errors = new_errors
+            if not new_errors:
+                # Fichier entièrement couvert par la baseline : rien à corriger
+                result.fixes_applied = []
+                result.fixed_content = None
+        return suppressed
+    
+    @staticmethod
+    def _relative(file_path: str, root: Path) -> str:
+        try:
+            return Path(file_path).resolve().relative_to(Path(root).resolve()).as_posix()
+        except ValueError:
+            return Path(file_path).as_posix()
+
+class ProjectConfigError(FixerError):
+    """Configuration de projet serveur invalide"""
+    code = 'invalid_project_config'
+
+class StorageConflictError(FixerError):
+    """Enregistrement déjà présent (contrainte d'unicité)"""
+    code = 'storage_conflict'
+
+class Database:
+    """🗄️ BASE DU SERVEUR - SQLite (fichier) ou PostgreSQL (URL postgresql://), migrations embarquées
+    
+    Connexion DB-API par opération : utilisable depuis n'importe quel thread.
+    Les migrations manquantes sont appliquées au premier accès (ou via --migrate).
+    """
+    
+    PLACEHOLDER = '?'
+    # (version, description, instructions) - ne jamais modifier une migration publiée, en ajouter une
+    MIGRATIONS: Tuple[Tuple[int, str, Tuple[str, ...]], ...] = (
+        (1, 'create projects', (
+            'CREATE TABLE IF NOT EXISTS projects ('
+            'name TEXT PRIMARY KEY, config TEXT NOT NULL, updated_at TEXT NOT NULL)',
+        )),
+        (2, 'create api keys and tiers', (
+            'CREATE TABLE IF NOT EXISTS api_keys (name TEXT PRIMARY KEY, key_hash TEXT UNIQUE NOT NULL, '
+            'tier TEXT NOT NULL, created_at TEXT NOT NULL, revoked_at TEXT)',
+            'CREATE TABLE IF NOT EXISTS tiers (name TEXT PRIMARY KEY, quota TEXT NOT NULL)',
+        )),
+        (3, 'create job history', (
+            'CREATE TABLE IF NOT EXISTS job_history (id TEXT PRIMARY KEY, run_id TEXT, kind TEXT NOT NULL, '
+            'target TEXT, client TEXT, status TEXT NOT NULL, error TEXT, started_at TEXT NOT NULL, finished_at TEXT)',
+            'CREATE INDEX IF NOT EXISTS job_history_started ON job_history (started_at)',
+        )),
+        (4, 'create pending jobs', (
+            'CREATE TABLE IF NOT EXISTS pending_jobs (id TEXT PRIMARY KEY, job TEXT NOT NULL, saved_at TEXT NOT NULL)',
+        )),
+        (5, 'create proposals', (
+            'CREATE TABLE IF NOT EXISTS proposals (id TEXT PRIMARY KEY, repo TEXT NOT NULL, base_ref TEXT NOT NULL, '
+            'base_sha TEXT NOT NULL, branch TEXT NOT NULL, commit_sha TEXT NOT NULL, files INTEGER, '
+            'status TEXT NOT NULL, pull_request INTEGER, created_at TEXT NOT NULL, decided_at TEXT, decided_by TEXT)',
+            'CREATE INDEX IF NOT EXISTS proposals_status ON proposals (status)',
+        )),
+    )
+    
+    def __init__(self, target: str):
+        self.target = target
+        self._migrated = False
+    
+    @staticmethod
+    def from_url(url: str) -> 'Database':
+        if url.startswith(('postgres://', 'postgresql://')):
+            return PostgresDatabase(url)
+        return SQLiteDatabase(url[len('sqlite:///'):] if url.startswith('sqlite:///') else url)
+    
+    @property
+    def driver(self) -> Any:
+        raise NotImplementedError
+    
+    def _open(self) -> Any:
+        raise NotImplementedError
+    
+    def _sql(self, statement: str) -> str:
+        return statement if self.PLACEHOLDER == '?' else statement.replace('?', self.PLACEHOLDER)
+    
+    def _lock_migrations(self, cursor: Any):
+        """Verrou empêchant deux réplicas de migrer en même temps"""
+    
+    @contextlib.contextmanager
+    def _cursor(self):
+        connection = self._open()
+        try:
+            if not self._migrated:
+                self._migrate(connection)
+            cursor = connection.cursor()
+            try:
+                yield cursor
+            except self.driver.IntegrityError as e:
+                connection.rollback()
+                raise StorageConflictError(str(e))
+            connection.commit()
+        finally:
+            connection.close()
+    
+    def query(self, statement: str, params: Tuple = ()) -> List[Tuple]:
+        with self._cursor() as cursor:
+            cursor.execute(self._sql(statement), params)
+            return cursor.fetchall()
+    
+    def execute(self, statement: str, params: Tuple = ()) -> int:
+        """Instruction sans résultat - retourne le nombre de lignes affectées"""
+        with self._cursor() as cursor:
+            cursor.execute(self._sql(statement), params)
+            return cursor.rowcount
+    
+    def migrate(self) -> List[str]:
+        """Application des migrations manquantes - retourne leurs descriptions"""
+        connection = self._open()
+        try:
+            return self._migrate(connection)
+        finally:
+            connection.close()
+    
+    def _migrate(self, connection: Any) -> List[str]:
+        cursor = connection.cursor()
+        cursor.execute('CREATE TABLE IF NOT EXISTS schema_migrations ('
+                       'version INTEGER PRIMARY KEY, description TEXT NOT NULL, applied_at TEXT NOT NULL)')
+        self._lock_migrations(cursor)
+        cursor.execute('SELECT version FROM schema_migrations')
+        applied = {row[0] for row in cursor.fetchall()}
+        done = []
+        for version, description, statements in self.MIGRATIONS:
+            if version in applied:
+                continue
+            for statement in statements:
+                cursor.execute(statement)
+            cursor.execute(self._sql('INSERT INTO schema_migrations (version, description, applied_at) VALUES (?, ?, ?)'),
+                           (version, description, datetime.now().isoformat(timespec='seconds')))
+            done.append(f"{version}: {description}")
+        connection.commit()
+        self._migrated = True
+        if done:
+            logger.info("applied %d database migrations", len(done), extra={'file_path': self.target})
+        return done
+
+class SQLiteDatabase(Database):
+    driver = sqlite3
+    
+    def _open(self) -> Any:
+        return sqlite3.connect(self.target)
+
+class PostgresDatabase(Database):
+    """PostgreSQL via psycopg (3) ou psycopg2 - paquet optionnel, importé à la première connexion"""
+    
+    PLACEHOLDER = '%s'
+    MIGRATION_LOCK = 0x617366  # Identifiant arbitraire pour pg_advisory_xact_lock
+    
+    @property
+    def driver(self) -> Any:
+        try:
+            import psycopg
+            return psycopg
+        except ImportError:
+            pass
+        try:
+            import psycopg2
+            return psycopg2
+        except ImportError:
+            raise FixerError("PostgreSQL storage requires the 'psycopg' package (pip install psycopg)")
+    
+    def _open(self) -> Any:
+        return self.driver.connect(self.target)
+    
+    def _lock_migrations(self, cursor: Any):
+        cursor.execute('SELECT pg_advisory_xact_lock(%s)', (self.MIGRATION_LOCK,))
+
+class ServerStore:
+    """🗄️ STOCKAGE DU SERVEUR - Base partagée (ASF_DB : chemin SQLite ou URL PostgreSQL)"""
+    
+    DEFAULT_PATH = 'asf-server.db'
+    
+    def __init__(self, url: Optional[str] = None):
+        self.db = Database.from_url(url or os.environ.get('ASF_DB', self.DEFAULT_PATH))
+
+class ProjectStore(ServerStore):
+    """🗄️ PROJETS - Configuration par repository enregistré en mode serveur
+    
+    La configuration d'un projet (règles, langages, nommage des branches,
+    comportement des PR) prime sur le .syntaxfixer.yml du repository.
+    """
+    
+    # `enabled_rules` : sélection de règles (`rules` désigne les règles personnalisées du .syntaxfixer.yml)
+    KEYS = {'enabled_rules', 'languages', 'exclude_languages', 'branch', 'pull_request'}
+    PULL_REQUEST_KEYS = {'push'}
+    
+    @classmethod
+    def validate(cls, config: Any) -> Dict[str, Any]:
+        if not isinstance(config, dict):
+            raise ProjectConfigError("Project configuration must be an object")
+        unknown = set(config) - cls.KEYS
+        if unknown:
+            raise ProjectConfigError(f"Unknown project settings: {', '.join(sorted(unknown))}")
+        for key in ('enabled_rules', 'languages', 'exclude_languages'):
+            value = config.get(key)
+            if value is not None and (not isinstance(value, list) or not all(isinstance(v, str) for v in value)):
+                raise ProjectConfigError(f"'{key}' must be a list of strings")
+        branch = config.get('branch')
+        if branch is not None:
+            if not isinstance(branch, str) or not branch.strip():
+                raise ProjectConfigError("'branch' must be a non-empty string")
+            try:
+                branch.format(date='', run_id='')
+            except (KeyError, IndexError, ValueError) as e:
+                raise ProjectConfigError(f"'branch' supports only {{date}} and {{run_id}}: {e}")
+        pull_request = config.get('pull_request')
+        if pull_request is not None:
+            if not isinstance(pull_request, dict) or set(pull_request) - cls.PULL_REQUEST_KEYS:
+                raise ProjectConfigError(f"'pull_request' accepts only: {', '.join(sorted(cls.PULL_REQUEST_KEYS))}")
+        return config
+    
+    @staticmethod
+    def key(name: str) -> str:
+        return name.strip('/').lower()
+    
+    def list(self) -> Dict[str, Dict[str, Any]]:
+        rows = self.db.query('SELECT name, config FROM projects ORDER BY name')
+        return {name: json.loads(config) for name, config in rows}
+    
+    def get(self, name: str) -> Optional[Dict[str, Any]]:
+        rows = self.db.query('SELECT config FROM projects WHERE name = ?', (self.key(name),))
+        return json.loads(rows[0][0]) if rows else None
+    
+    def put(self, name: str, config: Dict[str, Any]) -> Dict[str, Any]:
+        config = self.validate(config)
+        self.db.execute('INSERT INTO projects (name, config, updated_at) VALUES (?, ?, ?) '
+                        'ON CONFLICT (name) DO UPDATE SET config = excluded.config, updated_at = excluded.updated_at',
+                        (self.key(name), json.dumps(config), datetime.now().isoformat(timespec='seconds')))
+        return config
+    
+    def delete(self, name: str) -> bool:
+        return self.db.execute('DELETE FROM projects WHERE name = ?', (self.key(name),)) > 0
+    
+    @staticmethod
+    def branch_name(config: Dict[str, Any]) -> Optional[str]:
+        template = config.get('branch')
+        if not template:
+            return None
+        return template.format(date=datetime.now().strftime('%Y%m%d'), run_id=run_id_var.get())
+
+class ApiKeyStore(ServerStore):
+    """🔑 CLÉS D'API - Clés par client (seule l'empreinte SHA-256 est stockée) et quotas par niveau"""
+    
+    # Requêtes par heure (0 : illimité) ; ajustables par l'administration
+    DEFAULT_TIERS = {
+        'free': {'requests_per_hour': 60},
+        'pro': {'requests_per_hour': 1000},
+        'enterprise': {'requests_per_hour': 0}
+    }
+    
+    @staticmethod
+    def _hash(key: str) -> str:
+        return hashlib.sha256(key.encode()).hexdigest()
+    
+    def create(self, name: str, tier: str) -> str:
+        """Nouvelle clé - la valeur en clair n'est renvoyée qu'une fois"""
+        if tier not in self.tiers():
+            raise ValueError(f"Unknown tier {tier}")
+        key = 'asf_' + secrets.token_urlsafe(24)
+        self.db.execute('INSERT INTO api_keys (name, key_hash, tier, created_at) VALUES (?, ?, ?, ?)',
+                        (name, self._hash(key), tier, datetime.now().isoformat(timespec='seconds')))
+        return key
+    
+    def list(self) -> List[Dict[str, Any]]:
+        rows = self.db.query('SELECT name, tier, created_at, revoked_at FROM api_keys ORDER BY name')
+        return [{'name': name, 'tier': tier, 'created_at': created_at, 'revoked_at': revoked_at}
+                for name, tier, created_at, revoked_at in rows]
+    
+    def revoke(self, name: str) -> bool:
+        return self.db.execute('UPDATE api_keys SET revoked_at = ? WHERE name = ? AND revoked_at IS NULL',
+                               (datetime.now().isoformat(timespec='seconds'), name)) > 0
+    
+    def authenticate(self, key: str) -> Optional[Dict[str, str]]:
+        rows = self.db.query('SELECT name, tier FROM api_keys WHERE key_hash = ? AND revoked_at IS NULL',
+                             (self._hash(key),))
+        return {'name': rows[0][0], 'tier': rows[0][1]} if rows else None
+    
+    def tiers(self) -> Dict[str, Dict[str, Any]]:
+        tiers = {name: dict(quota) for name, quota in self.DEFAULT_TIERS.items()}
+        for name, quota in self.db.query('SELECT name, quota FROM tiers'):
+            tiers.setdefault(name, {}).update(json.loads(quota))
+        return tiers
+    
+    def set_quota(self, tier: str, quota: Dict[str, Any]) -> Dict[str, Any]:
+        limit = quota.get('requests_per_hour') if isinstance(quota, dict) else None
+        if not isinstance(limit, int) or isinstance(limit, bool) or limit < 0:
+            raise ValueError("'requests_per_hour' must be a non-negative integer (0: unlimited)")
+        self.db.execute('INSERT INTO tiers (name, quota) VALUES (?, ?) '
+                        'ON CONFLICT (name) DO UPDATE SET quota = excluded.quota',
+                        (tier, json.dumps({'requests_per_hour': limit})))
+        return self.tiers()[tier]
+
+class JobHistoryStore(ServerStore):
+    """🧾 HISTORIQUE DES TRAVAUX - Travaux terminés, conservés au-delà du redémarrage du serveur"""
+    
+    COLUMNS = ('id', 'run_id', 'kind', 'target', 'client', 'status', 'error', 'started_at', 'finished_at')
+    
+    def record(self, job: Dict[str, Any]):
+        self.db.execute(f"INSERT INTO job_history ({', '.join(self.COLUMNS)}) "
+                        f"VALUES ({', '.join('?' for _ in self.COLUMNS)})",
+                        tuple(job.get(column) for column in self.COLUMNS))
+    
+    def recent(self, limit: int = 100) -> List[Dict[str, Any]]:
+        rows = self.db.query(f"SELECT {', '.join(self.COLUMNS)} FROM job_history "
+                             f"ORDER BY started_at DESC LIMIT ?", (limit,))
+        return [dict(zip(self.COLUMNS, row)) for row in rows]
+
+class RateLimiter:
+    """⏳ LIMITEUR - Fenêtre glissante d'une heure par client, en mémoire (un seul processus)"""
+    
+    WINDOW = 3600.0
+    
+    def __init__(self):
+        self.hits: Dict[str, collections.deque] = {}
+    
+    def allow(self, client: str, limit: int) -> bool:
+        if limit <= 0:
+            return True
+        now = time.monotonic()
+        hits = self.hits.setdefault(client, collections.deque())
+        while hits and now - hits[0] > self.WINDOW:
+            hits.popleft()
+        if len(hits) >= limit:
+            return False
+        hits.append(now)
+        return True
+
+class PendingJobStore(ServerStore):
+    """⏸️ TRAVAUX SUSPENDUS - File en mémoire sauvegardée à l'arrêt, reprise au démarrage suivant"""
+    
+    def save(self, jobs: List[Dict[str, Any]]):
+        for job in jobs:
+            self.db.execute('INSERT INTO pending_jobs (id, job, saved_at) VALUES (?, ?, ?) '
+                            'ON CONFLICT (id) DO UPDATE SET job = excluded.job, saved_at = excluded.saved_at',
+                            (job['id'], json.dumps(job), datetime.now().isoformat(timespec='seconds')))
+    
+    def take(self) -> List[Dict[str, Any]]:
+        """Travaux suspendus, retirés de la base (ordre de sauvegarde)"""
+        rows = self.db.query('SELECT id, job FROM pending_jobs ORDER BY saved_at, id')
+        for job_id, _ in rows:
+            self.db.execute('DELETE FROM pending_jobs WHERE id = ?', (job_id,))
+        return [json.loads(job) for _, job in rows]
+
+class ProposalError(FixerError):
+    """Proposition inconnue, déjà traitée ou en conflit avec la branche cible"""
+    code = 'proposal_failed'
+    
+    def __init__(self, message: str, status: int = 409):
+        super().__init__(message)
+        self.status = status
+
+class ProposalStore(ServerStore):
+    """🗳️ PROPOSITIONS - Corrections poussées sur une branche de quarantaine, en attente d'approbation
+    
+    Première phase : commit sur la branche de quarantaine. Seconde phase
+    (approbation humaine) : rebase sur la branche cible, PR, fusion optionnelle.
+    """
+    
+    COLUMNS = ('id', 'repo', 'base_ref', 'base_sha', 'branch', 'commit_sha', 'files', 'status',
+               'pull_request', 'created_at', 'decided_at', 'decided_by')
+    BRANCH = 'asf/proposal-{run_id}'
+    
+    def create(self, repo: str, base_ref: str, base_sha: str, branch: str, commit_sha: str, files: int) -> Dict[str, Any]:
+        proposal = {'id': run_id_var.get(), 'repo': repo, 'base_ref': base_ref, 'base_sha': base_sha,
+                    'branch': branch, 'commit_sha': commit_sha, 'files': files, 'status': 'proposed',
+                    'created_at': datetime.now().isoformat(timespec='seconds')}
+        self.db.execute(f"INSERT INTO proposals ({', '.join(self.COLUMNS)}) "
+                        f"VALUES ({', '.join('?' for _ in self.COLUMNS)})",
+                        tuple(proposal.get(column) for column in self.COLUMNS))
+        return {column: proposal.get(column) for column in self.COLUMNS}
+    
+    def get(self, proposal_id: str) -> Optional[Dict[str, Any]]:
+        rows = self.db.query(f"SELECT {', '.join(self.COLUMNS)} FROM proposals WHERE id = ?", (proposal_id,))
+        return dict(zip(self.COLUMNS, rows[0])) if rows else None
+    
+    def list(self, status: Optional[str] = None) -> List[Dict[str, Any]]:
+        if status:
+            rows = self.db.query(f"SELECT {', '.join(self.COLUMNS)} FROM proposals WHERE status = ? "
+                                 f"ORDER BY created_at DESC", (status,))
+        else:
+            rows = self.db.query(f"SELECT {', '.join(self.COLUMNS)} FROM proposals ORDER BY created_at DESC")
+        return [dict(zip(self.COLUMNS, row)) for row in rows]
+    
+    def decide(self, proposal_id: str, status: str, commit_sha: str, pull_request: int,
+               decided_by: Optional[str]) -> Dict[str, Any]:
+        self.db.execute('UPDATE proposals SET status = ?, commit_sha = ?, pull_request = ?, decided_at = ?, '
+                        'decided_by = ? WHERE id = ?',
+                        (status, commit_sha, pull_request, datetime.now().isoformat(timespec='seconds'),
+                         decided_by, proposal_id))
+        return self.get(proposal_id)
+
+class JobTracker:
+    """🧾 TRAVAUX - Requêtes de correction en cours et derniers échecs (administration)"""
+    
+    MAX_RECENT = 100
+    
+    def __init__(self, history: Optional[JobHistoryStore] = None):
+        self.history = history
+        self.running: Dict[str, Dict[str, Any]] = {}
+        self.recent: collections.deque = collections.deque(maxlen=self.MAX_RECENT)
+        self.failures: collections.deque = collections.deque(maxlen=self.MAX_RECENT)
+    
+    @contextlib.contextmanager
+    def track(self, kind: str, target: str, client: Optional[str] = None):
+        job = {'id': uuid.uuid4().hex[:12], 'run_id': run_id_var.get(), 'kind': kind, 'target': target,
+               'client': client, 'started_at': datetime.now().isoformat(timespec='seconds'), 'status': 'running'}
+        self._started(job)
+        try:
+            yield job
+            job['status'] = 'succeeded'
+        except BaseException as e:
+            # HTTPException 4xx : erreur du client, pas un échec du service
+            job['status'] = 'cancelled' if isinstance(e, asyncio.CancelledError) else 'failed'
+            job['error'] = getattr(e, 'detail', None) or str(e) or type(e).__name__
+            if hasattr(e, 'status_code'):
+                job['status_code'] = e.status_code
+            raise
+        finally:
+            job['finished_at'] = datetime.now().isoformat(timespec='seconds')
+            self._finished(job, job['status'] == 'failed' and not 400 <= job.get('status_code', 500) < 500)
+            if self.history is not None:
+                try:
+                    self.history.record(job)
+                except Exception as e:
+                    logger.warning("cannot record job history: %s", e)
+    
+    def _started(self, job: Dict[str, Any]):
+        self.running[job['id']] = job
+    
+    def _finished(self, job: Dict[str, Any], failure: bool):
+        self.running.pop(job['id'], None)
+        self.recent.appendleft(job)
+        if failure:
+            self.failures.appendleft(job)
+    
+    def snapshot(self) -> Dict[str, List[Dict[str, Any]]]:
+        return {'running': list(self.running.values()), 'recent': list(self.recent)}
+    
+    def recent_failures(self) -> List[Dict[str, Any]]:
+        return list(self.failures)
+
+class JobQueue:
+    """📬 FILE DE TRAVAUX - Corrections asynchrones (POST /jobs) et résultats conservés RESULT_TTL secondes"""
+    
+    RESULT_TTL = 24 * 3600
+    MAX_RESULTS = 1000
+    
+    def __init__(self, pending: Optional[PendingJobStore] = None):
+        self.pending = pending
+        self.queue: Optional[asyncio.Queue] = None
+        self.results: collections.OrderedDict = collections.OrderedDict()
+    
+    async def put(self, job: Dict[str, Any]):
+        if self.queue is None:
+            self.queue = asyncio.Queue()
+        self.save(job['id'], {'id': job['id'], 'status': 'queued', 'kind': job['kind']})
+        await self.queue.put(job)
+    
+    async def get(self) -> Dict[str, Any]:
+        if self.queue is None:
+            self.queue = asyncio.Queue()
+        return await self.queue.get()
+    
+    def ack(self, job: Dict[str, Any]):
+        """Travail terminé (résultat enregistré) : retiré des travaux en cours de la file"""
+    
+    async def depth(self) -> int:
+        """Travaux en attente"""
+        return self.queue.qsize() if self.queue is not None else 0
+    
+    def save(self, job_id: str, payload: Dict[str, Any]):
+        self.results[job_id] = (time.monotonic(), payload)
+        self.results.move_to_end(job_id)
+        while len(self.results) > self.MAX_RESULTS:
+            self.results.popitem(last=False)
+    
+    def result(self, job_id: str) -> Optional[Dict[str, Any]]:
+        entry = self.results.get(job_id)
+        if entry is None or time.monotonic() - entry[0] > self.RESULT_TTL:
+            return None
+        return entry[1]
+    
+    def requeue(self, job: Dict[str, Any]):
+        """Travail interrompu par l'arrêt : conservé pour le prochain démarrage"""
+        self.save(job['id'], {'id': job['id'], 'status': 'queued', 'kind': job['kind']})
+        if self.pending is not None:
+            self.pending.save([job])
+    
+    def suspend(self) -> int:
+        """Arrêt : sauvegarde des travaux encore en file (perdus sans base)"""
+        jobs = []
+        while self.queue is not None and not self.queue.empty():
+            jobs.append(self.queue.get_nowait())
+        if jobs and self.pending is not None:
+            self.pending.save(jobs)
+        return len(jobs)
+    
+    async def resume(self) -> int:
+        """Démarrage : remise en file des travaux suspendus"""
+        jobs = await asyncio.to_thread(self.pending.take) if self.pending is not None else []
+        for job in jobs:
+            await self.put(job)
+        return len(jobs)
+
+class ResultCache:
+    """💾 CACHE DES RÉSULTATS - Correction d'un repository GitHub par commit, resservie sans relecture"""
+    
+    TTL = 24 * 3600
+    MAX_ENTRIES = 200
+    
+    def __init__(self):
+        self.entries: collections.OrderedDict = collections.OrderedDict()
+    
+    @staticmethod
+    def key(repo: str, commit: str, settings: Dict[str, Any]) -> str:
+        """Commit, version de l'outil et réglages du serveur (la configuration du repo suit le commit)"""
+        material = json.dumps({'tool': TOOL_VERSION, 'repo': repo.lower(), 'commit': commit, **settings},
+                              sort_keys=True, default=str)
+        return hashlib.sha256(material.encode()).hexdigest()
+    
+    def get(self, key: str) -> Optional[Dict[str, Any]]:
+        entry = self.entries.get(key)
+        if entry is None or time.monotonic() - entry[0] > self.TTL:
+            return None
+        self.entries.move_to_end(key)
+        return entry[1]
+    
+    def put(self, key: str, value: Dict[str, Any]):
+        self.entries[key] = (time.monotonic(), value)
+        self.entries.move_to_end(key)
+        while len(self.entries) > self.MAX_ENTRIES:
+            self.entries.popitem(last=False)
+
+class AnalysisStore:
+    """🏷️ DERNIÈRES ANALYSES - Synthèse par repository (owner/repo), servie par les badges"""
+    
+    def __init__(self):
+        self.entries: Dict[str, Dict[str, Any]] = {}
+    
+    def get(self, repo: str) -> Optional[Dict[str, Any]]:
+        return self.entries.get(repo)
+    
+    def put(self, repo: str, analysis: Dict[str, Any]):
+        self.entries[repo] = analysis
+
+# === BACKENDS REDIS (plusieurs réplicas derrière un répartiteur de charge) ===
+class RedisRateLimiter(RateLimiter):
+    """⏳ LIMITEUR REDIS - Fenêtre glissante partagée (sorted set par client)"""
+    
+    PREFIX = 'asf:rate:'
+    
+    # Purge, comptage et ajout en une seule opération : deux réplicas ne dépassent pas la limite ensemble
+    ALLOW_SCRIPT = """
+    local now = tonumber(ARGV[1])
+    local window = tonumber(ARGV[2])
+    redis.call('ZREMRANGEBYSCORE', KEYS[1], 0, now - window)
+    if redis.call('ZCARD', KEYS[1]) >= tonumber(ARGV[3]) then
+        return 0
+    end
+    redis.call('ZADD', KEYS[1], now, ARGV[4])
+    redis.call('EXPIRE', KEYS[1], window)
+    return 1
+    """
+    
+    def __init__(self, client: Any):
+        super().__init__()
+        self.client = client
+        self.allow_script = client.register_script(self.ALLOW_SCRIPT)
+    
+    def allow(self, client: str, limit: int) -> bool:
+        if limit <= 0:
+            return True
+        now = time.time()
+        return bool(self.allow_script(keys=[self.PREFIX + client],
+                                      args=[now, int(self.WINDOW), limit, f"{now}:{uuid.uuid4().hex[:8]}"]))
+
+class RedisJobTracker(JobTracker):
+    """🧾 TRAVAUX REDIS - État visible depuis toutes les réplicas"""
+    
+    RUNNING = 'asf:jobs:running'
+    RECENT = 'asf:jobs:recent'
+    FAILURES = 'asf:jobs:failures'
+    
+    def __init__(self, client: Any, history: Optional[JobHistoryStore] = None):
+        super().__init__(history)
+        self.client = client
+    
+    def _started(self, job: Dict[str, Any]):
+        self.client.hset(self.RUNNING, job['id'], json.dumps(job))
+    
+    def _finished(self, job: Dict[str, Any], failure: bool):
+        pipeline = self.client.pipeline()
+        pipeline.hdel(self.RUNNING, job['id'])
+        pipeline.lpush(self.RECENT, json.dumps(job))
+        pipeline.ltrim(self.RECENT, 0, self.MAX_RECENT - 1)
+        if failure:
+            pipeline.lpush(self.FAILURES, json.dumps(job))
+            pipeline.ltrim(self.FAILURES, 0, self.MAX_RECENT - 1)
+        pipeline.execute()
+    
+    def snapshot(self) -> Dict[str, List[Dict[str, Any]]]:
+        return {'running': [json.loads(job) for job in self.client.hvals(self.RUNNING)],
+                'recent': [json.loads(job) for job in self.client.lrange(self.RECENT, 0, -1)]}
+    
+    def recent_failures(self) -> List[Dict[str, Any]]:
+        return [json.loads(job) for job in self.client.lrange(self.FAILURES, 0, -1)]
+
+class RedisJobQueue(JobQueue):
+    """📬 FILE REDIS - Liste partagée consommée par les workers de chaque réplica, résultats avec TTL
+    
+    Un travail pris est déplacé (BLMOVE) dans la liste "en cours" de la réplica et n'en sort qu'à
+    l'acquittement : après un arrêt brutal, la réplica (même ASF_REPLICA_ID) le remet en file au démarrage.
+    """
+    
+    QUEUE = 'asf:queue'
+    PROCESSING_PREFIX = 'asf:queue:processing:'
+    RESULT_PREFIX = 'asf:result:'
+    POLL_TIMEOUT = 5
+    
+    def __init__(self, client: Any, replica: Optional[str] = None):
+        super().__init__()
+        self.client = client
+        self.processing = self.PROCESSING_PREFIX + (replica or os.environ.get('ASF_REPLICA_ID') or socket.gethostname())
+        # Élément brut de chaque travail en cours (LREM à l'acquittement)
+        self.taken: Dict[str, str] = {}
+    
+    async def put(self, job: Dict[str, Any]):
+        self.save(job['id'], {'id': job['id'], 'status': 'queued', 'kind': job['kind']})
+        await asyncio.to_thread(self.client.lpush, self.QUEUE, json.dumps(job))
+    
+    async def get(self) -> Dict[str, Any]:
+        while True:
+            item = await asyncio.to_thread(self.client.blmove, self.QUEUE, self.processing,
+                                           self.POLL_TIMEOUT, 'RIGHT', 'LEFT')
+            if item is not None:
+                job = json.loads(item)
+                self.taken[job['id']] = item
+                return job
+    
+    def ack(self, job: Dict[str, Any]):
+        item = self.taken.pop(job['id'], None)
+        if item is not None:
+            self.client.lrem(self.processing, 1, item)
+    
+    async def depth(self) -> int:
+        return int(await asyncio.to_thread(self.client.llen, self.QUEUE))
+    
+    def save(self, job_id: str, payload: Dict[str, Any]):
+        self.client.set(self.RESULT_PREFIX + job_id, json.dumps(payload), ex=self.RESULT_TTL)
+    
+    def result(self, job_id: str) -> Optional[Dict[str, Any]]:
+        payload = self.client.get(self.RESULT_PREFIX + job_id)
+        return json.loads(payload) if payload is not None else None
+    
+    def requeue(self, job: Dict[str, Any]):
+        # Côté consommateur de la liste : repris en premier par une autre réplica
+        self.save(job['id'], {'id': job['id'], 'status': 'queued', 'kind': job['kind']})
+        item = self.taken.pop(job['id'], None) or json.dumps(job)
+        pipeline = self.client.pipeline()
+        pipeline.rpush(self.QUEUE, item)
+        pipeline.lrem(self.processing, 1, item)
+        pipeline.execute()
+    
+    def suspend(self) -> int:
+        return 0  # La file reste dans Redis
+    
+    async def resume(self) -> int:
+        """Démarrage : travaux restés "en cours" après un arrêt brutal de cette réplica, remis en file"""
+        resumed = 0
+        while await asyncio.to_thread(self.client.lmove, self.processing, self.QUEUE, 'LEFT', 'RIGHT') is not None:
+            resumed += 1
+        return resumed
+
+class RedisResultCache(ResultCache):
+    """💾 CACHE REDIS - Résultats partagés entre réplicas, expirés par Redis"""
+    
+    PREFIX = 'asf:cache:'
+    
+    def __init__(self, client: Any):
+        super().__init__()
+        self.client = client
+    
+    def get(self, key: str) -> Optional[Dict[str, Any]]:
+        value = self.client.get(self.PREFIX + key)
+        return json.loads(value) if value is not None else None
+    
+    def put(self, key: str, value: Dict[str, Any]):
+        self.client.set(self.PREFIX + key, json.dumps(value), ex=self.TTL)
+
+class RedisAnalysisStore(AnalysisStore):
+    """🏷️ ANALYSES REDIS - Badges identiques quelle que soit la réplica qui répond"""
+    
+    KEY = 'asf:analyses'
+    
+    def __init__(self, client: Any):
+        super().__init__()
+        self.client = client
+    
+    def get(self, repo: str) -> Optional[Dict[str, Any]]:
+        value = self.client.hget(self.KEY, repo)
+        return json.loads(value) if value is not None else None
+    
+    def put(self, repo: str, analysis: Dict[str, Any]):
+        self.client.hset(self.KEY, repo, json.dumps(analysis))
+
+def redis_client(url: str) -> Any:
+    """Client Redis (paquet `redis` optionnel, installé seulement pour le déploiement multi-réplicas)"""
+    try:
+        import redis
+    except ImportError:
+        raise FixerError("Redis backend requires the 'redis' package (pip install redis)")
+    client = redis.Redis.from_url(url, decode_responses=True)
+    client.ping()
+    return client
+
+class RunHistory:
+    """📆 HISTORIQUE - Synthèse de chaque exécution, pour suivre l'évolution de l'hygiène du code"""
+    
+    DEFAULT_PATH = '.asf/history.json'
+    VERSION = 1
+    MAX_RUNS = 500
+    
+    def __init__(self, runs: Optional[List[Dict[str, Any]]] = None):
+        self.runs = runs or []
+    
+    @classmethod
+    def load(cls, path: Path) -> 'RunHistory':
+        if not Path(path).is_file():
+            return cls()
+        try:
+            with open(path, 'r', encoding='utf-8') as f:
+                return cls(list(json.load(f).get('runs', [])))
+        except (ValueError, IOError, AttributeError, TypeError) as e:
+            logger.error("invalid run history: %s", e, extra={'file_path': str(path)})
+            return cls()
+    
+    def save(self, path: Path):
+        Path(path).parent.mkdir(parents=True, exist_ok=True)
+        with open(path, 'w', encoding='utf-8') as f:
+            json.dump({'version': self.VERSION, 'runs': self.runs[-self.MAX_RUNS:]}, f, indent=2)
+            f.write('\n')
+    
+    def record(self, results: List[FixResult], root: Path) -> Dict[str, Any]:
+        """Ajout de la synthèse d'une exécution"""
+        rules: Dict[str, int] = {}
+        directories: Dict[str, int] = {}
+        for result in results:
+            for fix in result.fixes_applied:
+                rule = fix_rule_id(fix)
+                if rule != fix:  # Entrées "Fixed <rule>" / "Applied <tool>" uniquement
+                    rules[rule] = rules.get(rule, 0) + 1
+            if result.fixes_applied:
+                directory = Path(Baseline._relative(result.file_path, root)).parent.as_posix()
+                directories[directory] = directories.get(directory, 0) + len(result.fixes_applied)
+        
+        run = {
+            'timestamp': datetime.now().isoformat(timespec='seconds'),
+            'run_id': run_id_var.get(),
+            'files': len(results),
+            'files_changed': sum(1 for r in results if r.fixes_applied),
+            'errors': sum(len(r.original_errors) for r in results),
+            'fixes': sum(len(r.fixes_applied) for r in results),
+            'rules': rules,
+            'directories': directories
+        }
+        self.runs.append(run)
+        return run
+    
+    def trends(self, last: int = 10) -> Dict[str, Any]:
+        """Corrections par exécution, règles les plus fréquentes, répertoires les plus touchés"""
+        rules: Dict[str, int] = {}
+        directories: Dict[str, int] = {}
+        for run in self.runs:
+            for rule, count in run.get('rules', {}).items():
+                rules[rule] = rules.get(rule, 0) + count
+            for directory, count in run.get('directories', {}).items():
+                directories[directory] = directories.get(directory, 0) + count
+        
+        recent = self.runs[-last:]
+        return {
+            'runs': len(self.runs),
+            'recent': [{key: run.get(key) for key in ('timestamp', 'files', 'files_changed', 'fixes')}
+                       for run in recent],
+            # Variation des corrections entre la première et la dernière exécution récente
+            'fixes_delta': recent[-1].get('fixes', 0) - recent[0].get('fixes', 0) if recent else 0,
+            'top_rules': sorted(rules.items(), key=lambda item: (-item[1], item[0]))[:10],
+            'dirtiest_directories': sorted(directories.items(), key=lambda item: (-item[1], item[0]))[:10]
+        }
+
+class RunManifest:
+    """🧭 MANIFESTE D'EXÉCUTION - Résultats par fichier, pour reprendre une exécution interrompue
+    
+    JSON Lines ajoutées au fil de l'eau : chemin, empreinte du contenu, statut et
+    corrections, jamais le contenu corrigé. Un fichier sans correction est repris tel
+    quel ; un fichier corrigé est traité à nouveau (son contenu corrigé n'est pas conservé).
+    """
+    
+    DIRECTORY = '.asf/runs'
+    # Écriture par lots : un arrêt brutal perd au plus ce nombre de fichiers
+    SAVE_EVERY = 50
+    
+    def __init__(self, root: Path, run_id: str, files: Optional[Dict[str, Dict[str, Any]]] = None):
+        self.root = Path(root)
+        self.run_id = run_id
+        self.files = files or {}
+        self.pending: List[Dict[str, Any]] = []
+    
+    @classmethod
+    def path_for(cls, root: Path, run_id: str) -> Path:
+        return Path(root) / cls.DIRECTORY / f"{run_id}.jsonl"
+    
+    @classmethod
+    def load(cls, root: Path, run_id: str) -> Optional['RunManifest']:
+        path = cls.path_for(root, run_id)
+        if not path.is_file():
+            return None
+        files = {}
+        try:
+            with open(path, 'r', encoding='utf-8') as f:
+                for line in f:
+                    try:
+                        entry = json.loads(line)
+                    except ValueError:
+                        # Dernière ligne tronquée par un arrêt brutal
+                        continue
+                    if isinstance(entry, dict) and 'path' in entry:
+                        files[entry['path']] = entry
+        except IOError as e:
+            logger.error("invalid run manifest: %s", e, extra={'file_path': str(path)})
+            return None
+        return cls(root, run_id, files)
+    
+    @staticmethod
+    def _digest(content: str) -> str:
+        return hashlib.sha256(content.encode('utf-8')).hexdigest()
+    
+    @staticmethod
+    def status(result: FixResult) -> str:
+        return result.error_code or ('ok' if result.success else 'failed')
+    
+    def result_for(self, file_path: str, content: str) -> Optional[FixResult]:
+        """Résultat déjà calculé si le fichier n'a pas changé depuis et n'appelait aucune correction"""
+        entry = self.files.get(Baseline._relative(file_path, self.root))
+        if entry is None or entry.get('sha256') != self._digest(content) or entry.get('fixes'):
+            return None
+        status = entry.get('status')
+        return FixResult(file_path=file_path, original_errors=list(entry.get('errors') or []), fixes_applied=[],
+                         success=status == 'ok', language=entry.get('language', 'unknown'), processing_time=0.0,
+                         error_code=None if status in ('ok', 'failed') else status)
+    
+    def record(self, file_path: str, content: str, result: FixResult):
+        entry = {'path': Baseline._relative(file_path, self.root), 'sha256': self._digest(content),
+                 'status': self.status(result), 'language': result.language,
+                 'fixes': result.fixes_applied, 'errors': result.original_errors}
+        self.files[entry['path']] = entry
+        self.pending.append(entry)
+        if len(self.pending) >= self.SAVE_EVERY:
+            self.save()
+    
+    def save(self):
+        """Ajout des entrées en attente à la fin du manifeste"""
+        if not self.pending:
+            return
+        path = self.path_for(self.root, self.run_id)
+        try:
+            path.parent.mkdir(parents=True, exist_ok=True)
+            with open(path, 'a+b') as f:
+                # Ligne tronquée laissée par un arrêt brutal : les nouvelles entrées commencent sur une ligne propre
+                if f.tell() > 0:
+                    f.seek(-1, os.SEEK_END)
+                    if f.read(1) != b'\n':
+                        f.write(b'\n')
+                f.writelines((json.dumps(entry) + '\n').encode('utf-8') for entry in self.pending)
+            self.pending = []
+        except OSError as e:
+            logger.warning("cannot save run manifest: %s", e, extra={'file_path': str(path)})
+    
+    def remove(self):
+        """Exécution terminée : manifeste supprimé"""
+        with contextlib.suppress(OSError):
+            self.path_for(self.root, self.run_id).unlink()
+
+class FixLog:
+    """📜 JOURNAL DES CORRECTIONS - Historique lisible et versionné des modifications automatiques
+    
+    Une entrée par exécution ayant modifié des fichiers : date, commit de
+    départ, nombre de corrections par règle et fichiers touchés.
+    """
+    
+    DEFAULT_PATH = '.asf/fixes.log'
+    
+    @classmethod
+    def path_from_config(cls, value: Any) -> Optional[str]:
+        """`fix_log: true` (chemin par défaut) ou chemin relatif au repository (ex. CHANGELOG.md)"""
+        if value is True:
+            return cls.DEFAULT_PATH
+        if isinstance(value, str) and value:
+            return value
+        return None
+    
+    @staticmethod
+    def local_commit(root: Path) -> Optional[str]:
+        try:
+            completed = subprocess.run(['git', '-C', str(root), 'rev-parse', 'HEAD'],
+                                       capture_output=True, text=True, timeout=10)
+        except (OSError, subprocess.TimeoutExpired):
+            return None
+        return completed.stdout.strip() if completed.returncode == 0 else None
+    
+    @staticmethod
+    def entry(results: List[FixResult], root: Path, commit: Optional[str]) -> Optional[str]:
+        """Entrée Markdown de l'exécution (None si aucun fichier modifié)"""
+        changed = [r for r in results if r.fixes_applied]
+        if not changed:
+            return None
+        rules: Dict[str, int] = {}
+        for result in changed:
+            for fix in result.fixes_applied:
+                rule = fix_rule_id(fix)
+                rules[rule] = rules.get(rule, 0) + 1
+        
+        lines = [f"## {datetime.now().isoformat(timespec='seconds')} (run {run_id_var.get()})", '',
+                 f"- Commit: {commit or 'unknown'}",
+                 f"- Files changed: {len(changed)}",
+                 f"- Fixes: {sum(rules.values())}", '', 'Rules:']
+        lines.extend(f"- {rule}: {count}" for rule, count in sorted(rules.items(), key=lambda item: (-item[1], item[0])))
+        lines.extend(['', 'Files:'])
+        lines.extend(f"- {Baseline._relative(r.file_path, root)} ({len(r.fixes_applied)} fixes)"
+                     for r in sorted(changed, key=lambda r: r.file_path))
+        return '\n'.join(lines) + '\n'
+    
+    @staticmethod
+    def append(existing: str, entry: str) -> str:
+        if not existing:
+            return entry
+        return existing.rstrip('\n') + '\n\n' + entry
+    
+    @classmethod
+    def write(cls, path: Path, entry: str):
+        path = Path(path)
+        existing = path.read_text(encoding='utf-8') if path.is_file() else ''
+        path.parent.mkdir(parents=True, exist_ok=True)
+        path.write_text(cls.append(existing, entry), encoding='utf-8')
+
+class FixReverter:
+    """⏪ ANNULATION - Revert d'un commit de correction automatique (SHA, ou identifiant d'exécution)
+    
+    L'exécution est retrouvée par son trailer Asf-Run, sinon par le commit
+    ayant ajouté son entrée au journal des corrections. Si des commits
+    ultérieurs ont touché les mêmes fichiers, le revert n'est tenté que
+    s'il s'applique sans conflit ; sinon un rapport de conflit est produit.
+    """
+    
+    def __init__(self, root: Path, fix_log_path: Optional[str] = None):
+        self.root = Path(root)
+        self.fix_log_path = fix_log_path or FixLog.DEFAULT_PATH
+    
+    def _git(self, *args: str) -> subprocess.CompletedProcess:
+        return subprocess.run(['git', '-C', str(self.root), *args], capture_output=True, text=True, timeout=60)
+    
+    def resolve(self, target: str) -> str:
+        """SHA du commit de correction désigné par un commit ou un identifiant d'exécution"""
+        if re.fullmatch(r'[0-9a-fA-F]{7,40}', target):
+            completed = self._git('rev-parse', '--verify', '--quiet', f"{target}^{{commit}}")
+            if completed.returncode == 0:
+                return completed.stdout.strip()
+        
+        completed = self._git('log', '--format=%H', '--fixed-strings', f"--grep=Asf-Run: {target}")
+        commits = completed.stdout.split()
+        if not commits:
+            completed = self._git('log', '--format=%H', '-S', f"(run {target})", '--', self.fix_log_path)
+            commits = completed.stdout.split()
+        if not commits:
+            raise RevertError(f"No commit or recorded run matches {target!r}")
+        if len(commits) > 1:
+            raise RevertError(f"Run {target!r} matches {len(commits)} commits - revert by commit SHA")
+        return commits[0]
+    
+    def revert(self, target: str) -> Dict[str, Any]:
+        """Revert du commit ; rapport {commit, files, conflicts, reverted}"""
+        if self._git('status', '--porcelain', '--untracked-files=no').stdout.strip():
+            raise RevertError("Working tree has uncommitted changes - commit or stash them first")
+        commit = self.resolve(target)
+        files = self._git('diff-tree', '--no-commit-id', '--name-only', '-r', commit).stdout.split('\n')
+        files = [path for path in files if path]
+        
+        # Modifications humaines ultérieures sur les mêmes fichiers
+        later: Dict[str, List[str]] = {}
+        for path in files:
+            log = self._git('log', '--format=%h %s', f"{commit}..HEAD", '--', path).stdout
+            if log.strip():
+                later[path] = log.strip().split('\n')
+        
+        report = {'commit': commit, 'files': files, 'later_changes': later, 'conflicts': [], 'reverted': None,
+                  'diff_stats': DiffStats.from_git(self.root, f"{commit}^", commit).as_dict()}
+        completed = self._git('revert', '--no-edit', commit)
+        if completed.returncode == 0:
+            report['reverted'] = self._git('rev-parse', 'HEAD').stdout.strip()
+            return report
+        
+        unmerged = self._git('diff', '--name-only', '--diff-filter=U').stdout.split('\n')
+        report['conflicts'] = [path for path in unmerged if path]
+        self._git('revert', '--abort')
+        if not report['conflicts']:
+            raise RevertError(f"git revert failed: {(completed.stderr or completed.stdout).strip()}")
+        return report
+
+class Quarantine:
+    """🚧 QUARANTAINE - Fichiers en échec répété (corrections annulées, outil planté), ignorés jusqu'à levée"""
+    
+    DEFAULT_PATH = '.asf/quarantine.json'
+    VERSION = 1
+    # Exécutions consécutives en échec avant mise en quarantaine
+    THRESHOLD = 3
+    # Corrections annulées par la validation
+    REVERTED_CODES = (TestsFailedError.code, CompileFailedError.code)
+    
+    def __init__(self, files: Optional[Dict[str, Dict[str, Any]]] = None):
+        self.files = files or {}
+    
+    @classmethod
+    def load(cls, path: Path) -> 'Quarantine':
+        if not Path(path).is_file():
+            return cls()
+        try:
+            with open(path, 'r', encoding='utf-8') as f:
+                return cls(dict(json.load(f).get('files', {})))
+        except (ValueError, IOError, AttributeError, TypeError) as e:
+            logger.error("invalid quarantine list: %s", e, extra={'file_path': str(path)})
+            return cls()
+    
+    def dump(self) -> str:
+        return json.dumps({'version': self.VERSION, 'files': self.files}, indent=2, sort_keys=True) + '\n'
+    
+    def save(self, path: Path) -> bool:
+        """Écriture seulement si le contenu change (aucun fichier créé pour une liste vide) - retourne True si écrit"""
+        path = Path(path)
+        text = self.dump()
+        if path.is_file():
+            try:
+                if path.read_text(encoding='utf-8') == text:
+                    return False
+            except (OSError, UnicodeDecodeError):
+                pass
+        elif not self.files:
+            return False
+        path.parent.mkdir(parents=True, exist_ok=True)
+        with open(path, 'w', encoding='utf-8') as f:
+            f.write(text)
+        return True
+    
+    def quarantined(self) -> List[str]:
+        return sorted(path for path, entry in self.files.items() if entry.get('quarantined'))
+    
+    def contains(self, file_path: str, root: Path) -> bool:
+        return bool(self.files.get(Baseline._relative(file_path, root), {}).get('quarantined'))
+    
+    @classmethod
+    def failure(cls, result: FixResult, crashes: Set[str]) -> Optional[str]:
+        """Motif d'échec d'un fichier pour cette exécution (None si traité normalement)"""
+        if result.error_code in cls.REVERTED_CODES:
+            return 'reverted'
+        if result.file_path in crashes or result.error_code == FixerError.code:
+            return 'crashed'
+        return None
+    
+    def record(self, results: List[FixResult], root: Path, crashes: Set[str]) -> List[str]:
+        """Mise à jour des compteurs d'échecs - retourne les fichiers nouvellement mis en quarantaine"""
+        added = []
+        for result in results:
+            path = Baseline._relative(result.file_path, root)
+            reason = self.failure(result, crashes)
+            if reason is None:
+                # Un succès remet le compteur à zéro
+                self.files.pop(path, None)
+                continue
+            entry = self.files.setdefault(path, {'failures': 0})
+            entry.update(failures=entry['failures'] + 1, reason=reason,
+                         last_failure=datetime.now().isoformat(timespec='seconds'))
+            if entry['failures'] >= self.THRESHOLD and not entry.get('quarantined'):
+                entry['quarantined'] = True
+                added.append(path)
+        return added
+    
+    def clear(self, paths: Optional[List[str]] = None) -> int:
+        """Levée de la quarantaine (tous les fichiers si `paths` est vide) - retourne le nombre de fichiers libérés"""
+        targets = [Path(p).as_posix() for p in paths] if paths else list(self.files)
+        released = len([p for p in targets if self.files.get(p, {}).get('quarantined')])
+        for path in targets:
+            self.files.pop(path, None)
+        return released
+
+class HealthScore:
+    """💯 SCORE DE SANTÉ - Note d'hygiène 0-100 d'un repository
+    
+    70 points pour la part de fichiers sans correction, 20 pour la densité de
+    corrections pondérée par sévérité, 10 pour la présence d'une configuration
+    de formatage.
+    """
+    
+    SEVERITY_WEIGHTS = {'cosmetic': 1, 'aggressive': 3}
+    # Densité pondérée (corrections par fichier) à partir de laquelle les 20 points sont perdus
+    MAX_DENSITY = 2.0
+    FORMATTER_CONFIGS = ('.editorconfig', '.prettierrc', '.prettierrc.json', '.prettierrc.yml', '.prettierrc.yaml',
+                         '.prettierrc.js', 'prettier.config.js', '.clang-format', 'rustfmt.toml', '.rustfmt.toml',
+                         '.black', 'setup.cfg', '.flake8', 'ruff.toml', '.ruff.toml') + ESLintRunner.CONFIG_FILES
+    PYPROJECT_SECTIONS = ('[tool.black]', '[tool.ruff', '[tool.isort]', '[tool.autopep8]')
+    
+    @classmethod
+    def formatter_configs(cls, root: Path) -> List[str]:
+        found = [name for name in cls.FORMATTER_CONFIGS if (Path(root) / name).is_file()]
+        pyproject = Path(root) / 'pyproject.toml'
+        if pyproject.is_file():
+            try:
+                text = pyproject.read_text(encoding='utf-8', errors='replace')
+            except OSError:
+                text = ''
+            if any(section in text for section in cls.PYPROJECT_SECTIONS):
+                found.append('pyproject.toml')
+        return found
+    
+    @classmethod
+    def compute(cls, results: List[FixResult], root: Optional[Path]) -> Dict[str, Any]:
+        # Fichiers non traités (langage exclu, outil absent...) : hors du calcul
+        analyzed = [r for r in results if r.error_code is None]
+        needing_fixes = [r for r in analyzed if r.fixes_applied]
+        weighted = sum(cls.SEVERITY_WEIGHTS[fix_severity(fix)] for r in analyzed for fix in r.fixes_applied)
+        # Sans arbre local (mode GitHub, worktree supprimé) : configurations de formatage inconnues
+        configs = cls.formatter_configs(root) if root is not None else []
+        
+        clean_ratio = 1 - len(needing_fixes) / len(analyzed) if analyzed else 1.0
+        density = weighted / len(analyzed) if analyzed else 0.0
+        score = (70 * clean_ratio
+                 + 20 * max(0.0, 1 - density / cls.MAX_DENSITY)
+                 + (10 if configs else 0))
+        return {
+            'score': int(round(score)),
+            'files': len(analyzed),
+            'files_needing_fixes': len(needing_fixes),
+            'weighted_fixes': weighted,
+            'formatter_configs': configs
+        }
+    
+    @staticmethod
+    def color(score: int) -> str:
+        if score >= 90:
+            return '#4c1'
+        if score >= 75:
+            return '#97ca00'
+        if score >= 50:
+            return '#dfb317'
+        return '#e05d44'
+
+def render_badge(label: str, message: str, color: str) -> str:
+    """Badge SVG au format shields.io (largeur estimée à partir du texte)"""
+    label, message = html.escape(label), html.escape(message)
+    label_width = 10 + 7 * len(label)
+    message_width = 10 + 7 * len(message)
+    width = label_width + message_width
+    return (
+        f'<svg xmlns="http://www.w3.org/2000/svg" width="{width}" height="20" role="img" '
+        f'aria-label="{label}: {message}">'
+        f'<title>{label}: {message}</title>'
+        f'<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/>'
+        f'<stop offset="1" stop-opacity=".1"/></linearGradient>'
+        f'<clipPath id="r"><rect width="{width}" height="20" rx="3" fill="#fff"/></clipPath>'
+        f'<g clip-path="url(#r)"><rect width="{label_width}" height="20" fill="#555"/>'
+        f'<rect x="{label_width}" width="{message_width}" height="20" fill="{color}"/>'
+        f'<rect width="{width}" height="20" fill="url(#s)"/></g>'
+        f'<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">'
+        f'<text x="{label_width / 2}" y="14">{label}</text>'
+        f'<text x="{label_width + message_width / 2}" y="14">{message}</text></g></svg>'
+    )
+
+GITHUB_REMOTE = re.compile(r'github\.com[:/]([\w.-]+)/([\w.-]+?)(?:\.git)?/?$')
+
+def github_slug(repo_path: Path) -> Optional[str]:
+    """owner/repo du remote `origin` s'il pointe vers GitHub"""
+    try:
+        completed = subprocess.run(['git', '-C', str(repo_path), 'remote', 'get-url', 'origin'],
+                                   capture_output=True, text=True, timeout=10)
+    except (OSError, subprocess.TimeoutExpired):
+        return None
+    match = GITHUB_REMOTE.search(completed.stdout.strip()) if completed.returncode == 0 else None
+    return f"{match.group(1)}/{match.group(2)}" if match else None
+
+class CodeOwners:
+    """👥 CODEOWNERS - Attribution des fichiers aux équipes (dernière règle gagnante)"""
+    
+    LOCATIONS = ['.github/CODEOWNERS', 'CODEOWNERS', 'docs/CODEOWNERS']
+    UNOWNED = '(unowned)'
+    
+    def __init__(self, root: Path, rules: List[Tuple[re.Pattern, List[str]]]):
+        self.root = Path(root)
+        self.rules = rules
+    
+    @classmethod
+    def load(cls, root: Path) -> Optional['CodeOwners']:
+        for location in cls.LOCATIONS:
+            path = Path(root) / location
+            if path.is_file():
+                with open(path, 'r', encoding='utf-8', errors='replace') as f:
+                    return cls.parse(root, f.read())
+        return None
+    
+    @classmethod
+    def parse(cls, root: Path, text: str) -> 'CodeOwners':
+        rules = []
+        for line in text.splitlines():
+            line = line.split('#', 1)[0].strip()
+            if not line:
+                continue
+            pattern, *owners = line.split()
+            rules.append((cls._compile(pattern), owners))
+        return cls(root, rules)
+    
+    @staticmethod
+    def _compile(pattern: str) -> re.Pattern:
+        """Pattern CODEOWNERS (syntaxe gitignore) vers expression régulière"""
+        anchored = pattern.startswith('/') or '/' in pattern.rstrip('/')
+        pattern = pattern.strip('/') if pattern.endswith('/') else pattern.lstrip('/')
+        directory = not pattern or pattern.endswith('/') or not re.search(r'[*?]', pattern.split('/')[-1])
+        
+        regex = ''
+        i = 0
+        while i < len(pattern):
+            if pattern.startswith('**/', i):
+                regex += '(?:.*/)?'
+                i += 3
+            elif pattern.startswith('**', i):
+                regex += '.*'
+                i += 2
+            elif pattern[i] == '*':
+                regex += '[^/]*'
+                i += 1
+            elif pattern[i] == '?':
+                regex += '[^/]'
+                i += 1
+            else:
+                regex += re.escape(pattern[i])
+                i += 1
+        
+        prefix = '' if anchored else '(?:.*/)?'
+        # Un nom sans joker couvre aussi le contenu d'un répertoire du même nom
+        suffix = '(?:/.*)?' if directory else ''
+        return re.compile(f'^{prefix}{regex}{suffix}$')
+    
+    def owners_for(self, file_path: str) -> List[str]:
+        try:
+            relative = Path(file_path).resolve().relative_to(self.root.resolve()).as_posix()
+        except ValueError:
+            relative = Path(file_path).as_posix()
+        owners: List[str] = []
+        for regex, rule_owners in self.rules:
+            if regex.match(relative):
+                owners = rule_owners
+        return owners
+    
+    def group(self, results: List[FixResult]) -> Dict[str, List[FixResult]]:
+        """Résultats regroupés par propriétaire (équipes jointes par un espace)"""
+        groups: Dict[str, List[FixResult]] = {}
+        for result in results:
+            key = ' '.join(self.owners_for(result.file_path)) or self.UNOWNED
+            groups.setdefault(key, []).append(result)
+        return groups
+
+class DiffRegions:
+    """✂️ RÉGIONS MODIFIÉES - Corrections limitées aux lignes changées depuis une référence"""
+    
+    HUNK_HEADER = re.compile(r'^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@')
+    LINE_REFERENCE = re.compile(r'\b[Ll]ine (\d+)\b')
+    
+    def __init__(self, root: Path, regions: Dict[str, List[Tuple[int, int]]]):
+        self.root = Path(root)
+        self.regions = regions
+    
+    @classmethod
+    def from_git(cls, root: Path, base_ref: str) -> 'DiffRegions':
+        """Hunks de `git diff -U0 <base>` (arbre de travail inclus), chemins relatifs à `root`"""
+        try:
+            process = subprocess.run(
+                ['git', '-C', str(root), 'diff', '--relative', '--unified=0', '--no-color', base_ref, '--', '.'],
+                capture_output=True, text=True, timeout=60
+            )
+        except (FileNotFoundError, subprocess.TimeoutExpired) as e:
+            raise FixerError(f"Cannot diff against {base_ref}: {e}")
+        if process.returncode != 0:
+            raise FixerError(f"Cannot diff against {base_ref}: {process.stderr.strip()}")
+        
+        regions: Dict[str, List[Tuple[int, int]]] = {}
+        current = None
+        for line in process.stdout.splitlines():
+            if line.startswith('+++ '):
+                target = line[4:]
+                current = target[2:] if target.startswith('b/') else None
+                continue
+            match = cls.HUNK_HEADER.match(line)
+            if match and current is not None:
+                start, count = int(match.group(1)), int(match.group(2) or 1)
+                if count > 0:
+                    regions.setdefault(current, []).append((start, start + count - 1))
+        return cls(root, regions)
+    
+    def _relative(self, file_path: str) -> str:
+        return Path(os.path.relpath(file_path, self.root)).as_posix()
+    
+    def touched(self, file_path: str) -> bool:
+        return self._relative(file_path) in self.regions
+    
+    def contains(self, file_path: str, line_number: int) -> bool:
+        return any(start <= line_number <= end for start, end in self.regions.get(self._relative(file_path), []))
+    
+    def restrict(self, result: FixResult, original: str):
+        """Retrait des corrections et constats hors des hunks modifiés"""
+        ranges = self.regions.get(self._relative(result.file_path), [])
+        
+        def in_region(message: str) -> bool:
+            match = self.LINE_REFERENCE.search(message)
+            return match is None or self.contains(result.file_path, int(match.group(1)))
+        
+        result.original_errors = [e for e in result.original_errors if in_region(e)]
+        result.fixes_applied = [f for f in result.fixes_applied if in_region(f)]
+        if result.fixed_content is None:
+            return
+        
+        # Opérations du diff original -> corrigé conservées si elles touchent un hunk
+        original_lines = original.splitlines(keepends=True)
+        fixed_lines = result.fixed_content.splitlines(keepends=True)
+        kept = []
+        matcher = difflib.SequenceMatcher(None, original_lines, fixed_lines, autojunk=False)
+        opcodes = []
+        for tag, i1, i2, j1, j2 in matcher.get_opcodes():
+            # Remplacement ligne à ligne : chaque ligne est décidée séparément
+            if tag == 'replace' and i2 - i1 == j2 - j1:
+                opcodes.extend(('replace', i, i + 1, j1 + (i - i1), j1 + (i - i1) + 1) for i in range(i1, i2))
+            else:
+                opcodes.append((tag, i1, i2, j1, j2))
+        
+        for tag, i1, i2, j1, j2 in opcodes:
+            # Lignes 1-indexées ; une insertion est rattachée à la ligne qui la précède
+            first, last = i1 + 1, max(i2, i1 + 1)
+            if tag == 'equal' or not any(start <= last and first <= end for start, end in ranges):
+                kept.extend(original_lines[i1:i2])
+            else:
+                kept.extend(fixed_lines[j1:j2])
+        result.fixed_content = ''.join(kept)
+        
+        if result.fixed_content == original:
+            result.fixes_applied = []
+
+class BlameFilter:
+    """🕰️ CODE RÉCENT - Lignes écrites récemment (ou par certains auteurs) laissées intactes (git blame)
+    
+    Les corrections ne touchent que le code ancien : inutile de reformater ce
+    qu'un développeur vient d'écrire. Lignes non commitées et fichiers non
+    suivis comptent comme récents.
+    """
+    
+    HEADER = re.compile(r'^[0-9a-f]{40} \d+ (\d+)')
+    
+    def __init__(self, max_age_days: Optional[float] = None, authors: Optional[List[str]] = None):
+        self.max_age_days = max_age_days
+        self.authors = {author.strip().lower() for author in authors or [] if author.strip()}
+    
+    @classmethod
+    def from_config(cls, value: Any) -> Optional['BlameFilter']:
+        """`blame: {max_age_days: 14, authors: [alice@example.com, Bob]}`"""
+        if not value:
+            return None
+        if not isinstance(value, dict):
+            raise FixerError("'blame' must be a mapping with max_age_days and/or authors")
+        max_age = value.get('max_age_days')
+        return cls(float(max_age) if max_age is not None else None, value.get('authors') or [])
+    
+    def _fresh(self, author: str, mail: str, timestamp: int, now: float) -> bool:
+        if author.lower() in self.authors or mail.lower() in self.authors:
+            return True
+        return self.max_age_days is not None and now - timestamp < self.max_age_days * 86400
+    
+    def fresh_lines(self, root: Path, file_path: str) -> Optional[Set[int]]:
+        """Numéros des lignes récentes (None : fichier non suivi, entièrement récent)"""
+        completed = subprocess.run(['git', '-C', str(root), 'blame', '--line-porcelain', '--',
+                                    os.path.relpath(file_path, root)], capture_output=True, text=True, timeout=60)
+        if completed.returncode != 0:
+            return None
+        
+        fresh, now = set(), time.time()
+        line_number, author, mail, timestamp = 0, '', '', 0
+        for line in completed.stdout.split('\n'):
+            header = self.HEADER.match(line)
+            if header:
+                line_number = int(header.group(1))
+            elif line.startswith('author '):
+                author = line[7:]
+            elif line.startswith('author-mail '):
+                mail = line[12:].strip('<>')
+            elif line.startswith('author-time '):
+                timestamp = int(line[12:])
+            elif line.startswith('\t') and self._fresh(author, mail, timestamp, now):
+                fresh.add(line_number)
+        return fresh
+    
+    def regions(self, root: Path, file_paths: List[str], originals: Dict[str, str]) -> DiffRegions:
+        """Régions corrigeables : plages de lignes anciennes de chaque fichier"""
+        regions: Dict[str, List[Tuple[int, int]]] = {}
+        for file_path in file_paths:
+            fresh = self.fresh_lines(root, file_path) or set(range(1, originals[file_path].count('\n') + 2))
+            ranges: List[Tuple[int, int]] = []
+            for number in range(1, originals[file_path].count('\n') + 2):
+                if number in fresh:
+                    continue
+                if ranges and ranges[-1][1] == number - 1:
+                    ranges[-1] = (ranges[-1][0], number)
+                else:
+                    ranges.append((number, number))
+            regions[Path(os.path.relpath(file_path, root)).as_posix()] = ranges
+        return DiffRegions(root, regions)
+
+class TestVerifier:
+    """🧪 VÉRIFICATION - Suite de tests du projet avant/après corrections, annulation si régression
+    
+    Lancer les tests exécute le code du repository : `verify:` du .syntaxfixer.yml n'est lu
+    qu'avec --allow-repo-plugins, --verify reste le choix explicite de l'opérateur.
+    """
+    
+    DEFAULT_TIMEOUT = 600
+    
+    def __init__(self, command: Optional[List[str]] = None, timeout: float = DEFAULT_TIMEOUT):
+        self.command = command
+        self.timeout = timeout
+    
+    @classmethod
+    def from_config(cls, value: Any) -> Optional['TestVerifier']:
+        """`verify: true` ou `verify: {command: ..., timeout: ...}`"""
+        if not value:
+            return None
+        if value is True:
+            return cls()
+        command = value.get('command')
+        return cls(shlex.split(command) if isinstance(command, str) else command,
+                   float(value.get('timeout', cls.DEFAULT_TIMEOUT)))
+    
+    @staticmethod
+    def detect(root: Path) -> Optional[List[str]]:
+        """Commande de test du projet (go test, npm test, pytest)"""
+        root = Path(root)
+        if (root / 'go.mod').is_file():
+            return ['go', 'test', './...']
+        package = root / 'package.json'
+        if package.is_file():
+            try:
+                with open(package, 'r', encoding='utf-8') as f:
+                    if (json.load(f).get('scripts') or {}).get('test'):
+                        return ['npm', 'test', '--silent']
+            except (ValueError, IOError, AttributeError):
+                pass
+        if any((root / name).exists() for name in ('pytest.ini', 'conftest.py', 'tests', 'test')):
+            return ['python', '-m', 'pytest', '-q']
+        return None
+    
+    async def _run_tests(self, command: List[str], cwd: str) -> Tuple[bool, str]:
+        process = None
+        try:
+            process = await platform_subprocess_exec(
+                *command, cwd=cwd,
+                stdout=asyncio.subprocess.PIPE,
+                stderr=asyncio.subprocess.STDOUT
+            )
+            output, _ = await asyncio.wait_for(process.communicate(), timeout=self.timeout)
+            return process.returncode == 0, output.decode(errors='replace')
+        except FileNotFoundError:
+            raise ToolMissingError(f"Test command not found: {command[0]}")
+        except (asyncio.TimeoutError, asyncio.CancelledError) as e:
+            if process is not None and process.returncode is None:
+                process.kill()
+                await process.wait()
+            if isinstance(e, asyncio.CancelledError):
+                raise
+            return False, f"Tests timed out after {self.timeout}s"
+    
+    async def verify(self, root: Path, results: List[FixResult], workspace_dir: str) -> Dict[str, Any]:
+        """Exécution dans une copie du projet - annule et signale les corrections si les tests régressent"""
+        changed = [r for r in results if r.fixed_content is not None and r.fixes_applied and r.error_code is None]
+        command = self.command or self.detect(root)
+        if not changed:
+            return {'status': 'skipped', 'reason': 'no changes'}
+        if command is None:
+            return {'status': 'skipped', 'reason': 'no test command detected'}
+        
+        copy = os.path.join(workspace_dir, 'verify')
+        await asyncio.to_thread(shutil.copytree, root, copy, symlinks=True,
+                                ignore=shutil.ignore_patterns('.git'))
+        
+        try:
+            passed_before, _ = await self._run_tests(command, copy)
+            for result in changed:
+                target = os.path.join(copy, os.path.relpath(result.file_path, root))
+                with open(target, 'w', encoding='utf-8') as f:
+                    f.write(result.fixed_content)
+            passed_after, output = await self._run_tests(command, copy)
+        except ToolMissingError as e:
+            return {'status': 'skipped', 'reason': str(e), 'command': shlex.join(command)}
+        finally:
+            shutil.rmtree(copy, ignore_errors=True)
+        
+        report = {'command': shlex.join(command), 'passed_before': passed_before, 'passed_after': passed_after}
+        if passed_after or not passed_before:
+            # Pas de régression (une suite déjà rouge ne permet pas de conclure)
+            report['status'] = 'verified' if passed_after else 'inconclusive'
+            return report
+        
+        logger.warning("tests newly fail after fixes - rolling back", extra={'file_path': str(root)})
+        for result in changed:
+            error = TestsFailedError(f"Fixes rolled back: `{report['command']}` fails with them applied")
+            result.original_errors.append(str(error))
+            result.error_code = error.code
+            result.fixes_applied = []
+            result.fixed_content = None
+            result.success = False
+        report.update(status='rolled_back', output=output[-2000:], files=[r.file_path for r in changed])
+        return report
+
+class CompileChecker(TestVerifier):
+    """🏗️ COMPILATION - Vérification rapide des langages compilés, annulation fichier par fichier (bissection)"""
+    
+    # Langage -> (fichier marqueur du projet, commande)
+    CHECKS = {
+        'go': ('go.mod', ['go', 'build', './...']),
+        'rust': ('Cargo.toml', ['cargo', 'check', '--quiet']),
+        'typescript': ('tsconfig.json', ['npx', '--no-install', 'tsc', '--noEmit']),
+        'java': ('pom.xml', ['mvn', '-q', 'compile'])
+    }
+    
+    def commands(self, root: Path, languages: Set[str]) -> List[List[str]]:
+        return [command for language, (marker, command) in self.CHECKS.items()
+                if language in languages and (Path(root) / marker).is_file()]
+    
+    async def _check(self, commands: List[List[str]], cwd: str) -> bool:
+        for command in commands:
+            passed, output = await self._run_tests(command, cwd)
+            if not passed:
+                logger.debug("compile check failed: %s", output[-500:], extra={'tool': command[0]})
+                return False
+        return True
+    
+    async def verify(self, root: Path, results: List[FixResult], workspace_dir: str) -> Dict[str, Any]:
+        """Compilation de la copie corrigée ; en cas d'échec, bissection des fichiers fautifs"""
+        changed = [r for r in results if r.fixed_content is not None and r.fixes_applied
+                   and r.error_code is None and r.language in self.CHECKS]
+        commands = self.commands(root, {r.language for r in changed})
+        if not changed or not commands:
+            return {'status': 'skipped', 'reason': 'no compiled-language changes'}
+        
+        copy = os.path.join(workspace_dir, 'compile')
+        await asyncio.to_thread(shutil.copytree, root, copy, symlinks=True,
+                                ignore=shutil.ignore_patterns('.git'))
+        originals = {}
+        for result in changed:
+            with open(result.file_path, 'r', encoding='utf-8') as f:
+                originals[result.file_path] = f.read()
+        
+        def apply(fixed: List[FixResult]):
+            """Copie avec les corrections de `fixed` seulement"""
+            selected = {r.file_path for r in fixed}
+            for result in changed:
+                target = os.path.join(copy, os.path.relpath(result.file_path, root))
+                with open(target, 'w', encoding='utf-8') as f:
+                    f.write(result.fixed_content if result.file_path in selected else originals[result.file_path])
+        
+        async def bisect(candidates: List[FixResult], accepted: List[FixResult]) -> List[FixResult]:
+            apply(accepted + candidates)
+            if await self._check(commands, copy):
+                return []
+            if len(candidates) == 1:
+                return candidates
+            middle = len(candidates) // 2
+            bad_left = await bisect(candidates[:middle], accepted)
+            good_left = [r for r in candidates[:middle] if r not in bad_left]
+            return bad_left + await bisect(candidates[middle:], accepted + good_left)
+        
+        try:
+            apply([])
+            if not await self._check(commands, copy):
+                return {'status': 'inconclusive', 'reason': 'project does not compile before fixing',
+                        'command': '; '.join(shlex.join(c) for c in commands)}
+            bad = await bisect(changed, [])
+        except ToolMissingError as e:
+            return {'status': 'skipped', 'reason': str(e)}
+        finally:
+            shutil.rmtree(copy, ignore_errors=True)
+        
+        for result in bad:
+            logger.warning("fix breaks compilation - rolled back", extra={'file_path': result.file_path})
+            error = CompileFailedError("Fixes rolled back: they break compilation")
+            result.original_errors.append(str(error))
+            result.error_code = error.code
+            result.fixes_applied = []
+            result.fixed_content = None
+            result.success = False
+        return {'status': 'rolled_back' if bad else 'verified',
+                'command': '; '.join(shlex.join(c) for c in commands),
+                'files': [r.file_path for r in bad]}
+
+class ProjectFormatter(TestVerifier):
+    """🧰 FORMATEUR DU PROJET - Commande canonique (script npm, cible make, recette just, mix format)
+    
+    Exécutée dans une copie du repository ; les contenus formatés remplacent
+    alors les correcteurs intégrés, utilisés seulement si la commande échoue.
+    Même règle que `verify:` : `project_format:` du repository exige --allow-repo-plugins.
+    """
+    
+    DEFAULT_TIMEOUT = 300
+    TARGETS = ('format', 'fmt')
+    
+    @classmethod
+    def detect(cls, root: Path) -> Optional[List[str]]:
+        root = Path(root)
+        package = root / 'package.json'
+        if package.is_file():
+            try:
+                with open(package, 'r', encoding='utf-8') as f:
+                    scripts = json.load(f).get('scripts') or {}
+            except (ValueError, IOError, AttributeError):
+                scripts = {}
+            for target in cls.TARGETS:
+                if target in scripts:
+                    manager = ('pnpm' if (root / 'pnpm-lock.yaml').is_file() else
+                               'yarn' if (root / 'yarn.lock').is_file() else 'npm')
+                    return [manager, 'run', target]
+        
+        # Projet mix : `mix format` suit les entrées de .formatter.exs
+        if (root / 'mix.exs').is_file() and (root / '.formatter.exs').is_file():
+            return ['mix', 'format']
+        
+        for name, tool in (('Makefile', 'make'), ('justfile', 'just'), ('Justfile', 'just')):
+            path = root / name
+            if not path.is_file():
+                continue
+            with open(path, 'r', encoding='utf-8', errors='replace') as f:
+                text = f.read()
+            for target in cls.TARGETS:
+                if re.search(rf'^{target}\b[^:\n=]*:(?!=)', text, re.MULTILINE):
+                    return [tool, target]
+        return None
+    
+    async def format(self, root: Path, files: List[Path], workspace_dir: str) -> Dict[str, str]:
+        """Contenus formatés par fichier ({} si aucune commande ou en cas d'échec)"""
+        command = self.command or self.detect(root)
+        if command is None:
+            return {}
+        
+        copy = os.path.join(workspace_dir, 'format')
+        await asyncio.to_thread(shutil.copytree, root, copy, symlinks=True,
+                                ignore=shutil.ignore_patterns('.git'))
+        try:
+            passed, output = await self._run_tests(command, copy)
+            if not passed:
+                logger.warning("project format command failed, using built-in fixers: %s", output[-500:],
+                               extra={'tool': shlex.join(command)})
+                return {}
+            
+            formatted = {}
+            for file_path in files:
+                try:
+                    with open(os.path.join(copy, os.path.relpath(file_path, root)), 'r', encoding='utf-8') as f:
+                        formatted[str(file_path)] = f.read()
+                except (UnicodeDecodeError, IOError):
+                    continue
+            logger.info("project format command applied", extra={'tool': shlex.join(command)})
+            return formatted
+        except ToolMissingError as e:
+            logger.warning("%s - using built-in fixers", e)
+            return {}
+        finally:
+            shutil.rmtree(copy, ignore_errors=True)
+
+class FixerRegistry:
+    """📚 REGISTRE DES CORRECTEURS - Dispatch dynamique par langage
+    
+    Les langages sans correcteur enregistré passent par le pipeline de
+    patterns internes + outils shell.
+    """
+    
+    def __init__(self):
+        self._fixers: Dict[str, Fixer] = {}
+    
+    def register(self, language: str, fixer: Fixer):
+        """Enregistrement (remplace un correcteur existant pour ce langage)"""
+        self._fixers[language] = fixer
+    
+    def unregister(self, language: str):
+        self._fixers.pop(language, None)
+    
+    def copy(self) -> 'FixerRegistry':
+        registry = FixerRegistry()
+        registry._fixers = dict(self._fixers)
+        return registry
+    
+    def for_language(self, language: str) -> Optional[Fixer]:
+        return self._fixers.get(language)
+    
+    def languages(self) -> List[str]:
+        return sorted(self._fixers)
+
+class ProviderHttpError(FixerError):
+    """Échec définitif d'un appel HTTP vers un fournisseur (après reprises)"""
+    code = 'provider_http'
+    
+    def __init__(self, message: str, status: Optional[int] = None):
+        super().__init__(message)
+        self.status = status
+
+class ProviderHttpClient:
+    """🌐 CLIENT HTTP FOURNISSEUR - Quotas X-RateLimit, reprises avec backoff aléatoire"""
+    
+    MAX_RETRIES = 5
+    BACKOFF_BASE = 1.0
+    BACKOFF_MAX = 60.0
+    # Au-delà, attendre la remise à zéro du quota n'a pas de sens pour une exécution
+    MAX_RATE_LIMIT_WAIT = 900
+    RETRY_STATUSES = {429, 500, 502, 503, 504}
+    
+    def __init__(self, timeout: float = 30, max_retries: int = MAX_RETRIES,
+                 proxy: Optional[str] = None, ca_bundle: Optional[str] = None):
+        self.timeout = timeout
+        self.max_retries = max_retries
+        self.rate_limit_remaining: Optional[int] = None
+        self.rate_limit_total: Optional[int] = None
+        self.opener = self._build_opener(proxy, ca_bundle)
+    
+    @staticmethod
+    def _build_opener(proxy: Optional[str], ca_bundle: Optional[str]) -> urllib.request.OpenerDirector:
+        """Proxy explicite (sinon variables HTTP(S)_PROXY) et bundle CA d'entreprise"""
+        ca_bundle = ca_bundle or os.environ.get('SSL_CERT_FILE') or os.environ.get('REQUESTS_CA_BUNDLE')
+        context = ssl.create_default_context(cafile=ca_bundle) if ca_bundle else None
+        handlers: List[urllib.request.BaseHandler] = [
+            urllib.request.ProxyHandler({'http': proxy, 'https': proxy} if proxy else None),
+            urllib.request.HTTPSHandler(context=context)
+        ]
+        return urllib.request.build_opener(*handlers)
+    
+    def request(self, method: str, url: str, payload: Optional[Dict[str, Any]] = None,
+                headers: Optional[Dict[str, str]] = None) -> Any:
+        """Appel synchrone avec reprises (à exécuter hors de la boucle asyncio)"""
+        data = json.dumps(payload).encode('utf-8') if payload is not None else None
+        for attempt in range(self.max_retries + 1):
+            request = urllib.request.Request(url, data=data, method=method, headers=dict(headers or {}))
+            try:
+                with self.opener.open(request, timeout=self.timeout) as response:
+                    self._record_rate_limit(response.headers)
+                    return json.loads(response.read().decode('utf-8') or '{}')
+            except urllib.error.HTTPError as e:
+                self._record_rate_limit(e.headers)
+                message = e.read().decode('utf-8', errors='replace')
+                delay = self._retry_delay(e.code, e.headers, message, attempt)
+                if delay is None or attempt == self.max_retries:
+                    raise ProviderHttpError(f"HTTP {e.code} {message[:200]}", e.code)
+            except (urllib.error.URLError, OSError) as e:
+                if attempt == self.max_retries:
+                    raise ProviderHttpError(str(e))
+                delay = self._backoff(attempt)
+            
+            logger.warning("retrying %s %s in %.1fs (attempt %d/%d)", method, url, delay,
+                           attempt + 1, self.max_retries)
+            time.sleep(delay)
+    
+    def _retry_delay(self, status: int, headers: Any, message: str, attempt: int) -> Optional[float]:
+        """Délai avant nouvelle tentative, None si l'erreur est définitive"""
+        rate_limited = headers.get('X-RateLimit-Remaining') == '0' or 'rate limit' in message.lower()
+        if status == 403 and not rate_limited:
+            return None  # Permission refusée : inutile de réessayer
+        if status != 403 and status not in self.RETRY_STATUSES:
+            return None
+        
+        retry_after = headers.get('Retry-After')
+        if retry_after and retry_after.isdigit():
+            delay = float(retry_after)
+        elif rate_limited and headers.get('X-RateLimit-Reset', '').isdigit():
+            delay = max(0.0, int(headers['X-RateLimit-Reset']) - time.time()) + 1
+        else:
+            return self._backoff(attempt)
+        
+        if delay > self.MAX_RATE_LIMIT_WAIT:
+            logger.error("rate limit resets in %.0fs - giving up", delay)
+            return None
+        return delay
+    
+    def _backoff(self, attempt: int) -> float:
+        # Backoff exponentiel à gigue complète
+        return random.uniform(0, min(self.BACKOFF_MAX, self.BACKOFF_BASE * 2 ** attempt))
+    
+    def _record_rate_limit(self, headers: Any):
+        if headers is None or not str(headers.get('X-RateLimit-Remaining', '')).isdigit():
+            return
+        self.rate_limit_remaining = int(headers['X-RateLimit-Remaining'])
+        limit = headers.get('X-RateLimit-Limit', '')
+        self.rate_limit_total = int(limit) if limit.isdigit() else self.rate_limit_total
+        if self.rate_limit_total and self.rate_limit_remaining < self.rate_limit_total // 10:
+            logger.warning("API quota low: %d/%d requests remaining",
+                           self.rate_limit_remaining, self.rate_limit_total)
+        else:
+            logger.debug("API quota: %s/%s requests remaining", self.rate_limit_remaining, self.rate_limit_total)
+
+class SecretScanner:
+    """🔐 GARDE SECRETS - Aucun commit automatique d'un fichier contenant un secret évident"""
+    
+    PATTERNS = {
+        'aws_access_key': re.compile(r'\b(?:AKIA|ASIA)[0-9A-Z]{16}\b'),
+        'aws_secret_key': re.compile(r'(?i)aws_secret_access_key\s*[=:]\s*["\']?[A-Za-z0-9/+=]{40}\b'),
+        'private_key': re.compile(r'-----BEGIN (?:RSA |EC |DSA |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY( BLOCK)?-----'),
+        'github_token': re.compile(r'\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{60,})\b'),
+        'slack_token': re.compile(r'\bxox[abprs]-[A-Za-z0-9-]{10,}\b'),
+        'google_api_key': re.compile(r'\bAIza[0-9A-Za-z_\-]{35}\b'),
+        'stripe_key': re.compile(r'\b[sr]k_live_[0-9a-zA-Z]{24,}\b')
+    }
+    
+    def scan(self, content: str) -> List[Tuple[str, int]]:
+        """Secrets trouvés : (type, ligne)"""
+        findings = []
+        for line_number, line in enumerate(content.split('\n'), 1):
+            for kind, pattern in self.PATTERNS.items():
+                if pattern.search(line):
+                    findings.append((kind, line_number))
+        return findings
+    
+    def guard(self, results: List[FixResult], changes: Dict[str, str]) -> Dict[str, str]:
+        """Retrait des fichiers contenant un secret - entrée de rapport sur le résultat"""
+        by_path = {r.file_path: r for r in results}
+        allowed = {}
+        for file_path, content in changes.items():
+            findings = self.scan(content)
+            if not findings:
+                allowed[file_path] = content
+                continue
+            
+            kinds = ', '.join(sorted({kind for kind, _ in findings}))
+            logger.warning("possible secret (%s) - file not committed", kinds, extra={'file_path': file_path})
+            result = by_path.get(file_path)
+            if result is not None:
+                error = SecretDetectedError(
+                    f"Not committed: possible secret ({kinds}) on line {findings[0][1]}")
+                result.original_errors.append(str(error))
+                result.error_code = error.code
+        return allowed
+
+class GitHubApiError(FixerError):
+    """Échec d'un appel à l'API GitHub"""
+    code = 'github_api'
+
+class GitHubApiClient:
+    """🐙 GITHUB API - Lecture (Trees/Blobs) et commit (Git Data) sans clone local"""
+    
+    API_URL = 'https://api.github.com'
+    MAX_FILES = 500
+    FORK_READY_ATTEMPTS = 10
+    STATUS_CONTEXT = 'auto-syntax-fixer'
+    FORK_READY_DELAY = 3.0
+    
+    def __init__(self, repo: str, token: Optional[str] = None, timeout: float = 30,
+                 http: Optional[ProviderHttpClient] = None, api_url: Optional[str] = None):
+        self.repo = repo
+        self.token = token or os.environ.get('GITHUB_TOKEN')
+        self.http = http or ProviderHttpClient(timeout)
+        # GitHub Enterprise Server : https://<hôte>/api/v3
+        self.api_url = (api_url or os.environ.get('GITHUB_API_URL') or self.API_URL).rstrip('/')
+    
+    def _request(self, method: str, path: str, payload: Optional[Dict[str, Any]] = None) -> Any:
+        """Appel synchrone de l'API (exécuté hors de la boucle asyncio)"""
+        headers = {'Accept': 'application/vnd.github+json', 'Content-Type': 'application/json'}
+        if self.token:
+            headers['Authorization'] = f"Bearer {self.token}"
+        try:
+            url = f"{self.api_url}/repos/{self.repo}/{path}" if path else f"{self.api_url}/repos/{self.repo}"
+            return self.http.request(method, url, payload, headers)
+        except ProviderHttpError as e:
+            raise GitHubApiError(f"GitHub API {method} {path}: {e}")
+    
+    async def call(self, method: str, path: str, payload: Optional[Dict[str, Any]] = None) -> Any:
+        return await asyncio.to_thread(self._request, method, path, payload)
+    
+    async def head_commit(self, ref: str) -> str:
+        data = await self.call('GET', f"git/ref/heads/{ref}")
+        return data['object']['sha']
+    
+    async def list_tree(self, commit_sha: str) -> List[Dict[str, Any]]:
+        """Blobs de l'arbre complet du commit"""
+        with tracing.span('github.fetch_tree', repository=self.repo, commit=commit_sha) as span:
+            commit = await self.call('GET', f"git/commits/{commit_sha}")
+            tree = await self.call('GET', f"git/trees/{commit['tree']['sha']}?recursive=1")
+            if tree.get('truncated'):
+                raise GitHubApiError("Repository tree too large for API mode - use a local clone")
+            blobs = [entry for entry in tree.get('tree', []) if entry.get('type') == 'blob']
+            tracing.annotate(span, files=len(blobs))
+            return blobs
+    
+    async def read_blob(self, sha: str) -> bytes:
+        data = await self.call('GET', f"git/blobs/{sha}")
+        return base64.b64decode(data['content'])
+    
+    async def read_file(self, path: str, ref: str) -> Optional[str]:
+        """Contenu d'un fichier au commit donné (None s'il n'existe pas)"""
+        try:
+            data = await self.call('GET', f"contents/{urllib.parse.quote(path)}?ref={ref}")
+        except GitHubApiError as e:
+            if self._not_found(e):
+                return None
+            raise
+        return base64.b64decode(data['content']).decode('utf-8')
+    
+    @staticmethod
+    def _not_found(error: GitHubApiError) -> bool:
+        return getattr(error.__context__, 'status', None) == 404
+    
+    async def can_push(self) -> bool:
+        """Droit de push du jeton sur le repository"""
+        data = await self.call('GET', '')
+        return bool((data.get('permissions') or {}).get('push', True))
+    
+    async def fork(self, branch: str) -> 'GitHubApiClient':
+        """Fork du repository pour le jeton (existant réutilisé), synchronisé sur `branch`"""
+        data = await self.call('POST', 'forks', {})
+        fork = GitHubApiClient(data['full_name'], self.token, http=self.http, api_url=self.api_url)
+        # Création asynchrone côté GitHub : le fork n'est pas immédiatement lisible
+        for attempt in range(self.FORK_READY_ATTEMPTS):
+            try:
+                await fork.call('GET', f"git/ref/heads/{branch}")
+                break
+            except GitHubApiError:
+                if attempt == self.FORK_READY_ATTEMPTS - 1:
+                    raise GitHubApiError(f"Fork {fork.repo} is not ready yet - retry later")
+                await asyncio.sleep(self.FORK_READY_DELAY)
+        try:
+            await fork.call('POST', 'merge-upstream', {'branch': branch})
+        except GitHubApiError as e:
+            logger.warning("cannot sync fork with upstream: %s", e, extra={'file_path': fork.repo})
+        return fork
+    
+    async def branch_protected(self, branch: str) -> bool:
+        """Règles de protection sur la branche (False si elle n'existe pas encore)"""
+        try:
+            data = await self.call('GET', f"branches/{urllib.parse.quote(branch, safe='')}")
+        except GitHubApiError as e:
+            if self._not_found(e):
+                return False
+            raise
+        return bool(data.get('protected'))
+    
+    async def commit_files(self, base_sha: str, files: Dict[str, str], message: str, branch: str,
+                           ignore_revs: bool = False) -> str:
+        """Création d'un commit (blobs, arbre, commit) puis de la branche cible - SHA du commit de correction
+        
+        Avec `ignore_revs`, un second commit ajoute ce SHA à .git-blame-ignore-revs
+        (le commit ne pouvant contenir son propre SHA) ; la branche pointe sur ce dernier.
+        """
+        with tracing.span('github.push', repository=self.repo, branch=branch, files=len(files),
+                          bytes=sum(len(content.encode('utf-8')) for content in files.values())):
+            commit_sha = await self._create_commit(base_sha, files, message)
+            head = commit_sha
+            if ignore_revs:
+                existing = await self.read_file(BLAME_IGNORE_REVS, base_sha) or ''
+                head = await self._create_commit(commit_sha, {
+                    BLAME_IGNORE_REVS: blame_ignore_revs_entry(existing, commit_sha, message)
+                }, f"Ignore {commit_sha[:12]} in git blame")
+            await self._update_branch(branch, head)
+            return commit_sha
+    
+    async def _create_commit(self, base_sha: str, files: Dict[str, str], message: str) -> str:
+        base = await self.call('GET', f"git/commits/{base_sha}")
+        tree_entries = []
+        for path, content in files.items():
+            blob = await self.call('POST', 'git/blobs', {'content': content, 'encoding': 'utf-8'})
+            tree_entries.append({'path': path, 'mode': '100644', 'type': 'blob', 'sha': blob['sha']})
+        
+        tree = await self.call('POST', 'git/trees', {'base_tree': base['tree']['sha'], 'tree': tree_entries})
+        commit = await self.call('POST', 'git/commits', {
+            'message': message, 'tree': tree['sha'], 'parents': [base_sha]
+        })
+        return commit['sha']
+    
+    async def _update_branch(self, branch: str, sha: str):
+        try:
+            await self.call('POST', 'git/refs', {'ref': f"refs/heads/{branch}", 'sha': sha})
+        except GitHubApiError:
+            # Branche existante : avance forcée sur le nouveau commit
+            await self.call('PATCH', f"git/refs/heads/{branch}", {'sha': sha, 'force': True})
+    
+    async def pull_request(self, number: int) -> Dict[str, Any]:
+        return await self.call('GET', f"pulls/{number}")
+    
+    async def post_status(self, sha: str, state: str, description: str) -> Dict[str, Any]:
+        """Statut de commit (pending, success, failure, error) sur lequel CI et files de fusion peuvent attendre"""
+        return await self.call('POST', f"statuses/{sha}", {'state': state, 'context': self.STATUS_CONTEXT,
+                                                           'description': description[:140]})
+    
+    async def commit_message(self, commit_sha: str) -> str:
+        return (await self.call('GET', f"git/commits/{commit_sha}"))['message']
+    
+    async def changed_files(self, base_sha: str, head_sha: str) -> Dict[str, Optional[str]]:
+        """Fichiers modifiés entre deux commits : chemin → SHA du blob (None si supprimé)"""
+        data = await self.call('GET', f"compare/{base_sha}...{head_sha}")
+        return {entry['filename']: None if entry.get('status') == 'removed' else entry.get('sha')
+                for entry in data.get('files', [])}
+    
+    async def diff_stats(self, base_sha: str, head_sha: str) -> 'DiffStats':
+        """Équivalent de `git diff --numstat` via l'API de comparaison"""
+        data = await self.call('GET', f"compare/{base_sha}...{head_sha}")
+        return DiffStats({entry['filename']: (entry.get('additions', 0), entry.get('deletions', 0))
+                          for entry in data.get('files', [])})
+    
+    async def create_pull_request(self, head: str, base: str, title: str, body: str) -> Dict[str, Any]:
+        return await self.call('POST', 'pulls', {'head': head, 'base': base, 'title': title, 'body': body})
+    
+    async def merge_pull_request(self, number: int, method: str = 'squash') -> Dict[str, Any]:
+        return await self.call('PUT', f"pulls/{number}/merge", {'merge_method': method})
+    
+    async def pull_request_regions(self, number: int) -> DiffRegions:
+        """Lignes modifiées par la PR (côté head), seules commentables dans une revue"""
+        regions: Dict[str, List[Tuple[int, int]]] = {}
+        page = 1
+        while True:
+            files = await self.call('GET', f"pulls/{number}/files?per_page=100&page={page}")
+            for entry in files:
+                for line in (entry.get('patch') or '').splitlines():
+                    match = DiffRegions.HUNK_HEADER.match(line)
+                    if match:
+                        start, count = int(match.group(1)), int(match.group(2) or 1)
+                        if count > 0:
+                            regions.setdefault(entry['filename'], []).append((start, start + count - 1))
+            if len(files) < 100:
+                return DiffRegions(Path('.'), regions)
+            page += 1
+    
+    async def post_review(self, number: int, commit_sha: str, body: str,
+                          comments: List[Dict[str, Any]]) -> Dict[str, Any]:
+        return await self.call('POST', f"pulls/{number}/reviews", {
+            'commit_id': commit_sha, 'event': 'COMMENT', 'body': body, 'comments': comments
+        })
+
+class WorkerCoordinator:
+    """🛰️ COORDINATEUR - Répartition d'un gros repository entre serveurs workers
+    
+    Les fichiers du commit sont découpés en lots équilibrés par taille ; chaque worker
+    lit son lot au commit figé et le corrige dans son propre espace de travail.
+    Les contenus corrigés sont fusionnés puis commités en une fois par le coordinateur.
+    Un lot en échec est retenté sur le worker suivant, puis traité localement.
+    """
+    
+    SHARD_PATH = '/api/v1/shards'
+    TIMEOUT = 1800
+    
+    def __init__(self, workers: List[str], token: Optional[str] = None):
+        self.workers = [worker.rstrip('/') for worker in workers]
+        self.token = token if token is not None else os.environ.get('ASF_WORKER_TOKEN')
+        self.http = ProviderHttpClient(self.TIMEOUT, max_retries=1)
+    
+    @staticmethod
+    def shard(entries: List[Dict[str, Any]], count: int) -> List[List[Dict[str, Any]]]:
+        """Lots équilibrés : plus gros fichiers d'abord, chacun dans le lot le moins chargé"""
+        shards: List[List[Dict[str, Any]]] = [[] for _ in range(max(1, count))]
+        loads = [0] * len(shards)
+        for entry in sorted(entries, key=lambda e: e.get('size', 0), reverse=True):
+            target = loads.index(min(loads))
+            shards[target].append(entry)
+            loads[target] += entry.get('size', 0) or 1
+        return [shard for shard in shards if shard]
+    
+    async def run(self, fixer: 'AutoSyntaxFixerILN3', client: 'GitHubApiClient', repo: str, commit: str,
+                  subdir: str, selected: List[Dict[str, Any]],
+                  token: Optional[str]) -> Tuple[List[FixResult], Dict[str, str]]:
+        count = max(len(self.workers), -(-len(selected) // GitHubApiClient.MAX_FILES))
+        shards = self.shard(selected, count)
+        logger.info("dispatching %d files in %d shards to %d workers", len(selected), len(shards),
+                    len(self.workers), extra={'file_path': f"{repo}@{commit[:12]}"})
+        outcomes = await asyncio.gather(*(
+            self._run_shard(fixer, client, repo, commit, subdir, shard, index, token)
+            for index, shard in enumerate(shards)))
+        results: List[FixResult] = []
+        changes: Dict[str, str] = {}
+        for shard_results, shard_changes in outcomes:
+            results.extend(shard_results)
+            changes.update(shard_changes)
+        return results, changes
+    
+    async def _run_shard(self, fixer: 'AutoSyntaxFixerILN3', client: 'GitHubApiClient', repo: str, commit: str,
+                         subdir: str, shard: List[Dict[str, Any]], index: int,
+                         token: Optional[str]) -> Tuple[List[FixResult], Dict[str, str]]:
+        payload = {'repo': repo, 'commit': commit, 'path': subdir, 'paths': [entry['path'] for entry in shard],
+                   'settings': server_config_var.get(), 'strategy': strategy_var.get(), 'token': token}
+        headers = {'Content-Type': 'application/json'}
+        if self.token:
+            headers['Authorization'] = f"Bearer {self.token}"
+        for attempt in range(len(self.workers)):
+            worker = self.workers[(index + attempt) % len(self.workers)]
+            try:
+                data = await asyncio.to_thread(self.http.request, 'POST', worker + self.SHARD_PATH, payload, headers)
+                changes = data['changes']
+                return [result_from_payload(result, changes) for result in data['results']], changes
+            except (ProviderHttpError, KeyError, TypeError) as e:
+                logger.warning("shard %d failed on %s: %s", index, worker, e, extra={'file_path': repo})
+        logger.warning("shard %d: no worker available, fixing locally", index, extra={'file_path': repo})
+        return await fixer._fix_github_entries(client, shard)
+
+# === SPÉCIFICATION OPENAPI ===
+def json_schema(annotation: Any) -> Dict[str, Any]:
+    """Schéma JSON d'une annotation de type Python"""
+    origin, args = get_origin(annotation), get_args(annotation)
+    if origin is Union:
+        schemas = [json_schema(arg) for arg in args]
+        return {'anyOf': schemas} if len(schemas) > 1 else schemas[0]
+    if origin in (list, List, set, Set, tuple, Tuple):
+        return {'type': 'array', 'items': json_schema(args[0]) if args else {}}
+    if origin in (dict, Dict):
+        return {'type': 'object', 'additionalProperties': json_schema(args[1]) if args else {}}
+    return {str: {'type': 'string'}, int: {'type': 'integer'}, float: {'type': 'number'},
+            bool: {'type': 'boolean'}, type(None): {'type': 'null'}}.get(annotation, {})
+
+def dataclass_schema(cls: type) -> Dict[str, Any]:
+    """Schéma d'un dataclass - la spécification suit les structures réellement renvoyées"""
+    hints = get_type_hints(cls)
+    return {
+        'type': 'object',
+        'description': (cls.__doc__ or '').strip(),
+        'properties': {field.name: json_schema(hints[field.name]) for field in fields(cls)},
+        'required': [field.name for field in fields(cls)
+                     if field.default is MISSING and field.default_factory is MISSING]
+    }
+
+def object_schema(properties: Dict[str, Dict[str, Any]], required: List[str]) -> Dict[str, Any]:
+    return {'type': 'object', 'properties': properties, 'required': required}
+
+API_SCHEMAS = {
+    'FixResult': dataclass_schema(FixResult),
+    'FixResponse': object_schema({
+        'results': {'type': 'array', 'items': {'$ref': '#/components/schemas/FixResult'}},
+        'stats': {'type': 'object'},
+        'commit': {'anyOf': [{'type': 'string'}, {'type': 'null'}]},
+        'cache': {'anyOf': [{'$ref': '#/components/schemas/CacheStatus'}, {'type': 'null'}]},
+        'proposal': {'$ref': '#/components/schemas/Proposal'},
+        'pull_request': object_schema({
+            'reason': {'type': 'string', 'enum': ['protected', 'fork'],
+                       'description': 'Target branch protected, or no push access (fork mode)'},
+            'base': {'type': 'string', 'description': 'Branch the pull request targets'},
+            'branch': {'type': 'string', 'description': 'Branch the fixes were pushed to'},
+            'fork': {'anyOf': [{'type': 'string'}, {'type': 'null'}], 'description': 'Fork holding the branch'},
+            'number': {'type': 'integer'},
+            'url': {'anyOf': [{'type': 'string'}, {'type': 'null'}]}
+        }, ['reason', 'base', 'branch', 'number'])
+    }, ['results', 'stats']),
+    'CacheStatus': object_schema({
+        'hit': {'type': 'boolean', 'description': 'Result served from the commit cache'},
+        'commit': {'type': 'string', 'description': 'Analyzed commit SHA'},
+        'cached_at': {'anyOf': [{'type': 'string'}, {'type': 'null'}]}
+    }, ['hit', 'commit', 'cached_at']),
+    'FixDetail': object_schema({
+        'rule': {'type': 'string'},
+        'line': {'anyOf': [{'type': 'integer'}, {'type': 'null'}]},
+        'severity': {'type': 'string', 'enum': ['cosmetic', 'aggressive']},
+        'message': {'type': 'string'}
+    }, ['rule', 'line', 'severity', 'message']),
+    'RepositoryRequest': object_schema({
+        'path': {'type': 'string', 'default': '.', 'description': 'Repository path on the server'},
+        'url': {'type': 'string', 'description': 'Remote repository (https/ssh), checked out from the mirror cache instead of `path`'},
+        'ref': {'type': 'string', 'default': 'HEAD', 'description': 'Branch, tag or commit to check out with `url`'},
+        'project': {'type': 'string', 'description': 'Registered project (default: GitHub origin remote)'},
+        'strategy': {'$ref': '#/components/schemas/Strategy'},
+        'include_content': {'type': 'boolean', 'default': False, 'description': 'Return the full fixed_content of each file'}
+    }, []),
+    'Strategy': {'type': 'string', 'enum': list(STRATEGIES) + list(StrategyPolicy.registry()),
+                 'description': 'Force a strategy (remote, local, pattern) or select a policy for this request'},
+    'SnippetRequest': object_schema({
+        'language': {'type': 'string', 'description': 'Language of the snippet (python, javascript, go, ...)'},
+        'code': {'type': 'string', 'description': 'Code to fix'}
+    }, ['language', 'code']),
+    'SnippetResponse': object_schema({
+        'language': {'type': 'string'},
+        'code': {'type': 'string', 'description': 'Fixed code'},
+        'changed': {'type': 'boolean'},
+        'diff': {'type': 'string', 'description': 'Unified diff from the submitted code'},
+        'rules': {'type': 'array', 'items': {'type': 'string'}},
+        'fixes': {'type': 'array', 'items': {'type': 'string'}},
+        'errors': {'type': 'array', 'items': {'type': 'string'}}
+    }, ['language', 'code', 'changed', 'diff', 'rules', 'fixes', 'errors']),
+    'JobRequest': object_schema({
+        'kind': {'type': 'string', 'enum': ['fix-repository', 'fix-github']},
+        'request': {'description': 'RepositoryRequest or GitHubRequest body', 'type': 'object'}
+    }, ['kind']),
+    'Job': object_schema({
+        'id': {'type': 'string'},
+        'status': {'type': 'string', 'enum': ['queued', 'running', 'succeeded', 'failed', 'cancelled']}
+    }, ['id', 'status']),
+    'Proposal': object_schema({
+        'id': {'type': 'string', 'description': 'Run ID of the proposing run'},
+        'repo': {'type': 'string'},
+        'base_ref': {'type': 'string'},
+        'base_sha': {'type': 'string'},
+        'branch': {'type': 'string', 'description': 'Quarantine branch holding the fixes'},
+        'commit_sha': {'type': 'string'},
+        'files': {'type': 'integer'},
+        'status': {'type': 'string', 'enum': ['proposed', 'approved', 'merged']},
+        'pull_request': {'anyOf': [{'type': 'integer'}, {'type': 'null'}]},
+        'created_at': {'type': 'string'},
+        'decided_at': {'anyOf': [{'type': 'string'}, {'type': 'null'}]},
+        'decided_by': {'anyOf': [{'type': 'string'}, {'type': 'null'}]}
+    }, ['id', 'repo', 'base_ref', 'branch', 'commit_sha', 'status']),
+    'ProposalApproval': object_schema({
+        'merge': {'type': 'boolean', 'default': False, 'description': 'Merge the pull request after opening it'},
+        'merge_method': {'type': 'string', 'enum': ['merge', 'squash', 'rebase'], 'default': 'squash'},
+        'token': {'type': 'string', 'description': 'GitHub token (default: GITHUB_TOKEN)'},
+        'approver': {'type': 'string', 'description': 'Name recorded as approver when no API key identifies the caller'}
+    }, []),
+    'ProjectConfig': object_schema({
+        'enabled_rules': {'type': 'array', 'items': {'type': 'string'}, 'description': 'Rule IDs or groups'},
+        'languages': {'type': 'array', 'items': {'type': 'string'}},
+        'exclude_languages': {'type': 'array', 'items': {'type': 'string'}},
+        'branch': {'type': 'string', 'description': 'Fix branch name, may use {date} and {run_id}'},
+        'pull_request': object_schema({'push': {'type': 'boolean'}}, [])
+    }, []),
+    'GitHubRequest': object_schema({
+        'repo': {'type': 'string', 'description': 'owner/name'},
+        'ref': {'type': 'string', 'default': 'main'},
+        'path': {'type': 'string', 'default': '', 'description': 'Subdirectory to fix'},
+        'branch': {'type': 'string', 'description': 'Commit the fixes to this branch'},
+        'commit': {'type': 'boolean', 'description': "Commit to the project's branch naming when no branch is given"},
+        'pr': {'type': 'integer', 'description': 'Fix this pull request and post a review'},
+        'push': {'type': 'boolean', 'description': "With pr, push the fixes (default: project's pull_request.push)"},
+        'token': {'type': 'string', 'description': 'GitHub token (default: GITHUB_TOKEN)'},
+        'cache': {'type': 'boolean', 'default': True, 'description': 'Reuse the result of the same commit'},
+        'commit_status': {'type': 'boolean', 'description': 'Post a pending/success/failure status on the processed commit (default: server setting)'},
+        'fork': {'type': 'boolean', 'description': 'Without push access, push the branch to a fork and open a cross-repository pull request'},
+        'propose': {'type': 'boolean', 'description': 'Push to a quarantine branch and wait for approval (POST /proposals/{id}/approve)'},
+        'strategy': {'$ref': '#/components/schemas/Strategy'},
+        'include_content': {'type': 'boolean', 'default': False, 'description': 'Return the full fixed_content of each file'}
+    }, ['repo']),
+    'LanguageTree': object_schema({
+        'path': {'type': 'string', 'description': "Directory relative to the repository root ('' for the root)"},
+        'files': {'type': 'integer', 'description': 'Files with a detected language, subdirectories included'},
+        'languages': {'type': 'object', 'additionalProperties': {'type': 'integer'}},
+        'directories': {'type': 'object', 'additionalProperties': {'$ref': '#/components/schemas/LanguageTree'}}
+    }, ['path', 'files', 'languages', 'directories']),
+    'HealthScore': object_schema({
+        'score': {'type': 'integer', 'minimum': 0, 'maximum': 100},
+        'files': {'type': 'integer'},
+        'files_needing_fixes': {'type': 'integer'},
+        'weighted_fixes': {'type': 'integer'},
+        'formatter_configs': {'type': 'array', 'items': {'type': 'string'}}
+    }, ['score', 'files', 'files_needing_fixes', 'weighted_fixes', 'formatter_configs'])
+}
+
+# v2 : même résultat que v1, plus le détail de chaque correction par règle
+API_SCHEMAS['FixResultV2'] = {**API_SCHEMAS['FixResult'], 'properties': {
+    **API_SCHEMAS['FixResult']['properties'],
+    'fixes': {'type': 'array', 'items': {'$ref': '#/components/schemas/FixDetail'}}
+}, 'required': API_SCHEMAS['FixResult']['required'] + ['fixes']}
+API_SCHEMAS['FixResponseV2'] = {**API_SCHEMAS['FixResponse'], 'properties': {
+    **API_SCHEMAS['FixResponse']['properties'],
+    'results': {'type': 'array', 'items': {'$ref': '#/components/schemas/FixResultV2'}}
+}}
+
+FIX_LINE = re.compile(r'\bon line (\d+)')
+
+def result_v1(result: FixResult, include_content: bool = False) -> Dict[str, Any]:
+    """Résultat au format v1 - contenu corrigé complet (fixed_content) seulement sur demande"""
+    payload = asdict(result)
+    if not include_content:
+        del payload['fixed_content']
+    return payload
+
+def result_from_payload(payload: Dict[str, Any], changes: Dict[str, str]) -> FixResult:
+    """Résultat sérialisé sans contenu : fixed_content restauré depuis les modifications (chemin -> contenu)"""
+    return FixResult(**{**payload, 'fixed_content': changes.get(payload['file_path'], payload.get('fixed_content'))})
+
+def result_v2(result: FixResult, include_content: bool = False) -> Dict[str, Any]:
+    """Résultat au format v2 : champs v1 et détail règle / ligne / sévérité de chaque correction"""
+    payload = result_v1(result, include_content)
+    payload['fixes'] = []
+    for fix in result.fixes_applied:
+        rule = fix_rule_id(fix)
+        if rule == fix:
+            continue  # Entrée sans règle identifiable
+        line = FIX_LINE.search(fix)
+        payload['fixes'].append({'rule': rule, 'line': int(line.group(1)) if line else None,
+                                 'severity': fix_severity(fix), 'message': fix})
+    return payload
+
+def json_operation(request: Optional[str], response: str) -> Dict[str, Any]:
+    """Corps de requête et réponse JSON d'une route (openapi_extra)"""
+    operation = {'responses': {'200': {'description': 'Successful Response', 'content': {
+        'application/json': {'schema': {'$ref': f'#/components/schemas/{response}'}}}}}}
+    if request is not None:
+        operation['requestBody'] = {'required': True, 'content': {
+            'application/json': {'schema': {'$ref': f'#/components/schemas/{request}'}}}}
+    return operation
+
+API_VERSION_PREFIXES = ('/api/v1/', '/api/v2/')
+# Routes non versionnées conservées comme alias dépréciés de /api/v1
+API_ROUTES = {'/api/fix-files', '/api/fix-snippet', '/api/fix-archive', '/api/fix-repository', '/api/fix-github', '/api/health-score', '/api/stats',
+              '/api/projects', '/api/jobs', '/api/language-tree', '/api/proposals'}
+
+SVG_RESPONSE = {200: {'description': 'SVG badge', 'content': {'image/svg+xml': {}}}}
+ZIP_RESPONSE = {200: {'description': 'Zip of the fixed tree, report in asf-report.json',
+                      'content': {'application/zip': {}}}}
+
+class RunScoped:
+    """🧵 ATTRIBUT D'EXÉCUTION - Valeur propre à l'exécution en cours (run_state_var)
+    
+    Hors exécution configurée, lecture et écriture portent sur l'instance (valeurs de base).
+    Deux travaux concurrents du serveur ont chacun leur contexte asyncio, donc leurs réglages.
+    """
+    
+    def __set_name__(self, owner: type, name: str):
+        self.name = name
+    
+    @staticmethod
+    def _values(instance: Any) -> Optional[Dict[str, Any]]:
+        state = run_state_var.get()
+        return state[1] if state is not None and state[0] is instance else None
+    
+    def __get__(self, instance: Any, owner: Optional[type] = None) -> Any:
+        if instance is None:
+            return self
+        values = self._values(instance)
+        if values is not None and self.name in values:
+            return values[self.name]
+        try:
+            return instance.__dict__[self.name]
+        except KeyError:
+            raise AttributeError(self.name) from None
+    
+    def __set__(self, instance: Any, value: Any):
+        values = self._values(instance)
+        (values if values is not None else instance.__dict__)[self.name] = value
 
 class AutoSyntaxFixerILN3:
     """🚀 AUTO-SYNTAX-FIXER ILN NIVEAU 3 - CLASSE PRINCIPALE"""
     
+    JOB_KINDS = ('fix-repository', 'fix-github')
+    
+    # Réglages issus de configure() et état de l'exécution : jamais partagés entre travaux concurrents
+    registry = RunScoped()
+    plugins = RunScoped()
+    syntax_analyzer = RunScoped()
+    language_detector = RunScoped()
+    enabled_languages = RunScoped()
+    excluded_languages = RunScoped()
+    fail_policy = RunScoped()
+    baseline_file = RunScoped()
+    history_enabled = RunScoped()
+    fix_log_path = RunScoped()
+    commit_trailers = RunScoped()
+    commit_status = RunScoped()
+    blame_ignore_revs = RunScoped()
+    blame_filter = RunScoped()
+    walk = RunScoped()
+    quarantine_enabled = RunScoped()
+    verifier = RunScoped()
+    compile_checker = RunScoped()
+    project_formatter = RunScoped()
+    secondary_tools = RunScoped()
+    typescript_fixer = RunScoped()
+    module_style_converter = RunScoped()
+    go_struct_fixer = RunScoped()
+    go_error_fixer = RunScoped()
+    hygiene = RunScoped()
+    last_quarantine = RunScoped()
+    last_verification = RunScoped()
+    last_compile_check = RunScoped()
+    project_formatted = RunScoped()
+    project_format_command = RunScoped()
+    
+    # Taille maximale d'un fichier traité (octets)
+    MAX_FILE_SIZE = 2 * 1024 * 1024
+    # Extraits collés (POST /fix-snippet)
+    MAX_SNIPPET_SIZE = 64 * 1024
+    # Au-delà de MAX_FILE_SIZE : règles ligne à ligne en flux, jusqu'à cette taille
+    MAX_STREAM_SIZE = 64 * 1024 * 1024
+    
+    DEFAULT_SECONDARY_TOOLS = {'python': ['autoflake', 'pyupgrade']}
+    
+    # Commit de correction considéré comme reformatage massif (.git-blame-ignore-revs)
+    BLAME_IGNORE_MIN_FILES = 20
+    
+    # Branches traitées simultanément (--branches)
+    BRANCH_CONCURRENCY = 4
+    
     def __init__(self):
         # Python Interface (Familière)
         self.shell_champion = ShellChampion()
+        self.workspaces = WorkspaceManager()
+        self.mirrors = MirrorCache(os.environ.get('ASF_MIRROR_CACHE'))
+        self.eslint = ESLintRunner(self.shell_champion)
         self.language_detector = LanguageDetector()
         self.syntax_analyzer = SyntaxAnalyzer()
+        self.unicode_cleaner = UnicodeCleaner(self.syntax_analyzer.rule_enabled)
+        self.import_merger = ImportMerger(self.syntax_analyzer.rule_enabled)
+        
+        # Normalisation CommonJS/ESM (opt-in, `javascript.module_style`)
+        self.module_style_converter: Optional[ModuleStyleConverter] = None
+        
+        # Plugins externes par langage
+        self.plugins: Dict[str, ExecPluginFixer] = {}
+        
+        # Sélection des langages (CLI puis configuration)
+        self.cli_language_selection: Tuple[Optional[List[str]], Optional[List[str]]] = (None, None)
+        self.enabled_languages: Optional[Set[str]] = None
+        self.excluded_languages: Set[str] = set()
+        self.cli_rules: Optional[List[str]] = None
+        
+        # Configuration des projets enregistrés (mode serveur), prioritaire sur celle du repository
+        self.projects = ProjectStore()
+        # Corrections en attente d'approbation (application en deux phases)
+        self.proposals = ProposalStore()
+        
+        # Clés d'API (exigées si ASF_REQUIRE_API_KEY=1), quotas par niveau et administration
+        self.api_keys = ApiKeyStore()
+        self.require_api_key = os.environ.get('ASF_REQUIRE_API_KEY') == '1'
+        self.rate_limiter = RateLimiter()
+        self.jobs = JobTracker(JobHistoryStore())
+        
+        # File de travaux asynchrones (en mémoire, ou Redis via ASF_REDIS_URL / --redis-url)
+        self.job_queue = JobQueue(PendingJobStore())
+        self.job_workers = int(os.environ.get('ASF_JOB_WORKERS', '2'))
+        self.concurrency = ConcurrencyProfile.from_spec(None)
+        self._worker_tasks: List[asyncio.Task] = []
+        
+        # Arrêt gracieux (SIGTERM/SIGINT) : refus des nouveaux travaux, délai pour terminer ceux en cours
+        self.draining = False
+        self.shutdown_timeout = float(os.environ.get('ASF_SHUTDOWN_TIMEOUT', '30'))
+        self._active_jobs: Dict[str, Dict[str, Any]] = {}
+        
+        # Hors ligne (--offline, ASF_OFFLINE=1) : ni API distante ni téléchargement, outils locaux seulement
+        self.offline = False
+        
+        # Services de formatage distants par langage (--remote-formatters, ASF_REMOTE_FORMATTERS)
+        self.remote_formatters: Dict[str, RemoteFormatter] = {}
+        
+        # Choix distant / local / patterns par fichier (--strategy-policy, surcharge par requête)
+        self.strategy_policy = StrategyPolicy()
+        self.strategy_policies: Dict[str, StrategyPolicy] = {}
+        
+        # Mode coordinateur : gros repositories répartis entre workers (ASF_WORKERS, --workers)
+        workers = [w.strip() for w in os.environ.get('ASF_WORKERS', '').split(',') if w.strip()]
+        self.coordinator: Optional[WorkerCoordinator] = WorkerCoordinator(workers) if workers else None
+        
+        # Résultats GitHub par commit (ASF_RESULT_CACHE=0 pour désactiver)
+        self.result_cache: Optional[ResultCache] = (
+            ResultCache() if os.environ.get('ASF_RESULT_CACHE', '1') != '0' else None)
+        self.admin_token: Optional[str] = os.environ.get('ASF_ADMIN_TOKEN') or None
+        
+        # Sous-ensemble de chemins (globs relatifs au repository, CLI)
+        self.include_paths: List[str] = []
+        
+        # Référence git : corrections limitées aux lignes modifiées depuis celle-ci
+        self.diff_base: Optional[str] = None
+        # Lignes récentes laissées intactes (--skip-fresh-days / --skip-authors, `blame:`)
+        self.cli_blame: Dict[str, Any] = {}
+        self.blame_filter: Optional[BlameFilter] = None
+        # Manifeste de progression des exécutions locales (reprise avec --resume)
+        self.run_manifests = False
+        
+        # Registre des correcteurs de fichier complet (natifs et plugins)
+        self.registry = FixerRegistry()
+        
+        # Politique d'échec (seuils) : CLI prioritaire sur la configuration
+        self.cli_fail_policy: Dict[str, Any] = {}
+        self.fail_policy: Dict[str, Any] = {}
+        
+        # Fichier baseline (CLI prioritaire sur la configuration)
+        self.cli_baseline_file: Optional[str] = None
+        self.baseline_file: Optional[str] = None
+        
+        # Historique des exécutions (.asf/history.json)
+        self.cli_history = True
+        self.history_enabled = True
+        # Journal des corrections versionné dans le repository (--fix-log / fix_log:)
+        self.cli_fix_log: Optional[str] = None
+        self.fix_log_path: Optional[str] = None
+        # Trailers Asf-* dans les commits de correction
+        self.cli_commit_trailers = True
+        self.commit_trailers = True
+        # Statut auto-syntax-fixer sur le commit traité (mode GitHub)
+        self.cli_commit_status = False
+        self.commit_status = False
+        # Commits d'au moins N fichiers ajoutés à .git-blame-ignore-revs (None : désactivé)
+        self.cli_blame_ignore_revs = True
+        self.blame_ignore_revs: Optional[int] = self.BLAME_IGNORE_MIN_FILES
+        
+        # Parcours du repository (dossiers ignorés, limites) - section `walk:` de la configuration
+        self.walk = WalkPolicy()
+        
+        # Quarantaine des fichiers en échec répété (.asf/quarantine.json)
+        self.cli_quarantine = True
+        self.quarantine_enabled = True
+        self.last_quarantine: Optional[Dict[str, Any]] = None
+        # --dry-run : aucun fichier d'état écrit dans le repository (quarantaine, historique, journal, manifeste)
+        self.dry_run = False
+        
+        # Vérification par la suite de tests du projet (opt-in)
+        self.cli_verify = False
+        # Commandes déclarées par le .syntaxfixer.yml du repository (REPO_EXEC_KEYS) : désactivées par défaut,
+        # réglage de l'opérateur uniquement (--allow-repo-plugins, ASF_ALLOW_REPO_PLUGINS=1)
+        self.allow_repo_exec = os.environ.get('ASF_ALLOW_REPO_PLUGINS') == '1'
+        self.verifier: Optional[TestVerifier] = None
+        self.last_verification: Optional[Dict[str, Any]] = None
+        
+        # Commande de formatage du projet, prioritaire sur les correcteurs intégrés (opt-in)
+        self.cli_project_format = False
+        self.project_formatter: Optional[ProjectFormatter] = None
+        self.project_formatted: Dict[str, str] = {}
+        self.project_format_command: Optional[str] = None
+        
+        # Réglages réseau des appels aux fournisseurs : api_url, proxy, ca_bundle
+        self.network: Dict[str, Optional[str]] = {}
+        
+        # Vérification de compilation des langages compilés (opt-in)
+        self.cli_compile_check = False
+        self.compile_checker: Optional[CompileChecker] = None
+        self.last_compile_check: Optional[Dict[str, Any]] = None
+        self._register_builtin_fixers({})
+        
+        # Dernière analyse par repository GitHub (owner/repo), servie par /badge
+        self.latest_analyses = AnalysisStore()
+        
+        # Transformations Go opt-in
+        self.go_struct_fixer = GoStructTagFixer()
+        self.go_error_fixer = GoErrorCheckFixer()
+        
+        # Outils secondaires exécutés après le formateur principal
+        self.secondary_tools: Dict[str, List[str]] = dict(self.DEFAULT_SECONDARY_TOOLS)
+        
+        # Passe TypeScript opt-in (codefixes du compilateur)
+        self.typescript_fixer = TypeScriptCodeFixer(self.shell_champion)
         
         # Statistiques et métriques
         self.stats = {
@@ -314,7 +7580,7 @@ class AutoSyntaxFixerILN3:
         app = FastAPI(
             title="🔧 Auto-Syntax-Fixer ILN",
             description="Universal syntax fixer with ILN architecture",
-            version="3.0.0"
+            version=TOOL_VERSION
         )
         
         app.add_middleware(
@@ -327,54 +7593,608 @@ class AutoSyntaxFixerILN3:
         
         # Routes
         self._setup_routes(app)
+        
+        # Spécification OpenAPI (/openapi.json, Swagger UI sur /docs) : schémas partagés des routes
+        generate_openapi = app.openapi
+        
+        def openapi() -> Dict[str, Any]:
+            schema = generate_openapi()
+            schema.setdefault('components', {}).setdefault('schemas', {}).update(API_SCHEMAS)
+            return schema
+        
+        app.openapi = openapi
+        
+        @app.on_event("startup")
+        async def start_job_workers():
+            resumed = await self.job_queue.resume()
+            if resumed:
+                logger.info("resumed %d jobs suspended at last shutdown", resumed)
+            self._worker_tasks = [asyncio.create_task(self._job_worker()) for _ in range(self.job_workers)]
+        
+        @app.on_event("shutdown")
+        async def cleanup_workspace():
+            await self.drain_jobs()
+            self.workspaces.cleanup()
+            self.shell_champion.cleanup()
+            tracing.shutdown()
+        
         return app
     
     def _setup_routes(self, app: FastAPI):
         """Configuration des routes API"""
         
-        @app.get("/", response_class=HTMLResponse)
+        @app.get("/", response_class=HTMLResponse, include_in_schema=False)
         async def serve_interface():
             return self._generate_web_interface()
         
-        @app.post("/api/fix-files")
-        async def fix_files_endpoint(files: List[UploadFile] = File(...)):
+        # API versionnée : /api/v1 (schéma stable), /api/v2 ; /api seul = v1 déprécié
+        self._setup_api_routes(app, '/api/v1', 'v1')
+        self._setup_api_routes(app, '/api/v2', 'v2')
+        self._setup_api_routes(app, '/api', 'v1', deprecated=True)
+        
+        self._setup_admin_routes(app)
+        self._setup_worker_routes(app)
+        
+        @app.middleware("http")
+        async def reject_while_draining(request: Request, call_next):
+            """Arrêt en cours : plus de nouvelle correction (le répartiteur réessaie ailleurs)"""
+            if self.draining and request.method != 'GET' and request.url.path.startswith('/api/'):
+                return JSONResponse({'detail': 'Server is shutting down'}, status_code=503,
+                                    headers={'Retry-After': '5'})
+            return await call_next(request)
+        
+        @app.middleware("http")
+        async def api_key_auth(request: Request, call_next):
+            """Clé d'API (en-tête X-API-Key) et quota horaire du niveau - badges publics hors /api/"""
+            path = request.url.path
+            if (not self.require_api_key or not path.startswith('/api/') or path.startswith('/api/admin/')
+                    or path == WorkerCoordinator.SHARD_PATH):
+                return await call_next(request)
+            key = request.headers.get('x-api-key')
+            client = await asyncio.to_thread(self.api_keys.authenticate, key) if key else None
+            if client is None:
+                return JSONResponse({'detail': 'Missing or invalid API key'}, status_code=401)
+            quota = (await asyncio.to_thread(self.api_keys.tiers)).get(client['tier'], {})
+            if not self.rate_limiter.allow(client['name'], quota.get('requests_per_hour', 0)):
+                return JSONResponse({'detail': f"Hourly quota of tier {client['tier']} exceeded"},
+                                    status_code=429, headers={'Retry-After': str(int(RateLimiter.WINDOW))})
+            token = api_client_var.set(client['name'])
+            try:
+                return await call_next(request)
+            finally:
+                api_client_var.reset(token)
+        
+        @app.middleware("http")
+        async def deprecation_headers(request: Request, call_next):
+            response = await call_next(request)
+            path = request.url.path
+            if (path.startswith('/api/') and not path.startswith(API_VERSION_PREFIXES)
+                    and (path in API_ROUTES or path.startswith(('/api/projects/', '/api/jobs/', '/api/proposals/')))):
+                response.headers['Deprecation'] = 'true'
+                response.headers['Link'] = f'<{API_VERSION_PREFIXES[0]}{path[4:]}>; rel="successor-version"'
+            return response
+        
+        @app.get("/badge/{owner}/{repo}/health.svg", tags=['badges'], response_class=Response, responses=SVG_RESPONSE)
+        async def health_badge_endpoint(owner: str, repo: str):
+            """Badge SVG du score de santé d'un projet enregistré, calculé à sa dernière analyse (aucune exécution)"""
+            name = ProjectStore.key(f"{owner}/{repo}")
+            if await asyncio.to_thread(self.projects.get, name) is None:
+                raise HTTPException(status_code=404, detail=f"Unknown project {owner}/{repo}")
+            score = (self.latest_analyses.get(name) or {}).get('health')
+            badge = (render_badge('health', 'unknown', '#9f9f9f') if score is None
+                     else render_badge('health', f"{score}/100", HealthScore.color(score)))
+            return Response(content=badge, media_type='image/svg+xml', headers={'Cache-Control': 'max-age=300'})
+        
+        @app.get("/badge/{owner}/{repo}.svg", tags=['badges'], response_class=Response, responses=SVG_RESPONSE)
+        async def repository_badge(owner: str, repo: str):
+            """Badge "syntax" de la dernière analyse du repository (gris si jamais analysé)"""
+            analysis = self.latest_analyses.get(f"{owner}/{repo}".lower())
+            if analysis is None:
+                badge = render_badge('syntax', 'unknown', '#9f9f9f')
+            elif analysis['issues'] == 0:
+                badge = render_badge('syntax', 'clean', '#4c1')
+            else:
+                issues = analysis['issues']
+                badge = render_badge('syntax', f"{issues} issue{'s' if issues > 1 else ''}", '#e05d44')
+            return Response(content=badge, media_type='image/svg+xml',
+                            headers={'Cache-Control': 'no-cache, max-age=0'})
+        
+        @app.websocket("/ws")
+        async def websocket_endpoint(websocket: WebSocket):
+            """WebSocket pour updates en temps réel"""
+            await websocket.accept()
+            try:
+                while True:
+                    await websocket.send_json(self.stats)
+                    await asyncio.sleep(1)
+            except WebSocketDisconnect:
+                pass
+    
+    async def process_repository(self, options: ProcessOptions) -> ProcessReport:
+        """Point d'entrée unique : détection, analyse, correction, validation puis étapes git/PR"""
+        run_state_var.set(None)
+        cache_status_var.set(None)
+        fallback_pr_var.set(None)
+        result_listener_var.set(options.on_result)
+        commit_sha, proposal = None, None
+        if options.github:
+            if options.pr:
+                results, commit_sha = await self.fix_github_pull_request(
+                    options.github, options.pr, options.push, options.token)
+            elif options.propose:
+                # Application en deux phases : branche de quarantaine, approbation ensuite
+                results, proposal = await self.propose_github_fixes(
+                    options.github, options.ref or 'main', options.subdir, options.token, options.branch)
+                commit_sha = proposal['commit_sha'] if proposal else None
+            else:
+                results, commit_sha = await self.fix_github_repository(
+                    options.github, options.ref or 'main', options.subdir, options.branch, options.token,
+                    use_cache=options.use_cache, fork=options.fork, report_status=options.commit_status)
+            summary = self.get_summary_report(results)
+            if 'diff_stats' in summary:
+                # Contenus d'origine distants : pas de statistiques depuis le disque local
+                summary['diff_stats'] = None
+        elif options.url:
+            # Worktree de `ref` depuis le cache de miroirs, chemins relatifs au dépôt
+            async with self.mirrors.checkout(options.url, options.ref or 'HEAD', self.workspaces,
+                                             self.offline) as path:
+                results = await self.fix_repository(str(path), options.use_baseline)
+                summary = self.get_summary_report(results)
+                for result in results:
+                    result.file_path = Path(os.path.relpath(result.file_path, path)).as_posix()
+        else:
+            results = await self.fix_repository(options.path, options.use_baseline)
+            summary = self.get_summary_report(results)
+        
+        return ProcessReport(results=results, summary=summary, commit=commit_sha,
+                             proposal=proposal, pull_request=fallback_pr_var.get(),
+                             cache=cache_status_var.get())
+    
+    async def run_repository_request(self, repo_data: Dict[str, Any]) -> List[FixResult]:
+        """Correction d'un repository du serveur (requête directe ou travail en file)"""
+        if repo_data.get('url'):
+            return await self.run_mirror_request(repo_data)
+        repo_path = repo_data.get('path', '.')
+        project_name = repo_data.get('project') or github_slug(Path(repo_path))
+        with self.request_strategy(repo_data), self.jobs.track('fix-repository', repo_path, api_client_var.get()), \
+                self.project_settings(project_name):
+            report = await self.process_repository(ProcessOptions(path=repo_path))
+        self.record_analysis(project_name, report.results, Path(repo_path))
+        return report.results
+    
+    async def run_mirror_request(self, repo_data: Dict[str, Any]) -> List[FixResult]:
+        """Repository distant (`url`) : worktree de `ref` depuis le cache de miroirs"""
+        url, ref = repo_data['url'], repo_data.get('ref', 'HEAD')
+        if not MirrorCache.ALLOWED_URL.match(url):
+            raise HTTPException(status_code=400, detail=f"Unsupported repository URL: {url} (https or ssh only)")
+        remote = GITHUB_REMOTE.search(url)
+        project_name = repo_data.get('project') or (f"{remote.group(1)}/{remote.group(2)}" if remote else None)
+        with self.request_strategy(repo_data), self.jobs.track('fix-repository', url, api_client_var.get()), \
+                self.project_settings(project_name):
+            try:
+                report = await self.process_repository(ProcessOptions(url=url, ref=ref))
+            except (MirrorError, OfflineError) as e:
+                raise HTTPException(status_code=502, detail=str(e))
+            except WorktreeError as e:
+                raise HTTPException(status_code=400, detail=str(e))
+        self.record_analysis(project_name, report.results)
+        return report.results
+    
+    def request_strategy(self, repo_data: Dict[str, Any]) -> contextlib.AbstractContextManager:
+        """Champ `strategy` de la requête - 400 si inconnu"""
+        try:
+            return self.strategy_override(StrategyPolicy.validate_override(repo_data.get('strategy')))
+        except ValueError as e:
+            raise HTTPException(status_code=400, detail=str(e))
+    
+    async def run_github_request(self, repo_data: Dict[str, Any]) -> Tuple[List[FixResult], Optional[str]]:
+        """Correction d'un repository GitHub sans clone (requête directe ou travail en file)"""
+        if 'repo' not in repo_data:
+            raise HTTPException(status_code=400, detail="Missing 'repo' (owner/name)")
+        try:
+            with self.request_strategy(repo_data), self.jobs.track('fix-github', repo_data['repo'], api_client_var.get()), \
+                    self.project_settings(repo_data['repo']) as project:
+                # Branche explicite, sinon nommage du projet (proposition ou commit demandé)
+                branch = repo_data.get('branch') or (
+                    ProjectStore.branch_name(project) if repo_data.get('commit') or repo_data.get('propose') else None)
+                report = await self.process_repository(ProcessOptions(
+                    github=repo_data['repo'],
+                    ref=repo_data.get('ref', 'main'),
+                    subdir=repo_data.get('path', ''),
+                    branch=branch,
+                    token=repo_data.get('token'),
+                    pr=int(repo_data['pr']) if repo_data.get('pr') else None,
+                    push=bool(repo_data.get('push', (project.get('pull_request') or {}).get('push', False))),
+                    propose=bool(repo_data.get('propose')),
+                    fork=bool(repo_data.get('fork', False)),
+                    commit_status=repo_data.get('commit_status'),
+                    use_cache=repo_data.get('cache', True) is not False
+                ))
+        except GitHubApiError as e:
+            raise HTTPException(status_code=502, detail=str(e))
+        except OfflineError as e:
+            raise HTTPException(status_code=503, detail=str(e))
+        self.record_analysis(repo_data['repo'], report.results)
+        return report.results, report.commit
+    
+    async def _job_worker(self):
+        """Consommation de la file de travaux (une boucle par worker, sur chaque réplica)"""
+        while True:
+            job = await self.job_queue.get()
+            if self.draining:
+                self.job_queue.requeue(job)
+                return
+            new_run_id()
+            run_state_var.set(None)
+            token = api_client_var.set(job.get('client'))
+            self._active_jobs[job['id']] = job
+            self.job_queue.save(job['id'], {'id': job['id'], 'status': 'running', 'kind': job['kind']})
+            try:
+                if job['kind'] == 'fix-github':
+                    results, commit_sha = await self.run_github_request(job['request'])
+                else:
+                    results, commit_sha = await self.run_repository_request(job['request']), None
+                include_content = bool(job['request'].get('include_content'))
+                payload = {'status': 'succeeded', 'results': [result_v1(r, include_content) for r in results],
+                           'commit': commit_sha,
+                           'cache': cache_status_var.get()}
+            except asyncio.CancelledError:
+                if self.draining:
+                    # Délai d'arrêt dépassé : travail repris depuis le début au prochain démarrage
+                    self.job_queue.requeue(job)
+                else:
+                    self.job_queue.save(job['id'], {'id': job['id'], 'status': 'cancelled', 'kind': job['kind']})
+                    self.job_queue.ack(job)
+                raise
+            except Exception as e:
+                logger.error("job %s failed: %s", job['id'], getattr(e, 'detail', None) or e,
+                             exc_info=not isinstance(e, HTTPException))
+                payload = {'status': 'failed', 'error': getattr(e, 'detail', None) or str(e)}
+            finally:
+                api_client_var.reset(token)
+                self._active_jobs.pop(job['id'], None)
+            self.job_queue.save(job['id'], {'id': job['id'], 'kind': job['kind'], **payload})
+            self.job_queue.ack(job)
+    
+    async def drain_jobs(self):
+        """Arrêt gracieux : travaux en cours terminés dans le délai, les autres sauvegardés pour reprise"""
+        self.draining = True
+        deadline = time.monotonic() + self.shutdown_timeout
+        while self._active_jobs and time.monotonic() < deadline:
+            await asyncio.sleep(0.2)
+        if self._active_jobs:
+            logger.warning("shutdown deadline reached, interrupting %d jobs", len(self._active_jobs))
+        for task in self._worker_tasks:
+            task.cancel()
+        await asyncio.gather(*self._worker_tasks, return_exceptions=True)
+        suspended = self.job_queue.suspend()
+        if suspended:
+            logger.info("saved %d queued jobs for resume", suspended)
+    
+    def use_redis(self, url: str):
+        """Backends Redis partagés : limiteur de débit, état des travaux, file, résultats et analyses"""
+        client = redis_client(url)
+        self.rate_limiter = RedisRateLimiter(client)
+        self.jobs = RedisJobTracker(client, self.jobs.history)
+        self.job_queue = RedisJobQueue(client)
+        self.result_cache = RedisResultCache(client)
+        self.latest_analyses = RedisAnalysisStore(client)
+    
+    def _require_admin(self, request: Request):
+        """Jeton d'administration (Authorization: Bearer) - API désactivée sans ASF_ADMIN_TOKEN"""
+        if not self.admin_token:
+            raise HTTPException(status_code=404, detail="Admin API disabled (set ASF_ADMIN_TOKEN)")
+        if not secrets.compare_digest(request.headers.get('authorization', ''), f"Bearer {self.admin_token}"):
+            raise HTTPException(status_code=401, detail="Invalid admin token")
+    
+    def _require_worker(self, request: Request):
+        """Jeton partagé avec le coordinateur (Authorization: Bearer) - mode worker désactivé sans ASF_WORKER_TOKEN"""
+        token = os.environ.get('ASF_WORKER_TOKEN')
+        if not token:
+            raise HTTPException(status_code=404, detail="Worker mode disabled (set ASF_WORKER_TOKEN)")
+        if not secrets.compare_digest(request.headers.get('authorization', ''), f"Bearer {token}"):
+            raise HTTPException(status_code=401, detail="Invalid worker token")
+    
+    def _setup_worker_routes(self, app: FastAPI):
+        """Mode worker : correction d'un lot de fichiers envoyé par un coordinateur"""
+        
+        @app.post(WorkerCoordinator.SHARD_PATH, tags=['workers'], include_in_schema=False)
+        async def fix_shard(request: Request, shard: dict):
+            self._require_worker(request)
+            if not all(key in shard for key in ('repo', 'commit', 'paths')):
+                raise HTTPException(status_code=400, detail="Shard requires 'repo', 'commit' and 'paths'")
+            new_run_id()
+            client = self.github_client(shard['repo'], shard.get('token'))
+            try:
+                with self.request_strategy(shard), \
+                        self.jobs.track('fix-shard', f"{shard['repo']}@{shard['commit'][:12]}", api_client_var.get()), \
+                        self.project_settings(None, shard.get('settings') or {}):
+                    selected = await self._select_github_entries(client, shard['commit'], shard.get('path', ''),
+                                                                 set(shard['paths']))
+                    results, changes = await self._fix_github_entries(client, selected)
+            except GitHubApiError as e:
+                raise HTTPException(status_code=502, detail=str(e))
+            return {'results': [result_v1(r) for r in results], 'changes': changes}
+    
+    def _setup_admin_routes(self, app: FastAPI):
+        """Administration : clés d'API, travaux en cours, quotas par niveau, échecs récents"""
+        tags = ['admin']
+        
+        @app.get("/api/admin/keys", tags=tags)
+        async def list_api_keys(request: Request):
+            self._require_admin(request)
+            return {"keys": self.api_keys.list(), "required": self.require_api_key}
+        
+        @app.post("/api/admin/keys", tags=tags)
+        async def create_api_key(request: Request, key_data: dict):
+            """Création d'une clé - sa valeur n'est affichée qu'une fois"""
+            self._require_admin(request)
+            name, tier = key_data.get('name'), key_data.get('tier', 'free')
+            if not name:
+                raise HTTPException(status_code=400, detail="Missing 'name'")
+            try:
+                key = self.api_keys.create(name, tier)
+            except ValueError as e:
+                raise HTTPException(status_code=400, detail=str(e))
+            except StorageConflictError:
+                raise HTTPException(status_code=409, detail=f"API key {name} already exists")
+            return {"name": name, "tier": tier, "key": key}
+        
+        @app.delete("/api/admin/keys/{name}", tags=tags)
+        async def revoke_api_key(request: Request, name: str):
+            self._require_admin(request)
+            if not self.api_keys.revoke(name):
+                raise HTTPException(status_code=404, detail=f"No active API key {name}")
+            return {"revoked": name}
+        
+        @app.get("/api/admin/jobs", tags=tags)
+        async def list_jobs(request: Request):
+            self._require_admin(request)
+            return self.jobs.snapshot()
+        
+        @app.get("/api/admin/jobs/history", tags=tags)
+        async def job_history(request: Request, limit: int = 100):
+            """Travaux terminés enregistrés en base"""
+            self._require_admin(request)
+            return {"jobs": self.jobs.history.recent(min(max(limit, 1), 1000)) if self.jobs.history else []}
+        
+        @app.get("/api/admin/tiers", tags=tags)
+        async def list_tiers(request: Request):
+            self._require_admin(request)
+            return {"tiers": self.api_keys.tiers()}
+        
+        @app.put("/api/admin/tiers/{tier}", tags=tags)
+        async def set_tier_quota(request: Request, tier: str, quota: dict):
+            self._require_admin(request)
+            try:
+                return {"tier": tier, "quota": self.api_keys.set_quota(tier, quota)}
+            except ValueError as e:
+                raise HTTPException(status_code=400, detail=str(e))
+        
+        @app.get("/api/admin/failures", tags=tags)
+        async def recent_failures(request: Request):
+            self._require_admin(request)
+            return {"failures": self.jobs.recent_failures()}
+    
+    def _setup_api_routes(self, app: FastAPI, prefix: str, version: str, deprecated: bool = False):
+        """Routes de l'API pour une version du schéma de réponse"""
+        serialize = result_v1 if version == 'v1' else result_v2
+        response_schema = 'FixResponse' if version == 'v1' else 'FixResponseV2'
+        tags = [version if not deprecated else 'deprecated']
+        
+        @app.post(f"{prefix}/fix-files", tags=tags, deprecated=deprecated,
+                  openapi_extra=json_operation(None, response_schema))
+        async def fix_files_endpoint(files: List[UploadFile] = File(...), strategy: Optional[str] = None,
+                                     include_content: bool = False):
             """API pour correction de fichiers uploadés"""
+            new_run_id()
             results = []
             
-            for file in files:
-                content = await file.read()
-                content_str = content.decode('utf-8')
-                
-                result = await self.fix_file_content(file.filename, content_str)
-                results.append(asdict(result))
+            # Espace de travail de la requête, supprimé même si le client abandonne
+            with self.request_strategy({'strategy': strategy}), \
+                    self.jobs.track('fix-files', ', '.join(f.filename for f in files), api_client_var.get()):
+                async with self.workspaces.allocate():
+                    for file in files:
+                        content = await file.read()
+                        if len(content) > self.MAX_FILE_SIZE:
+                            results.append(error_result(file.filename, FileTooLargeError(
+                                f"File exceeds {self.MAX_FILE_SIZE} bytes")))
+                            continue
+                        
+                        try:
+                            content_str = content.decode('utf-8')
+                        except UnicodeDecodeError as e:
+                            results.append(error_result(file.filename,
+                                                        ContentSniffer.decode_error(file.filename, content, e)))
+                            continue
+                        
+                        result = await self.fix_file_content(file.filename, content_str)
+                        results.append(result)
+            
+            return {"results": [serialize(r, include_content) for r in results], "stats": self.stats}
+        
+        @app.post(f"{prefix}/fix-archive", tags=tags, deprecated=deprecated, response_class=Response,
+                  responses=ZIP_RESPONSE)
+        async def fix_archive_endpoint(archive: UploadFile = File(...), strategy: Optional[str] = None):
+            """Projet envoyé en zip/tar.gz (sans accès git) : arbre corrigé et rapport renvoyés en zip"""
+            new_run_id()
+            data = await archive.read(ProjectArchive.MAX_UPLOAD_SIZE + 1)
+            with self.request_strategy({'strategy': strategy}), \
+                    self.jobs.track('fix-archive', archive.filename or 'archive', api_client_var.get()):
+                async with self.workspaces.allocate() as workspace:
+                    try:
+                        packed, report = await ProjectArchive.process(self, data, workspace.path)
+                    except ArchiveError as e:
+                        raise HTTPException(status_code=400, detail=str(e))
+                    except TransactionError as e:
+                        raise HTTPException(status_code=500, detail=str(e))
+            name = re.sub(r'(\.tar)?\.\w+$', '', Path(archive.filename or 'project').name) or 'project'
+            return Response(content=packed, media_type='application/zip', headers={
+                'Content-Disposition': f'attachment; filename="{name}-fixed.zip"',
+                'X-Asf-Fixes': str(report.get('summary', {}).get('total_fixes_applied', 0))
+            })
+        
+        @app.post(f"{prefix}/fix-snippet", tags=tags, deprecated=deprecated,
+                  openapi_extra=json_operation('SnippetRequest', 'SnippetResponse'))
+        async def fix_snippet_endpoint(snippet: dict):
+            """Correction d'un extrait collé (démo, évaluation via curl) - sans repository"""
+            new_run_id()
+            language, code = snippet.get('language'), snippet.get('code')
+            if not isinstance(code, str):
+                raise HTTPException(status_code=400, detail="Missing 'code' (string)")
+            if len(code.encode('utf-8')) > self.MAX_SNIPPET_SIZE:
+                raise HTTPException(status_code=413, detail=f"Snippet exceeds {self.MAX_SNIPPET_SIZE} bytes")
+            extension = self.language_detector.extension_for(language) if isinstance(language, str) else None
+            if extension is None:
+                raise HTTPException(status_code=400, detail=f"Unknown language: {language}")
             
-            return {"results": results, "stats": self.stats}
+            file_name = f"snippet{extension}"
+            with self.jobs.track('fix-snippet', file_name, api_client_var.get()):
+                async with self.workspaces.allocate():
+                    result = await self.fix_file_content(file_name, code)
+            fixed = result.fixed_content if result.fixed_content is not None else code
+            return {
+                "language": result.language,
+                "code": fixed,
+                "changed": fixed != code,
+                "diff": unified_diff(file_name, code, fixed) if fixed != code else '',
+                "rules": sorted({fix_rule_id(fix) for fix in result.fixes_applied} - set(result.fixes_applied)),
+                "fixes": result.fixes_applied,
+                "errors": result.original_errors
+            }
         
-        @app.post("/api/fix-repository")
+        @app.post(f"{prefix}/fix-repository", tags=tags, deprecated=deprecated,
+                  openapi_extra=json_operation('RepositoryRequest', response_schema))
         async def fix_repository_endpoint(repo_data: dict):
             """API pour correction d'un repository complet"""
-            repo_path = repo_data.get('path', '.')
-            results = await self.fix_repository(repo_path)
+            new_run_id()
+            results = await self.run_repository_request(repo_data)
             
             return {
-                "results": [asdict(r) for r in results],
+                "results": [serialize(r, bool(repo_data.get('include_content'))) for r in results],
+                "stats": self.stats
+            }
+        
+        @app.post(f"{prefix}/fix-github", tags=tags, deprecated=deprecated,
+                  openapi_extra=json_operation('GitHubRequest', response_schema))
+        async def fix_github_endpoint(repo_data: dict):
+            """API pour correction d'un repository GitHub sans clone (API Contents/Trees)"""
+            new_run_id()
+            results, commit_sha = await self.run_github_request(repo_data)
+            
+            response = {
+                "results": [serialize(r, bool(repo_data.get('include_content'))) for r in results],
+                "commit": commit_sha,
+                "cache": cache_status_var.get(),
                 "stats": self.stats
             }
+            if repo_data.get('propose') and commit_sha:
+                response["proposal"] = self.proposals.get(run_id_var.get())
+            if fallback_pr_var.get() is not None:
+                response["pull_request"] = fallback_pr_var.get()
+            return response
+        
+        @app.post(f"{prefix}/jobs", tags=tags, deprecated=deprecated, status_code=202,
+                  openapi_extra=json_operation('JobRequest', 'Job'))
+        async def enqueue_job(job_data: dict):
+            """Correction asynchrone : mise en file, résultat via GET /jobs/{id}"""
+            kind = job_data.get('kind')
+            if kind not in self.JOB_KINDS:
+                raise HTTPException(status_code=400, detail=f"'kind' must be one of: {', '.join(self.JOB_KINDS)}")
+            depth = self.concurrency.queue_depth
+            if depth and await self.job_queue.depth() >= depth:
+                raise HTTPException(status_code=503, detail=f"Job queue full ({depth} pending jobs)",
+                                    headers={'Retry-After': '30'})
+            job = {'id': uuid.uuid4().hex[:12], 'kind': kind, 'request': job_data.get('request') or {},
+                   'client': api_client_var.get()}
+            await self.job_queue.put(job)
+            return {"id": job['id'], "status": "queued"}
+        
+        @app.get(f"{prefix}/jobs/{{job_id}}", tags=tags, deprecated=deprecated)
+        async def get_job(job_id: str):
+            payload = self.job_queue.result(job_id)
+            if payload is None:
+                raise HTTPException(status_code=404, detail=f"Unknown or expired job {job_id}")
+            if 'results' in payload:
+                payload = {**payload, 'results': [serialize(FixResult(**r), 'fixed_content' in r)
+                                                  for r in payload['results']]}
+            return payload
         
-        @app.get("/api/stats")
+        @app.post(f"{prefix}/health-score", tags=tags, deprecated=deprecated,
+                  openapi_extra=json_operation('RepositoryRequest', 'HealthScore'))
+        async def health_score_endpoint(repo_data: dict):
+            """Score de santé 0-100 d'un repository"""
+            new_run_id()
+            repo_path = repo_data.get('path', '.')
+            with self.jobs.track('health-score', repo_path, api_client_var.get()):
+                results = await self.fix_repository(repo_path)
+            self.record_analysis(github_slug(Path(repo_path)), results, Path(repo_path))
+            return HealthScore.compute(results, Path(repo_path))
+        
+        @app.post(f"{prefix}/language-tree", tags=tags, deprecated=deprecated,
+                  openapi_extra=json_operation('RepositoryRequest', 'LanguageTree'))
+        async def language_tree_endpoint(repo_data: dict):
+            """Répartition des langages par répertoire"""
+            repo_path = Path(repo_data.get('path', '.'))
+            if not repo_path.is_dir():
+                raise HTTPException(status_code=404, detail=f"Repository path does not exist: {repo_path}")
+            walk = WalkPolicy.from_config(ConfigLayers(repo_path).resolve()[0].get('walk'))
+            return await asyncio.to_thread(self.language_detector.language_tree, repo_path, walk)
+        
+        @app.get(f"{prefix}/stats", tags=tags, deprecated=deprecated)
         async def get_stats():
-            return self.stats
+            return {**self.stats, 'remote': self.remote_stats()}
         
-        @app.websocket("/ws")
-        async def websocket_endpoint(websocket: WebSocket):
-            """WebSocket pour updates en temps réel"""
-            await websocket.accept()
+        @app.get(f"{prefix}/proposals", tags=tags, deprecated=deprecated)
+        async def list_proposals(request: Request, status: Optional[str] = None):
+            """Corrections poussées sur une branche de quarantaine (filtre : proposed, approved, merged)"""
+            self._require_admin(request)
+            return {"proposals": self.proposals.list(status)}
+        
+        @app.post(f"{prefix}/proposals/{{proposal_id}}/approve", tags=tags, deprecated=deprecated,
+                  openapi_extra=json_operation('ProposalApproval', 'Proposal'))
+        async def approve_proposal(request: Request, proposal_id: str, approval: dict):
+            """Seconde phase : rebase sur la branche cible, ouverture de la PR, fusion optionnelle (jeton d'administration)"""
+            self._require_admin(request)
+            # Approbateur : client de la clé d'API, sinon nom déclaré par l'administrateur
+            approver = api_client_var.get() or str(approval.get('approver') or '').strip() or None
             try:
-                while True:
-                    await websocket.send_json(self.stats)
-                    await asyncio.sleep(1)
-            except WebSocketDisconnect:
-                pass
+                return await self.approve_proposal(
+                    proposal_id, bool(approval.get('merge', False)), approval.get('merge_method', 'squash'),
+                    approval.get('token'), approver)
+            except ProposalError as e:
+                raise HTTPException(status_code=e.status, detail=str(e))
+            except GitHubApiError as e:
+                raise HTTPException(status_code=502, detail=str(e))
+        
+        @app.get(f"{prefix}/projects", tags=tags, deprecated=deprecated)
+        async def list_projects():
+            """Projets enregistrés et leur configuration"""
+            return {"projects": self.projects.list()}
+        
+        @app.get(f"{prefix}/projects/{{owner}}/{{repo}}", tags=tags, deprecated=deprecated)
+        async def get_project(owner: str, repo: str):
+            config = self.projects.get(f"{owner}/{repo}")
+            if config is None:
+                raise HTTPException(status_code=404, detail=f"Unknown project {owner}/{repo}")
+            return {"project": ProjectStore.key(f"{owner}/{repo}"), "config": config}
+        
+        @app.put(f"{prefix}/projects/{{owner}}/{{repo}}", tags=tags, deprecated=deprecated,
+                 openapi_extra=json_operation('ProjectConfig', 'ProjectConfig'))
+        async def put_project(request: Request, owner: str, repo: str, config: dict):
+            """Enregistrement ou remplacement de la configuration d'un projet (jeton d'administration)"""
+            self._require_admin(request)
+            try:
+                return self.projects.put(f"{owner}/{repo}", config)
+            except ProjectConfigError as e:
+                raise HTTPException(status_code=400, detail=str(e))
+        
+        @app.delete(f"{prefix}/projects/{{owner}}/{{repo}}", tags=tags, deprecated=deprecated)
+        async def delete_project(request: Request, owner: str, repo: str):
+            self._require_admin(request)
+            if not self.projects.delete(f"{owner}/{repo}"):
+                raise HTTPException(status_code=404, detail=f"Unknown project {owner}/{repo}")
+            return {"deleted": ProjectStore.key(f"{owner}/{repo}")}
     
     def _generate_web_interface(self) -> str:
         """Génération de l'interface web moderne"""
@@ -675,7 +8495,7 @@ Upload files or run demo to see the magic happen!
                 }
                 
                 try {
-                    const response = await fetch('/api/fix-files', {
+                    const response = await fetch('/api/v1/fix-files', {
                         method: 'POST',
                         body: formData
                     });
@@ -719,17 +8539,28 @@ Upload files or run demo to see the magic happen!
         }
         
         // Fonctions globales
-        function testDemo() {
-            client.log('\n🧪 Running demo test...');
-            
-            // Simulation d'un test de démonstration
-            setTimeout(() => {
-                client.log('✅ Demo file processed successfully');
-                client.log('   - Fixed 3 Python indentation errors');
-                client.log('   - Fixed 2 missing colons');
-                client.log('   - Processing time: 0.125s');
-                client.log('   - Tool used: ILN_Auto_Syntax_Fixer');
-            }, 1000);
+        async function testDemo() {
+            client.log('\\n🧪 Running demo test...');
+            
+            // Extrait de démonstration corrigé par le serveur
+            const code = 'def greet(name)\\n    print("Hello " + name)  \\n';
+            try {
+                const response = await fetch('/api/v1/fix-snippet', {
+                    method: 'POST',
+                    headers: {'Content-Type': 'application/json'},
+                    body: JSON.stringify({language: 'python', code})
+                });
+                const data = await response.json();
+                if (!response.ok) {
+                    client.log(`❌ Error: ${data.detail}`);
+                    return;
+                }
+                client.log(`✅ Demo snippet processed: ${data.fixes.length} fixes`);
+                data.fixes.forEach(fix => client.log(`   - ${fix}`));
+                if (data.diff) client.log(data.diff);
+            } catch (error) {
+                client.log(`❌ Error: ${error.message}`);
+            }
         }
         
         function clearResults() {
@@ -745,14 +8576,90 @@ Upload files or run demo to see the magic happen!
 </body>
 </html>'''
     
-    async def fix_file_content(self, file_path: str, content: str) -> FixResult:
+    async def fix_file_content(self, file_path: str, content: str, record_stats: bool = True,
+                               hygiene: bool = True) -> FixResult:
+        """Correction intelligente d'un fichier, puis hygiène du texte"""
+        started = time.perf_counter()
+        with tracing.span('file.fix', file=file_path, bytes=len(content.encode('utf-8'))) as span:
+            result = await self._fix_file_content(file_path, content, record_stats)
+            rules = sorted({fix_rule_id(fix) for fix in result.fixes_applied} - set(result.fixes_applied))
+            tracing.annotate(span, language=result.language, tool=result.tool_used, error_code=result.error_code,
+                             fixes=len(result.fixes_applied), rules=rules)
+        if result.error_code is None:
+            strategy = 'remote' if result.tool_used.startswith('remote:') else 'local'
+            for policy in [self.strategy_policy, *self.strategy_policies.values()]:
+                policy.observe(strategy, result.language, time.perf_counter() - started)
+        if (not hygiene or self.hygiene is None or result.error_code is not None
+                or result.language in ('text', 'unknown') or result.tool_used.startswith('project:')
+                or not getattr(self.registry.for_language(result.language), 'hygiene', True)):
+            return result
+        
+        base = result.fixed_content if result.fixed_content is not None else content
+        cleaned, fixes = self.hygiene.clean(file_path, base)
+        if fixes:
+            result.fixed_content = cleaned
+            result.fixes_applied.extend(fixes)
+            result.success = True
+        return result
+    
+    async def _fix_file_content(self, file_path: str, content: str, record_stats: bool = True) -> FixResult:
         """Correction intelligente d'un fichier"""
         start_time = time.time()
         
         # Détection du langage
         language = self.language_detector.detect_language(file_path, content)
         
+        # Contenu contredisant l'extension : jamais transformé comme du texte
+        kind = ContentSniffer.sniff_text(content, language)
+        if kind is not None:
+            logger.warning("content mismatch: %s", kind, extra={'file_path': file_path})
+            result = error_result(file_path, ContentSniffer.mismatch(file_path, kind), language)
+            result.processing_time = time.time() - start_time
+            return result
+        
+        if language != 'unknown' and not self.language_enabled(language):
+            result = error_result(file_path, LanguageExcludedError(f"Language {language} excluded from this run"),
+                                  language)
+            result.processing_time = time.time() - start_time
+            return result
+        
+        # Sortie de la commande de formatage du projet, à la place des correcteurs intégrés
+        formatted = self.project_formatted.get(file_path)
+        if formatted is not None:
+            fixes = ["Applied project_format"] if formatted != content else []
+            return FixResult(
+                file_path=file_path,
+                original_errors=[],
+                fixes_applied=fixes,
+                success=True,
+                language=language,
+                processing_time=time.time() - start_time,
+                tool_used=f"project:{self.project_format_command}",
+                fixed_content=formatted
+            )
+        
+        # Stratégies selon la politique : service distant (repli si échec), outils locaux ou patterns seuls
+        remote = self.remote_formatters.get(language) if not self.offline else None
+        patterns_only = False
+        for strategy in self.strategy_order(language, len(content), remote is not None):
+            if strategy == 'remote':
+                result = await self._fix_with_fixer(remote, language, file_path, content, start_time,
+                                                    f"remote:{remote.name}", fallback=True)
+                if result is not None:
+                    return result
+            else:
+                patterns_only = strategy == 'pattern'
+                break
+        
+        # Correcteur enregistré pour le langage (plugin ou natif)
+        registered = self.registry.for_language(language)
+        if registered is not None:
+            tool_used = (f"plugin:{registered.name}" if isinstance(registered, ExecPluginFixer)
+                         else "ILN_Level3_internal")
+            return await self._fix_with_fixer(registered, language, file_path, content, start_time, tool_used)
+        
         if language == 'unknown':
+            logger.info("skipped: unknown file type", extra={'file_path': file_path})
             return FixResult(
                 file_path=file_path,
                 original_errors=["Unknown file type"],
@@ -762,10 +8669,34 @@ Upload files or run demo to see the magic happen!
                 processing_time=time.time() - start_time
             )
         
+        # Caractères Unicode parasites, avant les outils qui les rejetteraient
+        content, unicode_fixes = self.unicode_cleaner.clean(content, language)
+        content, import_fixes, import_conflicts = self.import_merger.merge(content, language)
+        unicode_fixes.extend(import_fixes)
+        if (language == 'javascript' and self.module_style_converter is not None
+                and self.syntax_analyzer.rule_enabled('module_style')):
+            content, module_fixes = self.module_style_converter.convert(file_path, content)
+            unicode_fixes.extend(module_fixes)
+        
+        # ESLint avec la configuration du projet, avant les heuristiques internes
+        eslint_outcome = None if patterns_only else await self.eslint.run(
+            file_path, content, language, self.allow_repo_exec)
+        if eslint_outcome is not None and eslint_outcome.success:
+            content = eslint_outcome.content
+        
         # Analyse et correction intelligente
         analysis_result, corrected_content = await self.syntax_analyzer.analyze_syntax_errors(
             content, language
         )
+        custom_errors, custom_fixes, corrected_content = self.syntax_analyzer.apply_custom_rules(
+            corrected_content, language, file_path
+        )
+        if language == 'go' and self.go_struct_fixer.enabled:
+            corrected_content, struct_fixes = self.go_struct_fixer.fix(corrected_content)
+            custom_fixes.extend(struct_fixes)
+        if language == 'go' and self.go_error_fixer.enabled:
+            corrected_content, error_fixes = self.go_error_fixer.fix(corrected_content)
+            custom_fixes.extend(error_fixes)
         
         # Tentative avec Shell Champion si outils disponibles
         tool_mapping = {
@@ -779,11 +8710,21 @@ Upload files or run demo to see the magic happen!
             'java': []         # Internal patterns only
         }
         
-        tools_for_lang = tool_mapping.get(language, [])
+        tools_for_lang = [tool for tool in tool_mapping.get(language, [])
+                          if self.syntax_analyzer.rule_enabled(tool) and not patterns_only]
         shell_success = False
         shell_errors = []
         final_content = corrected_content
         
+        # Ni pattern interne ni outil externe : fichier non traité
+        has_tool = any(self.shell_champion.available_tools.get(tool, False) for tool in tools_for_lang)
+        if (language not in self.syntax_analyzer.fix_patterns and eslint_outcome is None and
+                not self.syntax_analyzer.has_custom_rules(language) and not has_tool and not unicode_fixes):
+            logger.info("no fixer available", extra={'file_path': file_path, 'language': language})
+            result = error_result(file_path, ToolMissingError(f"No fixer available for {language}"), language)
+            result.processing_time = time.time() - start_time
+            return result
+        
         # Essayer les outils via Shell Champion
         for tool in tools_for_lang:
             success, tool_corrected, errors = await self.shell_champion.execute_tool(
@@ -797,26 +8738,136 @@ Upload files or run demo to see the magic happen!
             else:
                 shell_errors.extend(errors)
         
+        # Chaîne secondaire (autoflake, pyupgrade) - changements attribués par outil
+        secondary_fixes = []
+        for tool in ([] if patterns_only else self.secondary_tools.get(language, [])):
+            if not self.syntax_analyzer.rule_enabled(tool):
+                continue
+            if not self.shell_champion.available_tools.get(tool, False):
+                logger.debug("secondary tool not available", extra={'tool': tool, 'file_path': file_path})
+                continue
+            
+            success, tool_corrected, errors = await self.shell_champion.execute_tool(
+                tool, file_path, final_content
+            )
+            if not success:
+                shell_errors.extend(errors)
+            elif tool_corrected != final_content:
+                final_content = tool_corrected
+                secondary_fixes.append(f"Applied {tool}")
+        
         # Combinaison des résultats
         all_errors = analysis_result[:len(analysis_result)//2] if analysis_result else []
         all_fixes = analysis_result[len(analysis_result)//2:] if analysis_result else []
+        if eslint_outcome is not None:
+            all_errors = eslint_outcome.errors + all_errors
+            all_fixes = eslint_outcome.fixes + all_fixes
+        all_fixes = unicode_fixes + all_fixes
+        all_errors.extend(import_conflicts)
+        all_errors.extend(custom_errors)
+        all_fixes.extend(custom_fixes)
         
         if shell_errors:
             all_errors.extend(shell_errors)
         
         if shell_success:
             all_fixes.append(f"Applied external tool formatting")
+        all_fixes.extend(secondary_fixes)
+        
+        used_shell = shell_success or bool(secondary_fixes) or bool(eslint_outcome and eslint_outcome.fixes)
         
         # Mise à jour des statistiques
+        processing_time = time.time() - start_time
+        if record_stats:
+            self._update_stats(len(all_fixes), processing_time)
+        
+        return FixResult(
+            file_path=file_path,
+            original_errors=all_errors,
+            fixes_applied=all_fixes,
+            success=len(all_fixes) > 0 or len(all_errors) == 0,
+            language=language,
+            processing_time=processing_time,
+            tool_used=f"ILN_Level3_{'with_shell' if used_shell else 'internal'}",
+            fixed_content=final_content
+        )
+    
+    def dedup_key(self, file_path: str, content: str) -> Optional[Tuple]:
+        """Clé de déduplication : contenu et tout ce qui, dans le chemin, influence la correction
+        
+        None si le fichier doit être corrigé seul (sortie de la commande de formatage du projet).
+        """
+        if file_path in self.project_formatted:
+            return None
+        language = self.language_detector.detect_language(file_path, content)
+        rules = tuple(rule.id for rule in self.syntax_analyzer.custom_rules if rule.applies_to(language, file_path))
+        properties = tuple(sorted(self.hygiene.editorconfig.properties(file_path).items())) if self.hygiene else ()
+        module_style = (self.module_style_converter.target_style(file_path)
+                        if language == 'javascript' and self.module_style_converter is not None else None)
+        eslint_config = (self.eslint.find_config(file_path, self.allow_repo_exec)
+                         if language in ESLintRunner.LANGUAGES else None)
+        return (hashlib.sha256(content.encode()).hexdigest(), Path(file_path).name, language,
+                rules, properties, module_style, eslint_config)
+    
+    def record_analysis(self, repo: Optional[str], results: List[FixResult], root: Optional[Path] = None):
+        """Mémorisation de la dernière analyse d'un repository GitHub (badges syntax et health)"""
+        if not repo:
+            return
+        self.latest_analyses.put(repo.lower(), {
+            'issues': sum(len(r.original_errors) for r in results if r.error_code is None),
+            'health': HealthScore.compute(results, root)['score'],
+            'timestamp': datetime.now().isoformat(timespec='seconds')
+        })
+    
+    def streamable(self, file_path: str) -> bool:
+        """Fichier traitable en flux (patterns ligne à ligne du langage, sans correcteur de fichier complet)"""
+        language = self.language_detector.detect_language(file_path)
+        return (language in self.syntax_analyzer.fix_patterns and self.registry.for_language(language) is None
+                and self.project_formatted.get(file_path) is None)
+    
+    async def fix_file_stream(self, file_path: str) -> FixResult:
+        """Correction en flux d'un gros fichier : patterns ligne à ligne, sans outils externes"""
+        start_time = time.time()
+        language = self.language_detector.detect_language(file_path)
+        out = io.StringIO()
+        
+        def source() -> TextIO:
+            return filesystem_var.get().open_text(file_path)
+        
+        try:
+            analysis = await asyncio.to_thread(self.syntax_analyzer.analyze_stream, source, language, out)
+        except UnicodeDecodeError as e:
+            return error_result(file_path, ContentSniffer.decode_error(file_path, read_head(file_path), e), language)
+        except IOError as e:
+            return error_result(file_path, ReadFailedError(f"Cannot read file: {e}"), language)
+        
+        errors = [entry for entry in analysis if entry.startswith('Line ')]
+        fixes = [entry for entry in analysis if not entry.startswith('Line ')]
+        processing_time = time.time() - start_time
+        self._update_stats(len(fixes), processing_time)
+        logger.info("streamed large file", extra={'file_path': file_path, 'language': language})
+        
+        return FixResult(
+            file_path=file_path,
+            original_errors=errors,
+            fixes_applied=fixes,
+            success=len(fixes) > 0 or len(errors) == 0,
+            language=language,
+            processing_time=processing_time,
+            tool_used="ILN_Level3_stream",
+            fixed_content=out.getvalue()
+        )
+    
+    def _update_stats(self, fix_count: int, processing_time: float):
+        """Mise à jour des statistiques globales"""
         self.stats['files_processed'] += 1
-        self.stats['total_fixes'] += len(all_fixes)
+        self.stats['total_fixes'] += fix_count
         
         # Calcul du taux de succès
         if self.stats['files_processed'] > 0:
             self.stats['success_rate'] = (self.stats['total_fixes'] / self.stats['files_processed']) * 100
         
         # Temps de traitement moyen
-        processing_time = time.time() - start_time
         if self.stats['files_processed'] == 1:
             self.stats['avg_processing_time'] = processing_time * 1000
         else:
@@ -824,19 +8875,369 @@ Upload files or run demo to see the magic happen!
                 (self.stats['avg_processing_time'] * (self.stats['files_processed'] - 1) + 
                  processing_time * 1000) / self.stats['files_processed']
             )
+    
+    async def _fix_with_fixer(self, fixer: Fixer, language: str, file_path: str, content: str,
+                              start_time: float, tool_used: str, fallback: bool = False) -> Optional[FixResult]:
+        """Correction déléguée à un correcteur de fichier complet (plugin, natif ou distant)
+        
+        Avec `fallback`, None en cas d'échec : le traitement local prend le relais.
+        """
+        try:
+            outcome = await fixer.fix(file_path, content)
+        except FixerError as e:
+            if fallback:
+                logger.debug("%s - local processing", e, extra={'tool': fixer.name, 'file_path': file_path})
+                return None
+            logger.warning("%s", e, extra={'tool': fixer.name, 'file_path': file_path})
+            result = error_result(file_path, e, language)
+            result.processing_time = time.time() - start_time
+            return result
+        
+        custom_errors, custom_fixes, fixed_content = self.syntax_analyzer.apply_custom_rules(
+            outcome.content, language, file_path
+        )
+        fixes = outcome.fixes + custom_fixes
+        
+        processing_time = time.time() - start_time
+        self._update_stats(len(fixes), processing_time)
         
         return FixResult(
             file_path=file_path,
-            original_errors=all_errors,
-            fixes_applied=all_fixes,
-            success=len(all_fixes) > 0 or len(all_errors) == 0,
+            original_errors=outcome.errors + custom_errors,
+            fixes_applied=fixes,
+            success=outcome.success,
             language=language,
             processing_time=processing_time,
-            tool_used=f"ILN_Level3_{'with_shell' if shell_success else 'internal'}"
+            tool_used=tool_used,
+            fixed_content=fixed_content
+        )
+    
+    def configure(self, config: Dict[str, Any]):
+        """Application de la configuration du repository
+        
+        Les réglages sont écrits dans l'état de l'exécution en cours (RunScoped), repris des valeurs
+        de base de l'instance : un travail concurrent qui reconfigure ne les modifie pas.
+        """
+        config = {**config, **server_config_var.get()}
+        # Réinitialisation : la configuration est propre à chaque repository
+        run_state_var.set((self, {}))
+        self.registry = self.registry.copy()
+        self.plugins = {}
+        self.syntax_analyzer = copy.copy(self.syntax_analyzer)
+        self.syntax_analyzer.custom_rules = []
+        self.language_detector = LanguageDetector()
+        
+        self.syntax_analyzer.select_rules(self.cli_rules or config.get('enabled_rules'))
+        cli_include, cli_exclude = self.cli_language_selection
+        include = cli_include or config.get('languages') or []
+        self.enabled_languages = set(include) if include else None
+        self.excluded_languages = set(cli_exclude or config.get('exclude_languages') or [])
+        self.fail_policy = {**(config.get('fail_on', {}) or {}), **self.cli_fail_policy}
+        self.baseline_file = self.cli_baseline_file or config.get('baseline')
+        self.history_enabled = self.cli_history and config.get('history', True) is not False
+        self.fix_log_path = self.cli_fix_log or FixLog.path_from_config(config.get('fix_log'))
+        self.commit_trailers = self.cli_commit_trailers and config.get('commit_trailers', True) is not False
+        self.commit_status = self.cli_commit_status or bool(config.get('commit_status', False))
+        ignore_revs = config.get('blame_ignore_revs', True)
+        self.blame_ignore_revs = (None if not self.cli_blame_ignore_revs or ignore_revs is False else
+                                  int(ignore_revs.get('min_files', self.BLAME_IGNORE_MIN_FILES))
+                                  if isinstance(ignore_revs, dict) else self.BLAME_IGNORE_MIN_FILES)
+        blame = config.get('blame') or {}
+        self.blame_filter = BlameFilter.from_config({**blame, **self.cli_blame} if isinstance(blame, dict) else blame)
+        self.walk = WalkPolicy.from_config(config.get('walk'))
+        self.quarantine_enabled = self.cli_quarantine and config.get('quarantine', True) is not False
+        self.verifier = TestVerifier.from_config(config.get('verify') or self.cli_verify)
+        self.compile_checker = CompileChecker() if (config.get('compile_check') or self.cli_compile_check) else None
+        self.project_formatter = ProjectFormatter.from_config(config.get('project_format') or self.cli_project_format)
+        
+        tools_options = config.get('tools', {}) or {}
+        self.secondary_tools = dict(self.DEFAULT_SECONDARY_TOOLS)
+        for language, options in tools_options.items():
+            if isinstance(options, dict) and 'secondary' in options:
+                self.secondary_tools[language] = list(options['secondary'] or [])
+        
+        codefixes = (config.get('typescript', {}) or {}).get('codefixes', False)
+        self.typescript_fixer = TypeScriptCodeFixer(
+            self.shell_champion, TypeScriptCodeFixer.DEFAULT_FIXES if codefixes is True else list(codefixes or [])
+        )
+        
+        self._register_builtin_fixers(config)
+        
+        module_style = (config.get('javascript', {}) or {}).get('module_style')
+        self.module_style_converter = (ModuleStyleConverter(module_style)
+                                       if module_style in ('auto', 'esm', 'commonjs') else None)
+        
+        go_options = config.get('go', {}) or {}
+        self.go_struct_fixer = GoStructTagFixer(
+            align=bool(go_options.get('align_struct_tags', False)),
+            sort=bool(go_options.get('sort_struct_tags', False))
         )
+        self.go_error_fixer = GoErrorCheckFixer(bool(go_options.get('check_dropped_errors', False)))
+        
+        for entry in config.get('plugins', []) or []:
+            try:
+                plugin = ExecPluginFixer.from_config(entry)
+            except (KeyError, TypeError, AttributeError, ValueError) as e:
+                logger.error("invalid plugin entry %r: %s", entry, e)
+                continue
+            
+            self.plugins[plugin.language] = plugin
+            self.registry.register(plugin.language, plugin)
+            for extension in plugin.extensions:
+                self.language_detector.extension_map[extension] = plugin.language
+            logger.info("plugin registered", extra={'tool': plugin.name, 'language': plugin.language})
+        
+        for entry in config.get('rules', []) or []:
+            try:
+                rule = CustomRule.from_config(entry)
+            except (KeyError, TypeError, AttributeError, ValueError, re.error) as e:
+                logger.error("invalid rule entry %r: %s", entry, e)
+                continue
+            
+            self.syntax_analyzer.custom_rules.append(rule)
+            logger.info("custom rule registered", extra={'rule_id': rule.id, 'language': rule.language})
+        
+        for entry in config.get('wasm_rules', []) or []:
+            try:
+                rule = WasmRule.from_config(entry)
+            except (KeyError, TypeError, AttributeError, ValueError) as e:
+                logger.error("invalid WASM rule entry %r: %s", entry, e)
+                continue
+            
+            self.syntax_analyzer.custom_rules.append(rule)
+            logger.info("WASM rule registered", extra={'rule_id': rule.id, 'language': rule.language})
+    
+    def set_fail_policy(self, max_files_changed: Optional[int] = None, aggressive: bool = False):
+        """Seuils d'échec depuis la CLI"""
+        policy = {}
+        if max_files_changed is not None:
+            policy['max_files_changed'] = max_files_changed
+        if aggressive:
+            policy['aggressive'] = True
+        self.cli_fail_policy = policy
+        self.fail_policy = dict(policy)
+    
+    def cli_overrides(self, config: Dict[str, Any]) -> Dict[str, Any]:
+        """Réglages CLI exprimés comme une couche de configuration (affichage de la configuration résolue)"""
+        include, exclude = self.cli_language_selection
+        overrides = {
+            'enabled_rules': self.cli_rules,
+            'languages': include,
+            'exclude_languages': exclude,
+            'fail_on': self.cli_fail_policy,
+            'baseline': self.cli_baseline_file,
+            'fix_log': self.cli_fix_log,
+            'blame': self.cli_blame,
+            'history': False if not self.cli_history else None,
+            'commit_trailers': False if not self.cli_commit_trailers else None,
+            'blame_ignore_revs': False if not self.cli_blame_ignore_revs else None,
+            'quarantine': False if not self.cli_quarantine else None,
+            'commit_status': True if self.cli_commit_status else None,
+        }
+        # Options opt-in : la section de la configuration garde la priorité (commande, timeout)
+        for key in ('verify', 'compile_check', 'project_format'):
+            if getattr(self, f"cli_{key}") and not config.get(key):
+                overrides[key] = True
+        return {key: value for key, value in overrides.items() if value not in (None, [], {})}
+    
+    def threshold_violations(self, results: List[FixResult]) -> List[str]:
+        """Seuils de la politique d'échec dépassés par l'exécution"""
+        violations = []
+        changed = [r for r in results if r.fixes_applied]
+        
+        max_files = self.fail_policy.get('max_files_changed')
+        if max_files is not None and len(changed) > int(max_files):
+            violations.append(f"{len(changed)} files changed (max {max_files})")
+        
+        if self.fail_policy.get('aggressive'):
+            aggressive = [r for r in changed
+                          if any(fix_severity(fix) == 'aggressive' for fix in r.fixes_applied)]
+            if aggressive:
+                violations.append(f"aggressive fixes applied in {len(aggressive)} files")
+        return violations
+    
+    def _register_builtin_fixers(self, config: Dict[str, Any]):
+        """Enregistrement des correcteurs natifs avec leurs options de configuration"""
+        html_options = config.get('html', {}) or {}
+        graphql_options = config.get('graphql', {}) or {}
+        cmake_options = config.get('cmake', {}) or {}
+        maven_options = config.get('maven', {}) or {}
+        xml_options = config.get('xml', {}) or {}
+        dart_options = config.get('dart', {}) or {}
+        
+        self.registry.register('html', HtmlFixer(indent=int(html_options.get('indent', 2))))
+        self.registry.register('protobuf', ProtoFixer(self.shell_champion))
+        self.registry.register('starlark', StarlarkFixer(self.shell_champion))
+        self.registry.register('makefile', MakefileFixer())
+        self.registry.register('cmake', CMakeFixer(self.shell_champion, indent=int(cmake_options.get('indent', 2))))
+        self.registry.register('graphql', GraphQLFixer(
+            self.shell_champion,
+            indent=int(graphql_options.get('indent', 2)),
+            sort_fields=bool(graphql_options.get('sort_fields', False))
+        ))
+        self.registry.register('jupyter', NotebookFixer(
+            lambda path, source: self.fix_file_content(path, source, record_stats=False, hygiene=False)
+        ))
+        self.registry.register('manifest', ManifestFixer(
+            self.shell_champion,
+            pom_indent=int(maven_options['indent']) if maven_options.get('indent') else None,
+            sort_pom_dependencies=bool(maven_options.get('sort_dependencies', False))
+        ))
+        self.registry.register('gradle', GradleFixer(self.shell_champion))
+        self.registry.register('r', RFixer(self.shell_champion))
+        self.registry.register('julia', JuliaFixer(self.shell_champion))
+        self.registry.register('matlab', MatlabFixer(self.shell_champion))
+        self.registry.register('lua', LuaFixer(self.shell_champion))
+        self.registry.register('perl', PerlFixer(self.shell_champion))
+        self.registry.register('elixir', ElixirFixer(self.shell_champion))
+        self.registry.register('erlang', ErlangFixer(self.shell_champion))
+        self.registry.register('haskell', HaskellFixer(self.shell_champion))
+        self.registry.register('zig', ZigFixer(self.shell_champion))
+        self.registry.register('nim', NimFixer(self.shell_champion))
+        self.registry.register('scala', ScalaFixer(self.shell_champion))
+        self.registry.register('dart', DartFixer(self.shell_champion, apply_fixes=bool(dart_options.get('fix', False))))
+        self.registry.register('xml', XmlFixer(
+            indent=int(xml_options['indent']) if xml_options.get('indent') else None,
+            attribute_order=xml_options.get('attribute_order')
+        ))
+        
+        # Hygiène du texte : fichiers "text" et passe finale sur les autres langages
+        if config.get('hygiene', True) is not False:
+            self.hygiene = TextHygieneFixer(rule_enabled=self.syntax_analyzer.rule_enabled)
+            self.registry.register('text', self.hygiene)
+        else:
+            self.hygiene = None
+            self.registry.unregister('text')
+            self.excluded_languages.add('text')
+    
+    def select_languages(self, include: Optional[List[str]] = None, exclude: Optional[List[str]] = None):
+        """Sélection des langages depuis la CLI (prioritaire sur la configuration)"""
+        self.cli_language_selection = (include, exclude)
+        self.enabled_languages = set(include) if include else None
+        self.excluded_languages = set(exclude or [])
+    
+    @contextlib.contextmanager
+    def project_settings(self, name: Optional[str], config: Optional[Dict[str, Any]] = None):
+        """Configuration du projet enregistré (ou transmise par le coordinateur) appliquée le temps d'une requête"""
+        if config is None:
+            config = (self.projects.get(name) if name else None) or {}
+        token = server_config_var.set(config)
+        try:
+            yield config
+        finally:
+            server_config_var.reset(token)
+    
+    def strategy_order(self, language: str, size: int, remote_available: bool) -> List[str]:
+        """Stratégies à essayer : surcharge de la requête (stratégie ou politique), sinon politique du serveur"""
+        override = strategy_var.get()
+        if override in STRATEGIES:
+            order = [override, 'local'] if override == 'remote' else [override]
+        else:
+            policy = self.strategy_policy
+            if override and override != policy.name:
+                # Politique nommée, paramètres par défaut (instance conservée : latences apprises)
+                policy = self.strategy_policies.get(override)
+                if policy is None:
+                    policy = self.strategy_policies[override] = StrategyPolicy.registry()[override]()
+            order = policy.order(language, size, remote_available)
+        return [strategy for strategy in order if strategy != 'remote' or remote_available] or ['local']
+    
+    @contextlib.contextmanager
+    def strategy_override(self, value: Optional[str]):
+        """Stratégie ('remote', 'local', 'pattern') ou politique ('size', 'latency', 'cost') d'une requête"""
+        token = strategy_var.set(value)
+        try:
+            yield
+        finally:
+            strategy_var.reset(token)
+    
+    def remote_stats(self) -> Dict[str, Dict[str, Any]]:
+        """État des disjoncteurs des services distants, par langage"""
+        return {language: {'endpoint': remote.url, **remote.breaker.stats()}
+                for language, remote in self.remote_formatters.items()}
+    
+    def use_concurrency(self, profile: ConcurrencyProfile):
+        """Profil de parallélisme : fichiers, sous-processus, lectures et file de travaux"""
+        self.concurrency = profile
+        self.shell_champion.subprocess_limit = asyncio.Semaphore(profile.max_subprocesses)
+    
+    def enable_offline(self):
+        """Mode hors ligne pour le processus et les outils qu'il lance"""
+        self.offline = True
+        os.environ.update(OFFLINE_ENV)
+        if self.shell_champion.container is not None:
+            self.shell_champion.container.pull = 'never'
+    
+    def offline_path(self, language: str) -> Optional[str]:
+        """Correcteur local du langage, None s'il n'en existe aucun"""
+        registered = self.registry.for_language(language)
+        if registered is not None:
+            return f"plugin:{registered.name}" if isinstance(registered, ExecPluginFixer) else "built-in fixer"
+        if language in self.syntax_analyzer.fix_patterns:
+            return "built-in patterns"
+        if self.syntax_analyzer.has_custom_rules(language):
+            return "custom rules"
+        if language in ESLintRunner.LANGUAGES and self.shell_champion.available_tools.get('eslint', False):
+            return "local eslint"
+        return None
+    
+    def offline_gaps(self, files: List[Path]) -> Dict[str, int]:
+        """Langages sans correcteur local, avec leur nombre de fichiers"""
+        gaps: Dict[str, int] = {}
+        for file_path in files:
+            language = self.language_detector.detect_language(str(file_path))
+            if language != 'unknown' and self.offline_path(language) is None:
+                gaps[language] = gaps.get(language, 0) + 1
+        return gaps
+    
+    def select_rules(self, rule_ids: Optional[List[str]] = None):
+        """Sélection des règles depuis la CLI (prioritaire sur la configuration)"""
+        self.cli_rules = rule_ids
+        self.syntax_analyzer.select_rules(rule_ids)
+    
+    def language_enabled(self, language: str) -> bool:
+        """Le langage fait-il partie de l'exécution ?"""
+        if language in self.excluded_languages:
+            return False
+        return self.enabled_languages is None or language in self.enabled_languages
+    
+    def plugin_extensions(self) -> Set[str]:
+        """Extensions prises en charge par les plugins"""
+        return {ext for plugin in self.plugins.values() for ext in plugin.extensions}
+    
+    async def fix_repository(self, repo_path: str, use_baseline: bool = True) -> List[FixResult]:
+        """Correction intelligente d'un repository complet - chan!(concurrent)"""
+        if run_id_var.get() == '-':
+            new_run_id()
+        with tracing.span('repository.fix', repository=str(repo_path)) as span:
+            async with self.workspaces.allocate():
+                results = await self._fix_repository(repo_path, use_baseline)
+            tracing.annotate(span, files=len(results), fixes=sum(len(r.fixes_applied) for r in results))
+            return results
+    
+    async def fix_branches(self, repo_path: str, branches: List[str]) -> Dict[str, List[FixResult]]:
+        """Correction de plusieurs branches en parallèle, chacune dans un worktree du même dépôt"""
+        semaphore = asyncio.Semaphore(self.BRANCH_CONCURRENCY)
+        
+        async def fix_branch(branch: str) -> List[FixResult]:
+            async with semaphore:
+                new_run_id()
+                try:
+                    async with self.workspaces.worktree(Path(repo_path), branch) as path:
+                        results = await self.fix_repository(str(path))
+                except WorktreeError as e:
+                    logger.error("%s", e, extra={'file_path': str(repo_path)})
+                    return [error_result(str(repo_path), e)]
+                # Chemins rapportés au dépôt d'origine (le worktree est supprimé)
+                for result in results:
+                    result.file_path = str(Path(repo_path) / os.path.relpath(result.file_path, path))
+                return results
+        
+        results = await asyncio.gather(*(fix_branch(branch) for branch in branches))
+        return dict(zip(branches, results))
     
-    async def fix_repository(self, repo_path: str) -> List[FixResult]:
-        """Correction intelligente d'un repository complet - chan!(concurrent)"""
+    async def _fix_repository(self, repo_path: str, use_baseline: bool) -> List[FixResult]:
+        """Traitement du repository dans l'espace de travail de l'exécution"""
         repo_path = Path(repo_path)
         if not repo_path.exists():
             return [FixResult(
@@ -848,18 +9249,48 @@ Upload files or run demo to see the magic happen!
                 processing_time=0.0
             )]
         
-        # Découverte des fichiers
-        supported_extensions = {'.py', '.js', '.jsx', '.ts', '.tsx', '.go', '.rs', '.java', 
-                              '.cpp', '.cc', '.cxx', '.c', '.h'}
+        # Configuration effective : défauts, repository, utilisateur, ASF_* (la CLI s'applique dans configure)
+        self.configure(ConfigLayers(repo_path, allow_repo_exec=self.allow_repo_exec).resolve()[0])
+        
+        # Découverte des fichiers - un seul parcours partagé par exécution
+        with tracing.span('repository.walk', repository=str(repo_path)) as span:
+            index = RepositoryIndex.build(repo_path, self.concurrency.max_open_files, walk=self.walk)
+            tracing.annotate(span, files=len(index.files))
+        supported = [f for f in index.supported_files(self.plugin_extensions(), self.include_paths)
+                     if self.language_enabled(self.language_detector.detect_language(str(f.path)))]
+        regions = None
+        if self.diff_base:
+            try:
+                regions = DiffRegions.from_git(repo_path, self.diff_base)
+            except FixerError as e:
+                logger.error("%s", e, extra={'file_path': str(repo_path)})
+                return [error_result(str(repo_path), e)]
+            supported = [f for f in supported if regions.touched(str(f.path))]
+        
+        # Fichiers en quarantaine : ignorés, signalés dans le rapport
+        self.last_quarantine = None
+        quarantine = None
+        if self.quarantine_enabled:
+            quarantine = Quarantine.load(repo_path / Quarantine.DEFAULT_PATH)
+            skipped = [f for f in supported if quarantine.contains(str(f.path), repo_path)]
+            if skipped:
+                logger.warning("skipping %d quarantined files", len(skipped), extra={'file_path': str(repo_path)})
+                supported = [f for f in supported if f not in skipped]
+            self.last_quarantine = {'skipped': sorted(Baseline._relative(str(f.path), repo_path) for f in skipped),
+                                    'added': []}
+        files_to_process = [f.path for f in supported]
+        logger.info("indexed %d files, %d supported", len(index.files), len(files_to_process),
+                    extra={'file_path': str(repo_path)})
         
-        files_to_process = []
-        for file_path in repo_path.rglob('*'):
-            if (file_path.is_file() and 
-                file_path.suffix.lower() in supported_extensions and
-                not any(part.startswith('.') for part in file_path.parts) and
-                'node_modules' not in file_path.parts and
-                '__pycache__' not in file_path.parts):
-                files_to_process.append(file_path)
+        # Hors ligne : échec immédiat si un langage n'a pas de correcteur local
+        if self.offline:
+            gaps = self.offline_gaps(files_to_process)
+            if gaps:
+                error = OfflineError("No offline fixer for: " + ", ".join(
+                    f"{language} ({count} files)" for language, count in sorted(gaps.items()))
+                    + " - install a local tool, add a plugin or exclude these languages")
+                logger.error("%s", error, extra={'file_path': str(repo_path)})
+                return [error_result(str(repo_path), error)]
         
         if not files_to_process:
             return [FixResult(
@@ -871,55 +9302,481 @@ Upload files or run demo to see the magic happen!
                 processing_time=0.0
             )]
         
+        # Commande de formatage du projet : contenus de référence pour chaque fichier
+        self.project_formatted = {}
+        self.project_format_command = None
+        if self.project_formatter is not None:
+            self.project_formatted = await self.project_formatter.format(
+                repo_path, files_to_process, workspace_var.get().path)
+            if self.project_formatted:
+                command = self.project_formatter.command or ProjectFormatter.detect(repo_path)
+                self.project_format_command = shlex.join(command)
+        
         # Traitement concurrent - chan!(parallel_processing)
         results = []
-        max_workers = min(8, len(files_to_process))
+        originals: Dict[str, str] = {}
+        # Contenus identiques (copies vendorisées) : corrigés une seule fois
+        primaries: Dict[Tuple, str] = {}
+        duplicates: Dict[str, str] = {}
+        # Manifeste de l'exécution : fichiers déjà traités repris tels quels (--resume) ; rien d'écrit en --dry-run
+        manifest = None
+        if self.run_manifests and not self.dry_run:
+            manifest = RunManifest.load(repo_path, run_id_var.get()) or RunManifest(repo_path, run_id_var.get())
+        resumed = 0
+        listener = result_listener_var.get()
+        
+        def finished(result: FixResult):
+            results.append(result)
+            if listener is not None:
+                listener(result)
+        
+        max_workers = min(self.concurrency.max_workers, len(files_to_process))
+        file_slots = asyncio.Semaphore(max(1, max_workers))
+        
+        async def bounded(coroutine: Awaitable[FixResult]) -> FixResult:
+            async with file_slots:
+                return await coroutine
+        sizes = {f.path: f.size for f in supported}
         
         with ThreadPoolExecutor(max_workers=max_workers) as executor:
             # Préparation des tâches
             tasks = []
+            task_paths = []
             for file_path in files_to_process:
+                size = sizes.get(file_path, 0)
+                if self.MAX_FILE_SIZE < size <= self.MAX_STREAM_SIZE and self.streamable(str(file_path)):
+                    tasks.append(asyncio.create_task(bounded(self.fix_file_stream(str(file_path)))))
+                    task_paths.append(str(file_path))
+                    continue
+                if size > self.MAX_FILE_SIZE:
+                    logger.warning("file too large", extra={'file_path': str(file_path)})
+                    finished(error_result(str(file_path), FileTooLargeError(
+                        f"File exceeds {self.MAX_FILE_SIZE} bytes")))
+                    continue
+                
                 try:
-                    with open(file_path, 'r', encoding='utf-8') as f:
-                        content = f.read()
+                    content = filesystem_var.get().read_text(file_path)
+                    
+                    previous = manifest.result_for(str(file_path), content) if manifest is not None else None
+                    if previous is not None:
+                        finished(previous)
+                        originals[str(file_path)] = content
+                        resumed += 1
+                        continue
+                    
+                    key = self.dedup_key(str(file_path), content)
+                    if key is not None and key in primaries:
+                        duplicates[str(file_path)] = primaries[key]
+                        originals[str(file_path)] = content
+                        continue
+                    if key is not None:
+                        primaries[key] = str(file_path)
                     
                     # Création de la tâche async
                     task = asyncio.create_task(
-                        self.fix_file_content(str(file_path), content)
+                        bounded(self.fix_file_content(str(file_path), content))
                     )
+                    if manifest is not None:
+                        task.add_done_callback(functools.partial(self._record_progress, manifest,
+                                                                 str(file_path), content))
                     tasks.append(task)
+                    task_paths.append(str(file_path))
+                    originals[str(file_path)] = content
                     
-                except (UnicodeDecodeError, IOError) as e:
+                except UnicodeDecodeError as e:
+                    # Fichier non décodable (binaire reconnu : incohérence signalée)
+                    logger.warning("cannot decode file: %s", e, extra={'file_path': str(file_path)})
+                    finished(error_result(str(file_path), ContentSniffer.decode_error(
+                        str(file_path), read_head(file_path), e)))
+                except IOError as e:
                     # Fichier non lisible
-                    results.append(FixResult(
-                        file_path=str(file_path),
-                        original_errors=[f"Cannot read file: {str(e)}"],
-                        fixes_applied=[],
-                        success=False,
-                        language="unknown",
-                        processing_time=0.0
-                    ))
+                    logger.warning("cannot read file: %s", e, extra={'file_path': str(file_path)})
+                    finished(error_result(str(file_path), ReadFailedError(f"Cannot read file: {e}")))
+            
+            if listener is not None:
+                for task, task_path in zip(tasks, task_paths):
+                    task.add_done_callback(functools.partial(self._notify_result, listener, task_path))
+            
+            if resumed:
+                logger.info("resumed %d files from run manifest", resumed, extra={'file_path': str(repo_path)})
             
             # Exécution parallèle des tâches
             if tasks:
-                completed_results = await asyncio.gather(*tasks, return_exceptions=True)
+                try:
+                    completed_results = await asyncio.gather(*tasks, return_exceptions=True)
+                except asyncio.CancelledError:
+                    # Annulation - arrêt des tâches encore en cours
+                    for task in tasks:
+                        task.cancel()
+                    await asyncio.gather(*tasks, return_exceptions=True)
+                    raise
+                finally:
+                    if manifest is not None:
+                        manifest.save()
                 
-                for result in completed_results:
-                    if isinstance(result, FixResult):
-                        results.append(result)
-                    elif isinstance(result, Exception):
-                        results.append(FixResult(
-                            file_path="unknown",
-                            original_errors=[f"Processing error: {str(result)}"],
-                            fixes_applied=[],
-                            success=False,
-                            language="unknown",
-                            processing_time=0.0
-                        ))
+                for task_path, result in zip(task_paths, completed_results):
+                    if not isinstance(result, (FixResult, Exception)):
+                        continue
+                    if isinstance(result, Exception) and not isinstance(result, FixerError):
+                        logger.error("processing error: %s", result, exc_info=result,
+                                     extra={'file_path': task_path})
+                    results.append(self._task_result(task_path, result))
+        
+        if duplicates:
+            by_path = {result.file_path: result for result in results}
+            for duplicate, primary in duplicates.items():
+                finished(replace(by_path[primary], file_path=duplicate,
+                                 original_errors=list(by_path[primary].original_errors),
+                                 fixes_applied=list(by_path[primary].fixes_applied)))
+            logger.info("reused fixes for %d duplicate files", len(duplicates), extra={'file_path': str(repo_path)})
+        
+        # Passe TypeScript sur l'ensemble du projet
+        await self.typescript_fixer.run(repo_path, results, self.allow_repo_exec)
+        
+        if regions is not None:
+            for result in results:
+                if result.file_path in originals:
+                    regions.restrict(result, originals[result.file_path])
+        
+        # Code récent (git blame) : corrections retirées des lignes fraîches
+        if self.blame_filter is not None:
+            changed = [r for r in results if r.file_path in originals and r.fixes_applied]
+            old_code = await asyncio.to_thread(self.blame_filter.regions, repo_path,
+                                               [r.file_path for r in changed], originals)
+            for result in changed:
+                old_code.restrict(result, originals[result.file_path])
+        
+        # Constats déjà acceptés dans la baseline
+        if use_baseline:
+            baseline = Baseline.load(self.baseline_path(repo_path))
+            if baseline is not None:
+                suppressed = baseline.filter(results, repo_path)
+                logger.info("baseline suppressed %d findings", suppressed, extra={'file_path': str(repo_path)})
+        
+        # Compilation d'abord : un fichier fautif est annulé seul, avant les tests
+        self.last_compile_check = None
+        if self.compile_checker is not None:
+            self.last_compile_check = await self.compile_checker.verify(repo_path, results, workspace_var.get().path)
+            logger.info("compile check: %s", self.last_compile_check['status'], extra={'file_path': str(repo_path)})
+        
+        # Vérification des corrections par les tests du projet
+        self.last_verification = None
+        if self.verifier is not None:
+            self.last_verification = await self.verifier.verify(repo_path, results, workspace_var.get().path)
+            logger.info("verification: %s", self.last_verification['status'], extra={'file_path': str(repo_path)})
         
+        crashes = {r.file_path for r in results if r.file_path in self.shell_champion.crashes}
+        self.shell_champion.crashes -= crashes
+        if quarantine is not None:
+            added = quarantine.record(results, repo_path, crashes)
+            for path in added:
+                logger.warning("file quarantined after %d failing runs", Quarantine.THRESHOLD,
+                               extra={'file_path': path})
+            self.last_quarantine['added'] = added
+            if not self.dry_run:
+                try:
+                    quarantine.save(repo_path / Quarantine.DEFAULT_PATH)
+                except OSError as e:
+                    logger.warning("cannot save quarantine list: %s", e, extra={'file_path': str(repo_path)})
+        
+        if manifest is not None:
+            manifest.remove()
         return results
     
-    def get_summary_report(self, results: List[FixResult]) -> Dict[str, Any]:
+    @staticmethod
+    def _task_result(task_path: str, outcome: Any) -> FixResult:
+        """Résultat d'une tâche de fichier (exception convertie en résultat d'erreur)"""
+        if isinstance(outcome, FixResult):
+            return outcome
+        if isinstance(outcome, FixerError):
+            return error_result(task_path, outcome)
+        return error_result(task_path, FixerError(f"Processing error: {outcome}"))
+    
+    @classmethod
+    def _notify_result(cls, listener: Callable[[FixResult], None], task_path: str, task: asyncio.Task):
+        """Fichier terminé : résultat transmis sans attendre la fin de l'exécution"""
+        if task.cancelled():
+            return
+        try:
+            listener(cls._task_result(task_path, task.exception() or task.result()))
+        except Exception as e:
+            logger.warning("result listener failed: %s", e, extra={'file_path': task_path})
+    
+    @staticmethod
+    def _record_progress(manifest: RunManifest, file_path: str, content: str, task: asyncio.Task):
+        """Fichier terminé : résultat ajouté au manifeste de l'exécution"""
+        if not task.cancelled() and task.exception() is None and isinstance(task.result(), FixResult):
+            manifest.record(file_path, content, task.result())
+    
+    async def fix_github_repository(self, repo: str, ref: str = 'main', subdir: str = '',
+                                    branch: Optional[str] = None, token: Optional[str] = None,
+                                    message: str = 'Fix syntax with Auto-Syntax-Fixer',
+                                    only_paths: Optional[Set[str]] = None,
+                                    use_cache: bool = True, fork: bool = False,
+                                    report_status: Optional[bool] = None) -> Tuple[List[FixResult], Optional[str]]:
+        """Correction via l'API GitHub, en mémoire - commit sur `branch` si fournie (sur un fork si `fork`)"""
+        if run_id_var.get() == '-':
+            new_run_id()
+        client = self.github_client(repo, token)
+        head = await client.head_commit(ref)
+        report_status = self.commit_status if report_status is None else report_status
+        if report_status:
+            await self._post_commit_status(client, head, 'pending', 'running')
+        
+        # Même commit, mêmes réglages : résultat déjà calculé (cache_status_var pour la réponse)
+        cache_key = ResultCache.key(repo, head, {
+            'subdir': subdir.strip('/'), 'include_paths': sorted(self.include_paths),
+            'only_paths': sorted(only_paths) if only_paths is not None else None,
+            'server_config': server_config_var.get(), 'cli_rules': self.cli_rules,
+            'plugins': sorted(self.plugin_extensions())})
+        cached = self.result_cache.get(cache_key) if use_cache and self.result_cache is not None else None
+        try:
+            if cached is not None:
+                logger.info("cache hit", extra={'file_path': f"{repo}@{head[:12]}"})
+                changes = dict(cached['changes'])
+                results = [result_from_payload(result, changes) for result in cached['results']]
+            else:
+                results, changes = await self._fix_github_tree(client, repo, ref, head, subdir, only_paths, token)
+                if self.result_cache is not None:
+                    self.result_cache.put(cache_key, {'results': [result_v1(result) for result in results],
+                                                      'changes': changes,
+                                                      'cached_at': datetime.now().isoformat(timespec='seconds')})
+        except Exception as e:
+            if report_status:
+                await self._post_commit_status(client, head, 'error', f"failed: {type(e).__name__}")
+            raise
+        cache_status_var.set({'hit': cached is not None, 'commit': head,
+                              'cached_at': cached['cached_at'] if cached is not None else None})
+        if report_status:
+            fixes = sum(len(r.fixes_applied) for r in results if r.file_path in changes)
+            await self._post_commit_status(client, head, *(('failure', f"{fixes} fixes available") if changes
+                                                           else ('success', 'clean')))
+        
+        commit_sha = None
+        if branch:
+            changes = SecretScanner().guard(results, changes)
+        if branch and changes and self.fix_log_path:
+            entry = FixLog.entry([r for r in results if r.file_path in changes], Path('.'), head)
+            if entry is not None:
+                existing = await client.read_file(self.fix_log_path, head)
+                changes[self.fix_log_path] = FixLog.append(existing or '', entry)
+        if branch and changes:
+            if self.commit_trailers:
+                message = with_commit_trailers(message, [r for r in results if r.file_path in changes])
+            target, reason, push_client = branch, None, client
+            if not await client.can_push():
+                # Sans droit de push : fork, branche sur le fork, PR inter-dépôts vers `ref`
+                if not fork:
+                    raise GitHubApiError(f"Token has no push access to {repo} - use fork mode to contribute the fixes")
+                push_client = await client.fork(ref)
+                target, reason = ref, 'fork'
+                logger.info("no push access, pushing to fork %s", push_client.repo, extra={'file_path': repo})
+            elif await client.branch_protected(branch):
+                # Push refusé sur une branche protégée : branche annexe + PR vers la cible
+                branch, reason = f"asf/fix-{run_id_var.get()}", 'protected'
+                logger.info("%s is protected, pushing to %s and opening a pull request", target, branch,
+                            extra={'file_path': repo})
+            ignore_revs = self.blame_ignore_revs is not None and len(changes) >= self.blame_ignore_revs
+            commit_sha = await push_client.commit_files(head, changes, message, branch, ignore_revs)
+            logger.info("committed %d files", len(changes), extra={'file_path': f"{push_client.repo}@{branch}"})
+            if reason is not None:
+                head_ref = f"{push_client.repo.split('/')[0]}:{branch}" if reason == 'fork' else branch
+                detail = (f"{target} is protected" if reason == 'protected'
+                          else f"contributed from the fork {push_client.repo}")
+                stats = await push_client.diff_stats(head, commit_sha)
+                pr = await client.create_pull_request(
+                    head_ref, target, message.split('\n', 1)[0],
+                    f"Automated fixes ({detail}): {stats.summary()}.")
+                fallback_pr_var.set({'reason': reason, 'base': target, 'branch': branch,
+                                     'fork': push_client.repo if reason == 'fork' else None,
+                                     'number': pr['number'], 'url': pr.get('html_url')})
+        return results, commit_sha
+    
+    async def _post_commit_status(self, client: GitHubApiClient, sha: str, state: str, description: str):
+        """Statut du commit traité (contexte auto-syntax-fixer) - un refus n'interrompt pas l'exécution"""
+        try:
+            await client.post_status(sha, state, description)
+        except GitHubApiError as e:
+            logger.warning("cannot post commit status: %s", e, extra={'file_path': f"{client.repo}@{sha[:12]}"})
+    
+    async def _fix_github_tree(self, client: GitHubApiClient, repo: str, ref: str, head: str, subdir: str,
+                               only_paths: Optional[Set[str]], token: Optional[str] = None,
+                               distribute: bool = True) -> Tuple[List[FixResult], Dict[str, str]]:
+        """Lecture et correction des fichiers du commit - résultats et contenus modifiés"""
+        selected = await self._select_github_entries(client, head, subdir, only_paths)
+        if distribute and self.coordinator is not None and selected:
+            return await self.coordinator.run(self, client, repo, head, subdir, selected, token)
+        
+        if len(selected) > GitHubApiClient.MAX_FILES:
+            raise GitHubApiError(f"{len(selected)} files exceed the API mode limit of {GitHubApiClient.MAX_FILES}")
+        logger.info("fetched tree: %d files selected", len(selected), extra={'file_path': f"{repo}@{ref}"})
+        return await self._fix_github_entries(client, selected)
+    
+    async def _select_github_entries(self, client: GitHubApiClient, head: str, subdir: str,
+                                     only_paths: Optional[Set[str]]) -> List[Dict[str, Any]]:
+        """Configuration du repository au commit, puis fichiers à corriger"""
+        blobs = await client.list_tree(head)
+        
+        config_entry = next((b for b in blobs if b['path'] == CONFIG_FILENAME), None)
+        config = {}
+        if config_entry is not None:
+            try:
+                config = yaml.safe_load(await client.read_blob(config_entry['sha'])) or {}
+            except yaml.YAMLError as e:
+                logger.error("invalid configuration: %s", e, extra={'file_path': CONFIG_FILENAME})
+        self.configure(ConfigLayers(Path(client.repo), allow_repo_exec=self.allow_repo_exec,
+                                    repo_config=config if isinstance(config, dict) else {}).resolve()[0])
+        
+        prefix = subdir.strip('/') + '/' if subdir.strip('/') else ''
+        supported = RepositoryIndex.SUPPORTED_EXTENSIONS | self.plugin_extensions()
+        selected = []
+        for entry in blobs:
+            path = entry['path']
+            parts = path.split('/')
+            if not path.startswith(prefix) or not self.walk.allows(path[len(prefix):]):
+                continue
+            if Path(path).suffix.lower() not in supported and LanguageDetector.special_filename(parts[-1]) is None:
+                continue
+            if self.include_paths and not path_matches(path[len(prefix):], self.include_paths):
+                continue
+            if only_paths is not None and path not in only_paths:
+                continue
+            if not self.language_enabled(self.language_detector.detect_language(path)):
+                continue
+            selected.append(entry)
+        return self.walk.limit(selected, lambda entry: entry['path'])
+    
+    async def _fix_github_entries(self, client: GitHubApiClient,
+                                  selected: List[Dict[str, Any]]) -> Tuple[List[FixResult], Dict[str, str]]:
+        semaphore = asyncio.Semaphore(8)
+        
+        async def fix_entry(entry: Dict[str, Any]) -> Tuple[FixResult, Optional[str]]:
+            path = entry['path']
+            if entry.get('size', 0) > self.MAX_FILE_SIZE:
+                return error_result(path, FileTooLargeError(f"File exceeds {self.MAX_FILE_SIZE} bytes")), None
+            async with semaphore:
+                data = await client.read_blob(entry['sha'])
+            try:
+                content = data.decode('utf-8')
+            except UnicodeDecodeError as e:
+                return error_result(path, ContentSniffer.decode_error(path, data, e)), None
+            return await self.fix_file_content(path, content), content
+        
+        async with self.workspaces.allocate():
+            fixed = await asyncio.gather(*(fix_entry(entry) for entry in selected))
+        
+        results = [result for result, _ in fixed]
+        changes = {result.file_path: result.fixed_content for result, original in fixed
+                   if original is not None and result.error_code is None
+                   and result.fixed_content is not None and result.fixed_content != original}
+        return results, changes
+    
+    MAX_REVIEW_COMMENTS = 50
+    
+    def github_client(self, repo: str, token: Optional[str] = None) -> GitHubApiClient:
+        """Client GitHub avec les réglages réseau de l'exécution (proxy, CA, Enterprise)"""
+        if self.offline:
+            raise OfflineError("GitHub API access is disabled in offline mode - fix a local checkout instead")
+        http = ProviderHttpClient(proxy=self.network.get('proxy'), ca_bundle=self.network.get('ca_bundle'))
+        return GitHubApiClient(repo, token, http=http, api_url=self.network.get('api_url'))
+    
+    async def propose_github_fixes(self, repo: str, ref: str = 'main', subdir: str = '',
+                                   token: Optional[str] = None, branch: Optional[str] = None
+                                   ) -> Tuple[List[FixResult], Optional[Dict[str, Any]]]:
+        """Première phase : commit sur une branche de quarantaine, proposition enregistrée"""
+        if run_id_var.get() == '-':
+            new_run_id()
+        branch = branch or ProposalStore.BRANCH.format(run_id=run_id_var.get())
+        results, commit_sha = await self.fix_github_repository(repo, ref, subdir, branch, token, use_cache=False)
+        if commit_sha is None:
+            return results, None
+        base_sha = (cache_status_var.get() or {}).get('commit')
+        proposal = self.proposals.create(repo, ref, base_sha, branch, commit_sha,
+                                         sum(1 for r in results if r.fixes_applied))
+        logger.info("fixes proposed on %s", branch, extra={'file_path': f"{repo}@{ref}"})
+        return results, proposal
+    
+    async def approve_proposal(self, proposal_id: str, merge: bool = False, merge_method: str = 'squash',
+                               token: Optional[str] = None, approver: Optional[str] = None) -> Dict[str, Any]:
+        """Seconde phase : rebase de la branche de quarantaine sur la cible, PR puis fusion optionnelle"""
+        if not approver:
+            raise ProposalError("Approver identity required (API key or 'approver' field)", status=400)
+        proposal = self.proposals.get(proposal_id)
+        if proposal is None:
+            raise ProposalError(f"Unknown proposal {proposal_id}", status=404)
+        if proposal['status'] != 'proposed':
+            raise ProposalError(f"Proposal {proposal_id} is already {proposal['status']}")
+        
+        client = self.github_client(proposal['repo'], token)
+        commit_sha = proposal['commit_sha']
+        head = await client.head_commit(proposal['base_ref'])
+        if head != proposal['base_sha']:
+            # Rebase : mêmes contenus rejoués sur la nouvelle tête, si aucun fichier n'a bougé entre-temps
+            fixed = await client.changed_files(proposal['base_sha'], commit_sha)
+            moved = sorted(set(fixed) & set(await client.changed_files(proposal['base_sha'], head)))
+            if moved:
+                raise ProposalError(f"{proposal['base_ref']} changed {len(moved)} proposed files since the proposal "
+                                    f"({', '.join(moved[:5])}) - propose again")
+            files = {path: (await client.read_blob(sha)).decode('utf-8') for path, sha in fixed.items() if sha}
+            ignore_revs = self.blame_ignore_revs is not None and len(files) >= self.blame_ignore_revs
+            commit_sha = await client.commit_files(head, files, await client.commit_message(commit_sha),
+                                                   proposal['branch'], ignore_revs)
+            logger.info("proposal rebased onto %s", head[:12], extra={'file_path': proposal['branch']})
+        
+        stats = await client.diff_stats(head, commit_sha)
+        pr = await client.create_pull_request(
+            proposal['branch'], proposal['base_ref'], 'Fix syntax with Auto-Syntax-Fixer',
+            f"Automated fixes ({stats.summary()}), approved by {approver} "
+            f"(proposal {proposal_id}).")
+        status = 'approved'
+        if merge:
+            await client.merge_pull_request(pr['number'], merge_method)
+            status = 'merged'
+        return self.proposals.decide(proposal_id, status, commit_sha, pr['number'], approver)
+    
+    async def fix_github_pull_request(self, repo: str, number: int, push: bool = False,
+                                      token: Optional[str] = None) -> Tuple[List[FixResult], Optional[str]]:
+        """Correction d'une PR : fichiers de la PR uniquement, revue expliquant chaque correction"""
+        client = self.github_client(repo, token)
+        pr = await client.pull_request(number)
+        regions = await client.pull_request_regions(number)
+        head_ref, head_sha = pr['head']['ref'], pr['head']['sha']
+        
+        results, commit_sha = await self.fix_github_repository(
+            repo, head_ref, branch=head_ref if push else None, token=token,
+            only_paths=set(regions.regions)
+        )
+        
+        # Commentaires sur les lignes de la PR, avec la règle et sa justification
+        explanations: Dict[Tuple[str, int], List[str]] = {}
+        for result in results:
+            for fix in result.fixes_applied:
+                match = DiffRegions.LINE_REFERENCE.search(fix)
+                if match is None or fix.startswith('Cell ') or not regions.contains(result.file_path, int(match.group(1))):
+                    continue
+                explanations.setdefault((result.file_path, int(match.group(1))), []).append(
+                    f"🔧 **{fix_rule_id(fix)}** ({fix_severity(fix)}): {fix_rationale(fix)}")
+        comments = [{'path': path, 'line': line, 'side': 'RIGHT', 'body': '\n'.join(lines)}
+                    for (path, line), lines in explanations.items()]
+        
+        changed = [r for r in results if r.fixes_applied]
+        if changed:
+            body = [f"Auto-Syntax-Fixer: {sum(len(r.fixes_applied) for r in changed)} fixes in {len(changed)} files."]
+            if commit_sha:
+                body.append(f"Fixes pushed in {commit_sha[:12]}.")
+            if len(comments) > self.MAX_REVIEW_COMMENTS:
+                body.append(f"{len(comments) - self.MAX_REVIEW_COMMENTS} further line comments omitted.")
+            await client.post_review(number, head_sha, ' '.join(body), comments[:self.MAX_REVIEW_COMMENTS])
+            logger.info("review posted with %d comments", min(len(comments), self.MAX_REVIEW_COMMENTS),
+                        extra={'file_path': f"{repo}#{number}"})
+        return results, commit_sha
+    
+    def baseline_path(self, repo_path: Path) -> Path:
+        """Chemin du fichier baseline (CLI, configuration, ou défaut à la racine)"""
+        path = Path(self.baseline_file or Baseline.DEFAULT_FILENAME)
+        return path if path.is_absolute() else Path(repo_path) / path
+    
+    def get_summary_report(self, results: List[FixResult], owners: Optional[CodeOwners] = None) -> Dict[str, Any]:
         """Génération d'un rapport de synthèse"""
         if not results:
             return {"message": "No results to analyze"}
@@ -946,6 +9803,12 @@ Upload files or run demo to see the magic happen!
             language_stats[lang]['errors'] += len(result.original_errors)
             language_stats[lang]['fixes'] += len(result.fixes_applied)
         
+        # Fichiers non traités par type d'erreur
+        errors_by_code = {}
+        for result in results:
+            if result.error_code:
+                errors_by_code[result.error_code] = errors_by_code.get(result.error_code, 0) + 1
+        
         # Calcul des taux de succès par langage
         for lang, stats in language_stats.items():
             if stats['files'] > 0:
@@ -953,6 +9816,16 @@ Upload files or run demo to see the magic happen!
                                         if r.language == lang and r.success)
                 stats['success_rate'] = (successful_for_lang / stats['files']) * 100
         
+        # Répartition par équipe propriétaire (CODEOWNERS)
+        owner_stats = {}
+        if owners is not None:
+            for owner, owned in owners.group(results).items():
+                owner_stats[owner] = {
+                    'files': len(owned),
+                    'errors': sum(len(r.original_errors) for r in owned),
+                    'fixes': sum(len(r.fixes_applied) for r in owned)
+                }
+        
         return {
             'summary': {
                 'total_files': total_files,
@@ -964,10 +9837,17 @@ Upload files or run demo to see the magic happen!
                 'efficiency_ratio': total_fixes / max(total_errors, 1)  # Fixes per error
             },
             'by_language': language_stats,
+            'by_owner': owner_stats,
+            'verification': self.last_verification,
+            'compile_check': self.last_compile_check,
+            'quarantine': self.last_quarantine,
+            'diff_stats': DiffStats.from_results(results).as_dict(),
+            'errors_by_code': errors_by_code,
             'top_issues': self._get_top_issues(results),
             'performance_metrics': {
                 'files_per_second': total_files / sum(r.processing_time for r in results) if sum(r.processing_time for r in results) > 0 else 0,
-                'fixes_per_second': total_fixes / sum(r.processing_time for r in results) if sum(r.processing_time for r in results) > 0 else 0
+                'fixes_per_second': total_fixes / sum(r.processing_time for r in results) if sum(r.processing_time for r in results) > 0 else 0,
+                'remote': self.remote_stats()
             }
         }
     
@@ -990,7 +9870,639 @@ Upload files or run demo to see the magic happen!
         
         return [{'issue': issue, 'count': count} for issue, count in top_issues]
 
+class FixTransaction:
+    """🧾 TRANSACTION - Écriture tout-ou-rien des corrections d'une exécution
+    
+    Phase 1 : chaque contenu corrigé est écrit dans un fichier temporaire voisin.
+    Phase 2 : renommages atomiques ; en cas d'échec, les fichiers déjà remplacés
+    retrouvent leur contenu d'origine. Un arrêt en phase 1 ne touche aucun fichier.
+    """
+    
+    TEMP_PREFIX = '.asf-tx-'
+    
+    def __init__(self, results: List[FixResult]):
+        self.changes: Dict[str, str] = {}
+        self.originals: Dict[str, str] = {}
+        for result in results:
+            if result.fixed_content is None or result.error_code is not None:
+                continue
+            try:
+                original = filesystem_var.get().read_text(result.file_path)
+            except (UnicodeDecodeError, IOError) as e:
+                logger.warning("cannot re-read file for apply: %s", e, extra={'file_path': result.file_path})
+                continue
+            if original != result.fixed_content:
+                self.changes[result.file_path] = result.fixed_content
+                self.originals[result.file_path] = original
+    
+    @classmethod
+    def _stage(cls, path: str, content: str) -> str:
+        """Fichier temporaire dans le même dossier (renommage atomique), mêmes permissions"""
+        try:
+            newline = newline_of(filesystem_var.get().read_bytes(path, 65536))
+        except OSError:
+            newline = '\n'
+        fd, temp_path = tempfile.mkstemp(prefix=cls.TEMP_PREFIX, dir=os.path.dirname(os.path.abspath(path)))
+        try:
+            with os.fdopen(fd, 'w', encoding='utf-8', newline=newline) as f:
+                f.write(content.replace('\r\n', '\n'))
+            shutil.copymode(path, temp_path)
+        except OSError:
+            os.unlink(temp_path)
+            raise
+        return temp_path
+    
+    def commit(self) -> List[str]:
+        """Application de toutes les corrections - TransactionError si rien (ou plus rien) n'est écrit"""
+        staged: Dict[str, str] = {}
+        try:
+            for path, content in self.changes.items():
+                staged[path] = self._stage(path, content)
+        except OSError as e:
+            for temp_path in staged.values():
+                os.unlink(temp_path)
+            raise TransactionError(f"Cannot stage {path}: {e} - no file written")
+        
+        written: List[str] = []
+        try:
+            for path, temp_path in staged.items():
+                os.replace(temp_path, path)
+                written.append(path)
+        except OSError as e:
+            for temp_path in staged.values():
+                if os.path.exists(temp_path):
+                    os.unlink(temp_path)
+            self._rollback(written)
+            raise TransactionError(f"Cannot write {path}: {e} - {len(written)} written files rolled back")
+        return written
+    
+    def _rollback(self, written: List[str]):
+        for path in written:
+            try:
+                os.replace(self._stage(path, self.originals[path]), path)
+            except OSError as e:
+                logger.error("cannot roll back file: %s", e, extra={'file_path': path})
+
+class ProjectArchive:
+    """📦 ARCHIVE DE PROJET - Extraction sûre d'un zip/tar(.gz) et archive zip de l'arbre corrigé"""
+    
+    MAX_UPLOAD_SIZE = 50 * 1024 * 1024
+    MAX_EXTRACTED_SIZE = 256 * 1024 * 1024
+    MAX_MEMBERS = 20000
+    REPORT_NAME = 'asf-report.json'
+    
+    @staticmethod
+    def _safe_name(name: str) -> Optional[str]:
+        """Chemin relatif normalisé, None pour les dossiers ; ArchiveError si hors de l'arbre"""
+        path = PurePosixPath(name.replace('\\', '/'))
+        if path.is_absolute() or '..' in path.parts or re.match(r'^[A-Za-z]:', name):
+            raise ArchiveError(f"Unsafe path in archive: {name}")
+        normalized = '/'.join(part for part in path.parts if part not in ('', '.'))
+        return normalized or None
+    
+    @classmethod
+    def _members(cls, data: bytes) -> Iterable[Tuple[str, Callable[[], bytes], int]]:
+        """(nom, lecture, taille) des fichiers réguliers - liens et fichiers spéciaux refusés"""
+        if data[:4] == b'PK\x03\x04':
+            archive = zipfile.ZipFile(io.BytesIO(data))
+            for info in archive.infolist():
+                if info.is_dir():
+                    continue
+                if (info.external_attr >> 16) & 0o170000 == 0o120000:
+                    raise ArchiveError(f"Symbolic link in archive: {info.filename}")
+                yield info.filename, functools.partial(archive.read, info), info.file_size
+            return
+        try:
+            archive = tarfile.open(fileobj=io.BytesIO(data), mode='r:*')
+        except tarfile.TarError:
+            raise ArchiveError("Unsupported archive format (expected zip, tar or tar.gz)")
+        with archive:
+            for member in archive:
+                if member.isdir():
+                    continue
+                if not member.isfile():
+                    raise ArchiveError(f"Link or special file in archive: {member.name}")
+                yield member.name, functools.partial(lambda m: archive.extractfile(m).read(), member), member.size
+    
+    @classmethod
+    def extract(cls, data: bytes, destination: Path) -> Path:
+        """Extraction sous `destination` - racine du projet (dossier racine unique retiré)"""
+        if len(data) > cls.MAX_UPLOAD_SIZE:
+            raise ArchiveError(f"Archive exceeds {cls.MAX_UPLOAD_SIZE} bytes")
+        total, count = 0, 0
+        try:
+            for name, read, size in cls._members(data):
+                relative = cls._safe_name(name)
+                if relative is None:
+                    continue
+                count += 1
+                total += size
+                if count > cls.MAX_MEMBERS or total > cls.MAX_EXTRACTED_SIZE:
+                    raise ArchiveError(f"Archive too large once extracted (limits: {cls.MAX_MEMBERS} files,"
+                                       f" {cls.MAX_EXTRACTED_SIZE} bytes)")
+                content = read()
+                if len(content) != size:
+                    raise ArchiveError(f"Corrupted archive member: {name}")
+                target = destination / relative
+                target.parent.mkdir(parents=True, exist_ok=True)
+                target.write_bytes(content)
+        except (zipfile.BadZipFile, tarfile.TarError, EOFError, zlib.error) as e:
+            raise ArchiveError(f"Corrupted archive: {e}")
+        if count == 0:
+            raise ArchiveError("Archive contains no files")
+        
+        # Archives GitHub/`git archive --prefix` : un seul dossier racine
+        entries = list(destination.iterdir())
+        return entries[0] if len(entries) == 1 and entries[0].is_dir() else destination
+    
+    @classmethod
+    def pack(cls, root: Path, files: List[Path], report: Dict[str, Any]) -> bytes:
+        """Zip des fichiers de l'archive d'origine (corrections appliquées) avec le rapport à la racine"""
+        buffer = io.BytesIO()
+        with zipfile.ZipFile(buffer, 'w', zipfile.ZIP_DEFLATED) as archive:
+            for path in sorted(files):
+                archive.write(path, path.relative_to(root).as_posix())
+            archive.writestr(cls.REPORT_NAME, json.dumps(report, indent=2, default=str) + '\n')
+        return buffer.getvalue()
+    
+    @classmethod
+    async def process(cls, fixer: 'AutoSyntaxFixerILN3', data: bytes, workspace: str) -> Tuple[bytes, Dict[str, Any]]:
+        """Extraction, pipeline complet, application des corrections puis archive corrigée et rapport"""
+        root = await asyncio.to_thread(cls.extract, data, Path(workspace) / 'archive')
+        # Fichiers envoyés uniquement (pas l'état écrit par l'outil, ex. .asf/)
+        files = [path for path in root.rglob('*') if path.is_file()]
+        results = (await fixer.process_repository(ProcessOptions(path=str(root)))).results
+        written = await asyncio.to_thread(FixTransaction(results).commit)
+        report = fixer.get_summary_report(results)
+        report['files_written'] = sorted(Path(path).relative_to(root).as_posix() for path in written)
+        report['results'] = [{**asdict(result), 'file_path': Baseline._relative(result.file_path, root),
+                              'fixed_content': None} for result in results]
+        return await asyncio.to_thread(cls.pack, root, files, report), report
+
+class GoldenSuite:
+    """🥇 GOLDEN FILES - Fixtures entrée / sortie attendue par langage et par règle
+    
+    Arborescence (DEFAULT_DIR) :
+        python/missing_colon/.syntaxfixer.yml   # optionnel : enabled_rules: [missing_colon]
+        python/missing_colon/if_block.py        # entrée
+        python/missing_colon/if_block.py.golden # sortie attendue
+    
+    Chaque dossier est corrigé avec DEFAULT_CONFIG et son propre .syntaxfixer.yml (ni
+    configuration utilisateur ni variables ASF_*), correcteurs internes seuls (stratégie
+    'pattern', sans REPO_EXEC_KEYS) : les résultats ne dépendent que des fixtures, pas des
+    outils installés.
+    """
+    
+    DEFAULT_DIR = 'testdata/golden'
+    SUFFIX = '.golden'
+    
+    def __init__(self, root: str = DEFAULT_DIR):
+        self.root = Path(root)
+    
+    def cases(self) -> List[Path]:
+        if not self.root.is_dir():
+            raise FileNotFoundError(f"Golden directory not found: {self.root}")
+        return sorted(path for path in self.root.rglob('*')
+                      if path.is_file() and path.suffix != self.SUFFIX and path.name != CONFIG_FILENAME)
+    
+    def golden_for(self, case: Path) -> Path:
+        return case.with_name(case.name + self.SUFFIX)
+    
+    async def run(self, fixer: 'AutoSyntaxFixerILN3', update: bool = False) -> Dict[str, Any]:
+        """Comparaison de chaque sortie à son golden ; `update` réécrit les goldens différents ou absents"""
+        report = {'cases': 0, 'passed': [], 'failed': [], 'updated': []}
+        for directory, cases in itertools.groupby(self.cases(), key=lambda path: path.parent):
+            config = {key: value for key, value in load_config(directory).items() if key not in REPO_EXEC_KEYS}
+            fixer.configure({**DEFAULT_CONFIG, **config})
+            for case in cases:
+                report['cases'] += 1
+                name = case.relative_to(self.root).as_posix()
+                source = case.read_text(encoding='utf-8')
+                with fixer.strategy_override('pattern'):
+                    result = await fixer.fix_file_content(str(case), source, record_stats=False)
+                actual = result.fixed_content if result.fixed_content is not None else source
+                golden = self.golden_for(case)
+                expected = golden.read_text(encoding='utf-8') if golden.is_file() else None
+                if actual == expected:
+                    report['passed'].append(name)
+                elif update:
+                    golden.write_text(actual, encoding='utf-8')
+                    report['updated'].append(name)
+                else:
+                    diff = ''.join(difflib.unified_diff(
+                        (expected or '').splitlines(keepends=True), actual.splitlines(keepends=True),
+                        f"{name}{self.SUFFIX}", f"{name} (actual)"))
+                    report['failed'].append({'case': name, 'missing': expected is None, 'diff': diff})
+        return report
+
+class InteractiveReviewer:
+    """🔍 REVUE INTERACTIVE - Validation des corrections une par une (à la git add -p)"""
+    
+    PROMPT = "Apply this fix? [y]es / [n]o / [e]dit / [q]uit: "
+    
+    def __init__(self):
+        self.accepted = 0
+        self.skipped = 0
+    
+    def review(self, results: List[FixResult]) -> List[str]:
+        """Revue des fichiers modifiés - retourne les chemins écrits"""
+        written = []
+        pending = [r for r in results if r.fixed_content is not None and r.error_code is None]
+        
+        for position, result in enumerate(pending, 1):
+            try:
+                original = filesystem_var.get().read_text(result.file_path)
+            except (UnicodeDecodeError, IOError) as e:
+                logger.warning("cannot re-read file for review: %s", e, extra={'file_path': result.file_path})
+                continue
+            
+            if original == result.fixed_content:
+                continue
+            
+            print(f"\n📄 [{position}/{len(pending)}] {result.file_path} ({result.language})")
+            for fix in result.fixes_applied:
+                print(f"   - {fix}")
+            print(unified_diff(result.file_path, original, result.fixed_content))
+            
+            choice = self._ask()
+            if choice == 'q':
+                break
+            if choice == 'n':
+                self.skipped += 1
+                continue
+            
+            content = result.fixed_content
+            if choice == 'e':
+                content = self._edit(result.file_path, content)
+                if content is None:
+                    self.skipped += 1
+                    continue
+            
+            try:
+                filesystem_var.get().write_text(result.file_path, content)
+            except PermissionError as e:
+                error = WriteDeniedError(f"Cannot write file: {e}")
+                result.error_code = error.code
+                result.original_errors.append(str(error))
+                print(f"   ❌ {error}")
+                continue
+            
+            self.accepted += 1
+            written.append(result.file_path)
+        
+        print(f"\n✅ {self.accepted} applied, ⏭️ {self.skipped} skipped")
+        return written
+    
+    def _ask(self) -> str:
+        """Lecture du choix utilisateur"""
+        while True:
+            try:
+                answer = input(self.PROMPT).strip().lower()[:1]
+            except EOFError:
+                return 'q'
+            if answer in ('y', 'n', 'e', 'q'):
+                return answer
+    
+    def _edit(self, file_path: str, content: str) -> Optional[str]:
+        """Édition de la correction proposée dans $EDITOR"""
+        editor = os.environ.get('VISUAL') or os.environ.get('EDITOR') or 'vi'
+        suffix = Path(file_path).suffix
+        with tempfile.NamedTemporaryFile('w', suffix=suffix, delete=False, encoding='utf-8') as tmp:
+            tmp.write(content)
+            tmp_path = tmp.name
+        
+        try:
+            if subprocess.run(platform_command([editor, tmp_path])).returncode != 0:
+                print("   ⚠️ Editor exited with an error, fix skipped")
+                return None
+            with open(tmp_path, 'r', encoding='utf-8') as f:
+                return f.read()
+        except FileNotFoundError:
+            print(f"   ⚠️ Editor not found: {editor}")
+            return None
+        finally:
+            os.remove(tmp_path)
+
+def split_glob_path(path: str) -> Tuple[str, Optional[str]]:
+    """Séparation `src/**/*.py` -> ('src', '**/*.py') ; chemin sans joker inchangé"""
+    parts = Path(path).parts
+    for i, part in enumerate(parts):
+        if any(char in part for char in '*?['):
+            root = str(Path(*parts[:i])) if i else '.'
+            return root, '/'.join(parts[i:])
+    return path, None
+
+def unified_diff(file_path: str, original: str, fixed: str) -> str:
+    """Diff unifié entre contenu original et corrigé"""
+    lines = []
+    for line in difflib.unified_diff(
+        original.splitlines(keepends=True),
+        fixed.splitlines(keepends=True),
+        fromfile=f"a/{file_path}",
+        tofile=f"b/{file_path}"
+    ):
+        # Marqueur attendu par git apply pour une dernière ligne sans saut de ligne
+        lines.append(line if line.endswith('\n') else line + '\n\\ No newline at end of file\n')
+    return ''.join(lines)
+
+class DiffStats:
+    """📐 STATISTIQUES DE DIFF - Lignes ajoutées/supprimées et fichiers réellement modifiés
+    
+    Sources : `git diff --numstat` (checkout local) ou contenus avant/après
+    (corrections en mémoire). Les fichiers binaires comptent sans lignes.
+    """
+    
+    def __init__(self, files: Optional[Dict[str, Tuple[int, int]]] = None):
+        self.files = files or {}
+    
+    @property
+    def insertions(self) -> int:
+        return sum(added for added, _ in self.files.values())
+    
+    @property
+    def deletions(self) -> int:
+        return sum(deleted for _, deleted in self.files.values())
+    
+    @property
+    def files_changed(self) -> int:
+        return len(self.files)
+    
+    @classmethod
+    def from_git(cls, repo_path: Path, base: str, head: Optional[str] = None) -> 'DiffStats':
+        """`git diff --numstat base [head]` (arbre de travail si head absent)"""
+        completed = subprocess.run(['git', '-C', str(repo_path), 'diff', '--numstat', '--no-renames', base,
+                                    *([head] if head else []), '--'], capture_output=True, text=True, timeout=60)
+        if completed.returncode != 0:
+            raise FixerError(f"git diff failed: {completed.stderr.strip()}")
+        return cls.parse_numstat(completed.stdout)
+    
+    @classmethod
+    def parse_numstat(cls, output: str) -> 'DiffStats':
+        files = {}
+        for line in output.splitlines():
+            parts = line.split('\t', 2)
+            if len(parts) != 3:
+                continue
+            added, deleted, path = parts
+            # Binaire : "-\t-\tchemin"
+            files[path] = (int(added) if added.isdigit() else 0, int(deleted) if deleted.isdigit() else 0)
+        return cls(files)
+    
+    @classmethod
+    def from_contents(cls, contents: Iterable[Tuple[str, str, str]]) -> 'DiffStats':
+        """Triplets (chemin, original, corrigé) - fichiers identiques ignorés"""
+        files = {}
+        for path, original, fixed in contents:
+            if original == fixed:
+                continue
+            added = deleted = 0
+            matcher = difflib.SequenceMatcher(None, original.splitlines(), fixed.splitlines(), autojunk=False)
+            for tag, i1, i2, j1, j2 in matcher.get_opcodes():
+                if tag != 'equal':
+                    deleted += i2 - i1
+                    added += j2 - j1
+            files[path] = (added, deleted)
+        return cls(files)
+    
+    @classmethod
+    def from_results(cls, results: List[FixResult]) -> 'DiffStats':
+        """Corrections en mémoire comparées aux fichiers sur disque (illisibles : ignorés)"""
+        def contents():
+            for result in results:
+                if result.fixed_content is None or result.error_code is not None:
+                    continue
+                try:
+                    yield result.file_path, filesystem_var.get().read_text(result.file_path), result.fixed_content
+                except (UnicodeDecodeError, IOError):
+                    continue
+        return cls.from_contents(contents())
+    
+    def as_dict(self) -> Dict[str, int]:
+        return {'insertions': self.insertions, 'deletions': self.deletions, 'files_changed': self.files_changed}
+    
+    def summary(self) -> str:
+        return f"{self.files_changed} files changed, +{self.insertions} -{self.deletions}"
+
+def build_patch(results: List[FixResult], root: Path) -> Tuple[str, int]:
+    """Patch unique au format git (compatible `git apply`) - retourne le patch et le nombre de fichiers"""
+    chunks = []
+    for result in results:
+        if result.fixed_content is None or result.error_code is not None:
+            continue
+        try:
+            original = filesystem_var.get().read_text(result.file_path)
+        except (UnicodeDecodeError, IOError) as e:
+            logger.warning("cannot re-read file for patch: %s", e, extra={'file_path': result.file_path})
+            continue
+        
+        if original == result.fixed_content:
+            continue
+        
+        relative = Path(os.path.relpath(result.file_path, root)).as_posix()
+        chunks.append(f"diff --git a/{relative} b/{relative}\n")
+        chunks.append(unified_diff(relative, original, result.fixed_content))
+    return ''.join(chunks), sum(1 for chunk in chunks if chunk.startswith('diff --git '))
+
+# === BENCHMARKS ===
+class Benchmark:
+    """⏱️ BENCHMARK - Pipeline complet sur des repositories synthétiques générés"""
+    
+    SIZES = {'small': 20, 'medium': 200, 'huge': 2000}
+    # Seuil de régression du débit par rapport à une référence enregistrée
+    REGRESSION_TOLERANCE = 0.2
+    
+    # Fichier synthétique par langage : erreurs volontaires pour chaque famille de règles
+    TEMPLATES = {
+        'python': ('.py', 'import os\nimport os\nfrom sys import path\nfrom sys import argv\n\n'
+                          'def handler_{n}(value)\n    if value == {n}\n        print value\n'
+                          '    return value   \n'),
+        'javascript': ('.js', "var total_{n} = 0\nconst {{ a }} = require('./a');\n"
+                              "if (total_{n} == {n}) {{\n  console.log(\u201cdone\u201d)\n}}\n"),
+        'go': ('.go', 'package bench\n\nimport "fmt"\n\nfunc Handler{n}()  {{\n\tfmt.Println({n})\n}}\n'),
+        'html': ('.html', '<div class=box{n}>\n<p>item {n}</p>\n<img src=a.png>\n</div>\n'),
+        'text': ('.md', '# Notes {n}  \n\nSome text with trailing spaces   \n\n\n')
+    }
+    
+    def __init__(self, fixer: 'AutoSyntaxFixerILN3'):
+        self.fixer = fixer
+    
+    def generate(self, root: Path, size: str, languages: List[str]):
+        """Repository synthétique : `SIZES[size]` fichiers par langage, répartis en sous-dossiers"""
+        for language in languages:
+            extension, template = self.TEMPLATES[language]
+            for n in range(self.SIZES[size]):
+                directory = Path(root) / language / f"pkg{n // 50}"
+                directory.mkdir(parents=True, exist_ok=True)
+                with open(directory / f"file{n}{extension}", 'w', encoding='utf-8') as f:
+                    f.write(template.format(n=n))
+    
+    async def run(self, size: str, languages: Optional[List[str]] = None) -> Dict[str, Any]:
+        languages = languages or list(self.TEMPLATES)
+        root = tempfile.mkdtemp(prefix='asf-bench-')
+        try:
+            self.generate(Path(root), size, languages)
+            shell = self.fixer.shell_champion
+            calls, subprocess_time = shell.subprocess_calls, shell.subprocess_time
+            
+            tracemalloc.start()
+            started = time.perf_counter()
+            results = await self.fixer.fix_repository(root, use_baseline=False)
+            elapsed = time.perf_counter() - started
+            _, peak = tracemalloc.get_traced_memory()
+            tracemalloc.stop()
+        finally:
+            shutil.rmtree(root, ignore_errors=True)
+        
+        per_language = {}
+        for result in results:
+            stats = per_language.setdefault(result.language, {'files': 0, 'time': 0.0})
+            stats['files'] += 1
+            stats['time'] += result.processing_time
+        
+        return {
+            'size': size,
+            'files': len(results),
+            'elapsed': elapsed,
+            'files_per_second': len(results) / elapsed if elapsed > 0 else 0.0,
+            'peak_memory_mb': peak / (1024 * 1024),
+            'subprocess_calls': shell.subprocess_calls - calls,
+            'subprocess_time': shell.subprocess_time - subprocess_time,
+            'by_language': {lang: {'files': stats['files'],
+                                   'ms_per_file': stats['time'] / stats['files'] * 1000}
+                            for lang, stats in per_language.items()}
+        }
+    
+    @classmethod
+    def regressions(cls, current: Dict[str, Any], reference: Dict[str, Any]) -> List[str]:
+        """Régressions du débit au-delà de la tolérance"""
+        found = []
+        if reference.get('size') != current['size']:
+            return [f"reference was recorded for size {reference.get('size')}, not {current['size']}"]
+        floor = reference['files_per_second'] * (1 - cls.REGRESSION_TOLERANCE)
+        if current['files_per_second'] < floor:
+            found.append(f"throughput {current['files_per_second']:.1f} files/sec "
+                         f"< {floor:.1f} (reference {reference['files_per_second']:.1f})")
+        for lang, stats in current['by_language'].items():
+            previous = reference.get('by_language', {}).get(lang)
+            if previous and stats['ms_per_file'] > previous['ms_per_file'] * (1 + cls.REGRESSION_TOLERANCE):
+                found.append(f"{lang}: {stats['ms_per_file']:.2f} ms/file (reference {previous['ms_per_file']:.2f})")
+        return found
+
 # CLI Interface
+class SelfUpdater:
+    """⬆️ MISE À JOUR - Dernière release téléchargée, signature et somme SHA-256 vérifiées, remplacement atomique
+    
+    La somme vient de la même source que le script : seule la signature détachée (app.py.sig, signature
+    SSH vérifiée par `ssh-keygen -Y verify`) contre la clé épinglée ci-dessous authentifie la release,
+    y compris lorsque ASF_RELEASE_URL pointe vers un miroir.
+    """
+    
+    RELEASE_URL = 'https://api.github.com/repos/Tryboy869/auto-syntaxe-fixer/releases/latest'
+    ASSET = 'app.py'
+    CHECKSUMS = 'SHA256SUMS'
+    SIGNATURE = 'app.py.sig'
+    # Clé publique OpenSSH des releases (ssh-ed25519 AAAA...) : sans clé épinglée, pas de mise à jour
+    SIGNING_KEY = ''
+    SIGNING_IDENTITY = 'releases@auto-syntax-fixer'
+    SIGNING_NAMESPACE = 'asf-release'
+    TIMEOUT = 30
+    
+    def __init__(self, target: Optional[str] = None, release_url: Optional[str] = None):
+        self.target = Path(target or os.path.abspath(__file__))
+        self.release_url = release_url or os.environ.get('ASF_RELEASE_URL', self.RELEASE_URL)
+    
+    @staticmethod
+    def parse_version(version: str) -> Tuple[int, ...]:
+        match = re.match(r'^v?(\d+(?:\.\d+)*)', version.strip())
+        if not match:
+            raise UpdateError(f"Invalid release version: {version}")
+        return tuple(int(part) for part in match.group(1).split('.'))
+    
+    def _get(self, url: str) -> bytes:
+        request = urllib.request.Request(url, headers={'User-Agent': f"auto-syntax-fixer/{TOOL_VERSION}"})
+        try:
+            with urllib.request.urlopen(request, timeout=self.TIMEOUT) as response:
+                return response.read()
+        except (urllib.error.URLError, OSError) as e:
+            raise UpdateError(f"Cannot download {url}: {e}")
+    
+    def latest(self) -> Dict[str, Any]:
+        """Version et URLs de la dernière release"""
+        try:
+            release = json.loads(self._get(self.release_url))
+            assets = {asset['name']: asset['browser_download_url'] for asset in release.get('assets', [])}
+            version = release['tag_name']
+        except (ValueError, KeyError, TypeError) as e:
+            raise UpdateError(f"Invalid release metadata from {self.release_url}: {e}")
+        return {'version': version, 'asset': assets.get(self.ASSET), 'checksums': assets.get(self.CHECKSUMS),
+                'signature': assets.get(self.SIGNATURE)}
+    
+    @classmethod
+    def expected_digest(cls, checksums: str) -> str:
+        """Ligne `<sha256>  app.py` du fichier SHA256SUMS"""
+        for line in checksums.splitlines():
+            parts = line.split()
+            if len(parts) == 2 and parts[1].lstrip('*') == cls.ASSET and re.fullmatch(r'[0-9a-f]{64}', parts[0]):
+                return parts[0]
+        raise UpdateError(f"No SHA-256 checksum for {cls.ASSET} in {cls.CHECKSUMS}")
+    
+    def verify_signature(self, payload: bytes, signature: bytes):
+        """Signature détachée de `payload` par la clé épinglée (namespace asf-release)"""
+        if not self.SIGNING_KEY:
+            raise UpdateError("No release signing key pinned in this build - self-update disabled")
+        if not shutil.which('ssh-keygen'):
+            raise UpdateError("Verifying the release signature requires ssh-keygen (OpenSSH)")
+        with tempfile.TemporaryDirectory(prefix='asf-update-') as directory:
+            signers = Path(directory) / 'allowed_signers'
+            signers.write_text(f'{self.SIGNING_IDENTITY} namespaces="{self.SIGNING_NAMESPACE}" {self.SIGNING_KEY}\n')
+            signature_path = Path(directory) / self.SIGNATURE
+            signature_path.write_bytes(signature)
+            try:
+                result = subprocess.run(
+                    ['ssh-keygen', '-Y', 'verify', '-f', str(signers), '-I', self.SIGNING_IDENTITY,
+                     '-n', self.SIGNING_NAMESPACE, '-s', str(signature_path)],
+                    input=payload, capture_output=True, timeout=self.TIMEOUT)
+            except (OSError, subprocess.TimeoutExpired) as e:
+                raise UpdateError(f"Cannot verify the release signature: {e}")
+        if result.returncode != 0:
+            message = (result.stderr or result.stdout).decode(errors='replace').strip()
+            raise UpdateError(f"Invalid signature for {self.ASSET}: {message}")
+    
+    def update(self, force: bool = False) -> Dict[str, Any]:
+        """Téléchargement, vérification puis remplacement - {'current', 'latest', 'updated'}"""
+        release = self.latest()
+        report = {'current': TOOL_VERSION, 'latest': release['version'], 'updated': False}
+        if not force and self.parse_version(release['version']) <= self.parse_version(TOOL_VERSION):
+            return report
+        if not release['asset'] or not release['checksums'] or not release['signature']:
+            raise UpdateError(f"Release {release['version']} lacks {self.ASSET}, {self.CHECKSUMS} "
+                              f"or {self.SIGNATURE} - not updating")
+        
+        payload = self._get(release['asset'])
+        digest = hashlib.sha256(payload).hexdigest()
+        expected = self.expected_digest(self._get(release['checksums']).decode('utf-8', errors='replace'))
+        if not hmac.compare_digest(digest, expected):
+            raise UpdateError(f"Checksum mismatch for {self.ASSET}: expected {expected}, got {digest}")
+        self.verify_signature(payload, self._get(release['signature']))
+        try:
+            compile(payload, self.ASSET, 'exec')
+        except (SyntaxError, ValueError) as e:
+            raise UpdateError(f"Downloaded {self.ASSET} is not valid Python: {e}")
+        
+        # Fichier temporaire voisin puis renommage : jamais de script à moitié écrit
+        fd, temp_path = tempfile.mkstemp(prefix='.asf-update-', dir=str(self.target.parent))
+        try:
+            with os.fdopen(fd, 'wb') as f:
+                f.write(payload)
+            shutil.copymode(self.target, temp_path)
+            os.replace(temp_path, self.target)
+        except OSError as e:
+            with contextlib.suppress(OSError):
+                os.unlink(temp_path)
+            raise UpdateError(f"Cannot replace {self.target}: {e}")
+        report['updated'] = True
+        return report
+
 def main():
     """Point d'entrée principal pour CLI"""
     import sys
@@ -998,20 +10510,515 @@ def main():
     
     parser = argparse.ArgumentParser(description='🔧 Auto-Syntax-Fixer ILN')
     parser.add_argument('path', nargs='?', default='.', 
-                       help='Path to file or repository to fix')
+                       help="Path to file or repository to fix (globs such as 'src/**/*.py' select a subtree)")
     parser.add_argument('--server', action='store_true',
                        help='Start web server')
+    parser.add_argument('--db', metavar='PATH|URL',
+                       help=f'Server database: SQLite path or postgresql:// URL (default: ASF_DB or {ServerStore.DEFAULT_PATH})')
+    parser.add_argument('--shutdown-timeout', type=float, metavar='SECONDS',
+                       help='Seconds running jobs get to finish on SIGTERM before being saved for resume (default: ASF_SHUTDOWN_TIMEOUT or 30)')
+    parser.add_argument('--workers', type=lambda v: [w.strip() for w in v.split(',') if w.strip()], metavar='URLS',
+                       help='Coordinator mode: split GitHub repositories across these worker servers (default: ASF_WORKERS)')
+    parser.add_argument('--offline', action='store_true', default=os.environ.get('ASF_OFFLINE') == '1',
+                       help='Air-gapped mode: no remote APIs or downloads, local tools and built-in fixers only; '
+                            f'exits with {EXIT_OFFLINE_UNSUPPORTED} if a language has no offline fixer (default: ASF_OFFLINE=1)')
+    parser.add_argument('--remote-formatters', metavar='FILE', default=os.environ.get('ASF_REMOTE_FORMATTERS'),
+                       help='Send languages to remote HTTPS formatting services, with local fallback '
+                            '(YAML: endpoints, secret_env) (default: ASF_REMOTE_FORMATTERS)')
+    parser.add_argument('--strategy-policy', metavar='NAME[:KEY=VALUE,...]', default=os.environ.get('ASF_STRATEGY_POLICY'),
+                       help='When files go to remote services, local tools or built-in patterns only: default, size, '
+                            'latency or cost, e.g. size:remote_min_bytes=32768 (default: ASF_STRATEGY_POLICY)')
+    parser.add_argument('--strategy', choices=STRATEGIES,
+                       help='Force one strategy for every file of this run')
+    parser.add_argument('--otlp-endpoint', metavar='URL',
+                       help='Export OpenTelemetry traces over OTLP/HTTP, e.g. http://localhost:4318 '
+                            '(default: OTEL_EXPORTER_OTLP_ENDPOINT)')
+    parser.add_argument('--containers', metavar='FILE', default=os.environ.get('ASF_CONTAINERS'),
+                       help='Run formatters in pinned per-language images (YAML: runtime, images) (default: ASF_CONTAINERS)')
+    parser.add_argument('--migrate', action='store_true',
+                       help='Apply pending server database migrations and exit')
+    parser.add_argument('--require-api-key', action='store_true',
+                       help='Require an X-API-Key header on API calls (keys managed via /api/admin, default: ASF_REQUIRE_API_KEY=1)')
+    parser.add_argument('--redis-url', metavar='URL', default=os.environ.get('ASF_REDIS_URL'),
+                       help='Share rate limits, job state and the job queue through Redis (default: ASF_REDIS_URL)')
     parser.add_argument('--port', type=int, default=8000,
                        help='Server port (default: 8000)')
     parser.add_argument('--host', default='0.0.0.0',
                        help='Server host (default: 0.0.0.0)')
     parser.add_argument('--report', action='store_true',
                        help='Generate detailed report')
+    parser.add_argument('--bench', nargs='?', const='medium', choices=list(Benchmark.SIZES),
+                       help='Benchmark the pipeline on a synthetic repository (default size: medium)')
+    parser.add_argument('--bench-output', metavar='FILE',
+                       help='Save benchmark results as JSON (reference for --bench-compare)')
+    parser.add_argument('--bench-compare', metavar='FILE',
+                       help='Exit non-zero if the benchmark regresses against this reference')
+    parser.add_argument('--health', action='store_true',
+                       help='Print the repository health score (0-100)')
+    parser.add_argument('--openapi', metavar='FILE',
+                       help="Write the server's OpenAPI specification to FILE ('-' for stdout) and exit")
+    parser.add_argument('--language-tree', action='store_true',
+                       help='Print the languages found in each directory and exit')
+    parser.add_argument('--stats', action='store_true',
+                       help=f'Show fix trends from the run history ({RunHistory.DEFAULT_PATH}) and exit')
+    parser.add_argument('--no-history', action='store_true',
+                       help='Do not record this run in the run history')
+    parser.add_argument('--fix-log', nargs='?', const=FixLog.DEFAULT_PATH, metavar='PATH',
+                       help=f'Append an entry describing this run to PATH in the repository (default: {FixLog.DEFAULT_PATH})')
+    parser.add_argument('--archive', metavar='FILE',
+                       help='Fix a zip/tar.gz project archive and write a zip of the fixed tree (with the report)')
+    parser.add_argument('--archive-output', metavar='FILE',
+                       help='Output of --archive (default: <archive name>-fixed.zip)')
+    parser.add_argument('--allow-repo-plugins', action='store_true',
+                       default=os.environ.get('ASF_ALLOW_REPO_PLUGINS') == '1',
+                       help=f"Run the plugins, verify and project_format commands declared in the repository's {CONFIG_FILENAME} "
+                            "(a repository configuration can run arbitrary code; default: ASF_ALLOW_REPO_PLUGINS=1)")
+    parser.add_argument('--config-validate', action='store_true',
+                       help=f'Check {CONFIG_FILENAME} in the repository against the configuration schema and exit')
+    parser.add_argument('--config-show', action='store_true',
+                       help='Print the resolved configuration (defaults < repo < user < ASF_* env < CLI) '
+                            'with the origin of each value and exit')
+    parser.add_argument('--config-init', action='store_true',
+                       help=f'Write a commented default {CONFIG_FILENAME} in the repository and exit')
+    parser.add_argument('--golden', nargs='?', const=GoldenSuite.DEFAULT_DIR, metavar='DIR',
+                       help=f'Check the fixers against the golden fixtures in DIR (default: {GoldenSuite.DEFAULT_DIR}) and exit')
+    parser.add_argument('--update-goldens', action='store_true',
+                       help='With --golden: rewrite the .golden files that differ or are missing')
+    parser.add_argument('--self-update', action='store_true',
+                       help='Download the latest release, verify its signature and SHA-256 checksum and replace this script')
+    parser.add_argument('--revert', metavar='RUN_ID|COMMIT',
+                       help='Revert a previous automated fix commit (by SHA or run id) and exit')
+    parser.add_argument('--no-commit-trailers', action='store_true',
+                       help='Do not add Asf-Rules/Asf-Version/Asf-Files-Changed trailers to fix commits')
+    parser.add_argument('--no-quarantine', action='store_true',
+                       help=f'Process quarantined files and do not update the quarantine list ({Quarantine.DEFAULT_PATH})')
+    parser.add_argument('--clear-quarantine', nargs='*', metavar='FILE',
+                       help='Release the given files (default: all) from quarantine and exit')
+    parser.add_argument('--github', metavar='OWNER/REPO',
+                       help='Fix a GitHub repository through the API, without cloning (token: GITHUB_TOKEN)')
+    parser.add_argument('--ref', default='main',
+                       help='Branch to read in --github mode (default: main)')
+    parser.add_argument('--commit-branch', metavar='BRANCH',
+                       help='In --github mode, commit the fixes to this branch (default: report only)')
+    parser.add_argument('--api-url', metavar='URL',
+                       help='GitHub API base URL, e.g. https://github.example.com/api/v3 (default: GITHUB_API_URL or api.github.com)')
+    parser.add_argument('--proxy', metavar='URL',
+                       help='HTTP(S) proxy for API calls (default: HTTPS_PROXY/HTTP_PROXY)')
+    parser.add_argument('--ca-bundle', metavar='FILE',
+                       help='CA bundle for TLS verification (default: SSL_CERT_FILE/REQUESTS_CA_BUNDLE)')
+    parser.add_argument('--no-blame-ignore-revs', action='store_true',
+                       help=f'Do not record large fix commits in {BLAME_IGNORE_REVS}')
+    parser.add_argument('--commit-status', action='store_true',
+                       help='In --github mode, post a pending/success/failure status on the processed commit')
+    parser.add_argument('--mirror-cache', metavar='DIR',
+                       help=f'Directory of the bare-mirror cache used for remote repositories (default: {MirrorCache.DEFAULT_DIR})')
+    parser.add_argument('--branches', metavar='BRANCH,...',
+                       help='Check several branches of the local repository in parallel, using git worktrees')
+    parser.add_argument('--fork', action='store_true',
+                       help='With --commit-branch and no push access, fork the repository and open a cross-repository PR')
+    parser.add_argument('--propose', action='store_true',
+                       help='In --github mode, push the fixes to a quarantine branch and record a proposal to approve')
+    parser.add_argument('--approve', metavar='PROPOSAL_ID',
+                       help='Rebase an approved proposal onto its target branch, open the pull request and exit')
+    parser.add_argument('--merge', action='store_true',
+                       help='With --approve, merge the pull request once opened')
+    parser.add_argument('--pr', type=int, metavar='NUMBER',
+                       help='In --github mode, fix this pull request and explain each fix in a review')
+    parser.add_argument('--push', action='store_true',
+                       help='With --pr, push the fixes to the pull request branch')
+    
+    parser.add_argument('--languages', type=lambda v: [l.strip() for l in v.split(',') if l.strip()],
+                       help='Comma-separated languages to fix (default: all)')
+    parser.add_argument('--exclude-languages', type=lambda v: [l.strip() for l in v.split(',') if l.strip()],
+                       help='Comma-separated languages to skip')
+    parser.add_argument('--rules', type=lambda v: [r.strip() for r in v.split(',') if r.strip()],
+                       help=f"Comma-separated rule IDs or groups to apply ({', '.join(SyntaxAnalyzer.RULE_GROUPS)}, ...)")
+    parser.add_argument('--diff-base', metavar='REF',
+                       help='Only fix lines changed since this git ref (like git clang-format)')
+    parser.add_argument('--skip-fresh-days', type=float, metavar='DAYS',
+                       help='Leave lines authored in the last DAYS days untouched (git blame)')
+    parser.add_argument('--skip-authors', type=lambda v: [a.strip() for a in v.split(',') if a.strip()],
+                       metavar='NAMES', help='Comma-separated author names or emails whose lines are left untouched')
+    parser.add_argument('--verify', action='store_true',
+                       help="Run the project's tests before/after fixing and roll back on regression")
+    parser.add_argument('--compile-check', action='store_true',
+                       help='Build Go/Rust/TypeScript/Java projects after fixing; roll back files that break it')
+    parser.add_argument('--project-format', action='store_true',
+                       help="Prefer the project's own format command (npm run format, make fmt, just fmt)")
+    parser.add_argument('--fail-on-files', type=int, metavar='N',
+                       help='Exit non-zero when more than N files need fixes')
+    parser.add_argument('--fail-on-aggressive', action='store_true',
+                       help='Exit non-zero when any aggressive (non-cosmetic) fix is applied')
+    parser.add_argument('--write-baseline', action='store_true',
+                       help='Record current findings as the baseline and exit')
+    parser.add_argument('--baseline', metavar='PATH',
+                       help=f'Baseline file (default: {Baseline.DEFAULT_FILENAME} in the repository)')
+    parser.add_argument('--split-by-owner', action='store_true',
+                       help='Group changed files by CODEOWNERS team for separate review')
+    parser.add_argument('--patch', metavar='FILE',
+                       help="Write all fixes to a git-format patch instead of the files ('-' for stdout)")
+    parser.add_argument('--stream', action='store_true',
+                       help='Print each file result as a JSON line as soon as the file is fixed (report on stderr)')
+    parser.add_argument('--resume', metavar='RUN_ID',
+                       help='Resume an interrupted repository run, reusing the results of files already processed')
+    parser.add_argument('--write', action='store_true',
+                       help='Write all fixes to disk in one all-or-nothing step (after validation and thresholds)')
+    parser.add_argument('--dry-run', action='store_true',
+                       help='Report fixes without updating repository state (quarantine list, run history, fix log)')
+    parser.add_argument('--interactive', action='store_true',
+                       help='Review each proposed fix before writing it')
+    parser.add_argument('--profile', choices=list(ConcurrencyProfile.presets()),
+                       default=os.environ.get('ASF_CONCURRENCY') or 'ci',
+                       help='Concurrency preset: laptop keeps the machine usable, server raises limits (default: ci)')
+    parser.add_argument('--max-workers', type=int, metavar='N', help='Files fixed concurrently (overrides --profile)')
+    parser.add_argument('--max-subprocesses', type=int, metavar='N',
+                       help='External tools run concurrently (overrides --profile)')
+    parser.add_argument('--workspace-quota', type=int, metavar='MB',
+                       help=f'Disk quota of the per-run temp workspace (default: {WorkspaceManager.DEFAULT_QUOTA // (1024 * 1024)} MB)')
+    parser.add_argument('--log-level', default='WARNING',
+                       choices=['DEBUG', 'INFO', 'WARNING', 'ERROR'],
+                       help='Log level (default: WARNING)')
+    parser.add_argument('--log-format', default='text', choices=['text', 'json'],
+                       help='Log output format (default: text)')
     
     args = parser.parse_args()
+    if args.dry_run and args.write:
+        parser.error('--dry-run cannot be combined with --write')
+    if args.stream and args.patch == '-':
+        parser.error("--stream and --patch - both write to stdout")
+    # Patch, spécification ou résultats en flux sur stdout : bannière, progression et rapport passent sur stderr
+    output = sys.stdout
+    if args.patch == '-' or args.openapi == '-' or args.stream:
+        sys.stdout = sys.stderr
+    configure_logging(args.log_level, args.log_format)
     
     # Création de l'instance principale
     fixer = AutoSyntaxFixerILN3()
+    fixer.select_languages(args.languages, args.exclude_languages)
+    fixer.select_rules(args.rules)
+    args.path, include = split_glob_path(args.path)
+    fixer.include_paths = [include] if include else []
+    fixer.diff_base = args.diff_base
+    if args.skip_fresh_days is not None:
+        fixer.cli_blame['max_age_days'] = args.skip_fresh_days
+    if args.skip_authors:
+        fixer.cli_blame['authors'] = args.skip_authors
+    fixer.cli_verify = args.verify
+    fixer.allow_repo_exec = args.allow_repo_plugins
+    fixer.cli_compile_check = args.compile_check
+    fixer.cli_project_format = args.project_format
+    fixer.network = {'api_url': args.api_url, 'proxy': args.proxy, 'ca_bundle': args.ca_bundle}
+    fixer.set_fail_policy(args.fail_on_files, args.fail_on_aggressive)
+    if args.workspace_quota is not None:
+        fixer.workspaces.quota = args.workspace_quota * 1024 * 1024
+    fixer.cli_baseline_file = args.baseline
+    fixer.baseline_file = args.baseline
+    fixer.cli_history = not args.no_history
+    fixer.cli_quarantine = not args.no_quarantine
+    fixer.dry_run = args.dry_run
+    fixer.cli_fix_log = args.fix_log
+    fixer.cli_commit_trailers = not args.no_commit_trailers
+    fixer.cli_commit_status = args.commit_status
+    fixer.cli_blame_ignore_revs = not args.no_blame_ignore_revs
+    try:
+        fixer.use_concurrency(ConcurrencyProfile.from_spec({
+            'profile': args.profile,
+            **({'max_workers': args.max_workers} if args.max_workers is not None else {}),
+            **({'max_subprocesses': args.max_subprocesses} if args.max_subprocesses is not None else {})
+        }))
+    except ValueError as e:
+        parser.error(str(e))
+    if args.mirror_cache:
+        fixer.mirrors = MirrorCache(args.mirror_cache)
+    if args.db:
+        fixer.projects = ProjectStore(args.db)
+        fixer.api_keys = ApiKeyStore(args.db)
+        fixer.jobs = JobTracker(JobHistoryStore(args.db))
+        fixer.job_queue = JobQueue(PendingJobStore(args.db))
+        fixer.proposals = ProposalStore(args.db)
+    
+    if args.offline and (args.github or args.workers or args.redis_url or args.remote_formatters):
+        print("❌ --offline cannot be combined with --github, --workers, --redis-url or --remote-formatters")
+        sys.exit(1)
+    
+    if args.remote_formatters:
+        try:
+            fixer.remote_formatters = RemoteFormatter.load(args.remote_formatters)
+        except RemoteFormatterError as e:
+            print(f"❌ {e}")
+            sys.exit(1)
+    
+    if args.strategy_policy:
+        try:
+            fixer.strategy_policy = StrategyPolicy.from_spec(args.strategy_policy)
+        except ValueError as e:
+            print(f"❌ {e}")
+            sys.exit(1)
+    if args.strategy:
+        strategy_var.set(args.strategy)
+    
+    if args.workers:
+        fixer.coordinator = WorkerCoordinator(args.workers)
+    
+    if args.containers:
+        try:
+            fixer.shell_champion.use_containers(ContainerBackend.load(args.containers))
+        except ContainerConfigError as e:
+            print(f"❌ {e}")
+            sys.exit(1)
+    
+    if args.offline:
+        fixer.enable_offline()
+    
+    if args.otlp_endpoint or os.environ.get('OTEL_EXPORTER_OTLP_ENDPOINT'):
+        try:
+            tracing.configure(args.otlp_endpoint)
+        except FixerError as e:
+            print(f"❌ {e}")
+            sys.exit(1)
+    
+    if args.migrate:
+        # Migrations de la base du serveur, avant un déploiement
+        store = ServerStore(args.db)
+        try:
+            applied = store.db.migrate()
+        except Exception as e:
+            print(f"❌ Migration failed: {e}")
+            sys.exit(1)
+        print(f"🗄️ {len(applied)} migrations applied" + ''.join(f"\n   - {m}" for m in applied))
+        sys.exit(0)
+    if args.require_api_key:
+        fixer.require_api_key = True
+    if args.redis_url and args.server:
+        try:
+            fixer.use_redis(args.redis_url)
+        except Exception as e:
+            print(f"❌ Cannot use Redis at {args.redis_url}: {e}")
+            sys.exit(1)
+    
+    if args.openapi:
+        # Spécification de l'API HTTP, versionnée avec le code
+        spec = json.dumps(fixer.app.openapi(), indent=2) + '\n'
+        if args.openapi == '-':
+            output.write(spec)
+        else:
+            with open(args.openapi, 'w', encoding='utf-8') as f:
+                f.write(spec)
+            print(f"📚 OpenAPI specification written: {args.openapi}")
+        sys.exit(0)
+    
+    if args.clear_quarantine is not None:
+        quarantine_path = Path(args.path) / Quarantine.DEFAULT_PATH
+        quarantine = Quarantine.load(quarantine_path)
+        released = quarantine.clear(args.clear_quarantine)
+        quarantine.save(quarantine_path)
+        print(f"🚧 {released} files released from quarantine ({len(quarantine.quarantined())} remaining)")
+        sys.exit(0)
+    
+    if args.approve:
+        # Seconde phase d'une application en deux phases
+        try:
+            proposal = asyncio.run(fixer.approve_proposal(args.approve, args.merge,
+                                                          approver=os.environ.get('USER') or os.environ.get('USERNAME')))
+        except (ProposalError, GitHubApiError, OfflineError) as e:
+            print(f"❌ {e}")
+            sys.exit(1)
+        print(f"✅ Proposal {proposal['id']} {proposal['status']}: pull request #{proposal['pull_request']}"
+              f" ({proposal['branch']} → {proposal['base_ref']})")
+        sys.exit(0)
+    
+    if args.config_validate:
+        config_path = Path(args.path) / CONFIG_FILENAME
+        if not config_path.is_file():
+            print(f"ℹ️ No {CONFIG_FILENAME} in {args.path} (defaults apply)")
+            sys.exit(0)
+        try:
+            issues = ConfigValidator().validate_file(config_path)
+        except (OSError, UnicodeDecodeError) as e:
+            print(f"❌ Cannot read {config_path}: {e}")
+            sys.exit(1)
+        for issue in issues:
+            print(f"{'❌' if issue.severity == 'error' else '⚠️'} {issue.format(str(config_path))}")
+        errors = sum(1 for issue in issues if issue.severity == 'error')
+        if errors:
+            print(f"\n{errors} errors, {len(issues) - errors} warnings")
+            sys.exit(1)
+        print(f"✅ {config_path} is valid" + (f" ({len(issues)} warnings)" if issues else ''))
+        sys.exit(0)
+    
+    if args.config_init:
+        config_path = Path(args.path) / CONFIG_FILENAME
+        if config_path.exists():
+            print(f"❌ {config_path} already exists")
+            sys.exit(1)
+        try:
+            config_path.write_text(CONFIG_TEMPLATE, encoding='utf-8')
+        except OSError as e:
+            print(f"❌ Cannot write {config_path}: {e}")
+            sys.exit(1)
+        print(f"📝 Wrote {config_path} (check it with --config-validate)")
+        sys.exit(0)
+    
+    if args.config_show:
+        # Configuration effective et couche d'origine de chaque valeur
+        layers = ConfigLayers(Path(args.path), allow_repo_exec=fixer.allow_repo_exec)
+        lower, _ = layers.resolve()
+        layers.cli = fixer.cli_overrides(lower)
+        config, sources = layers.resolve()
+        print(f"\n⚙️ RESOLVED CONFIGURATION ({' < '.join(ConfigLayers.ORDER)})")
+        for path in sorted(sources):
+            value = config
+            for key in path.split('.'):
+                value = value[key]
+            print(f"   {path}: {json.dumps(value)}  ← {layers.source_label(path, sources[path])}")
+        sys.exit(0)
+    
+    if args.self_update:
+        if args.offline:
+            print("❌ Offline mode: self-update needs network access")
+            sys.exit(1)
+        try:
+            update = SelfUpdater().update()
+        except UpdateError as e:
+            print(f"❌ {e}")
+            sys.exit(1)
+        if update['updated']:
+            print(f"⬆️ Updated {update['current']} → {update['latest']}")
+        else:
+            print(f"✅ Already up to date ({update['current']}, latest {update['latest']})")
+        sys.exit(0)
+    
+    if args.revert:
+        # Annulation d'une exécution précédente
+        path = Path(args.path)
+        fix_log_path = args.fix_log or FixLog.path_from_config(ConfigLayers(path).resolve()[0].get('fix_log'))
+        try:
+            report = FixReverter(path, fix_log_path).revert(args.revert)
+        except (FixerError, OSError, subprocess.TimeoutExpired) as e:
+            print(f"❌ {e}")
+            sys.exit(1)
+        if report['reverted']:
+            stats = report['diff_stats']
+            print(f"⏪ Reverted {report['commit'][:12]} as {report['reverted'][:12]}"
+                  f" ({stats['files_changed']} files, +{stats['deletions']} -{stats['insertions']})")
+            for file_path, commits in sorted(report['later_changes'].items()):
+                print(f"   ⚠️ {file_path} also changed later by {len(commits)} commits - merged cleanly")
+            sys.exit(0)
+        print(f"\n💥 REVERT CONFLICT: {report['commit'][:12]} conflicts with later changes (nothing reverted)")
+        for file_path in report['conflicts']:
+            print(f"   {file_path}")
+            for commit in report['later_changes'].get(file_path, []):
+                print(f"      ↳ {commit}")
+        sys.exit(EXIT_REVERT_CONFLICT)
+    
+    if args.language_tree:
+        # Langages par répertoire
+        path = Path(args.path)
+        tree = fixer.language_detector.language_tree(path, WalkPolicy.from_config(ConfigLayers(path).resolve()[0].get('walk')))
+        
+        def print_tree(node: Dict[str, Any], depth: int):
+            languages = ', '.join(f"{language} {count}" for language, count in
+                                  sorted(node['languages'].items(), key=lambda item: (-item[1], item[0])))
+            print(f"{'   ' * depth}{node['path'] or '.'}/: {node['files']} files ({languages})")
+            for _, child in sorted(node['directories'].items()):
+                print_tree(child, depth + 1)
+        
+        print(f"\n🌳 LANGUAGES BY DIRECTORY")
+        print_tree(tree, 1)
+        sys.exit(0)
+    
+    if args.stats:
+        # Tendances de l'historique des exécutions
+        history_path = Path(args.path) / RunHistory.DEFAULT_PATH
+        trends = RunHistory.load(history_path).trends()
+        if not trends['runs']:
+            print(f"No run history in {history_path}")
+            sys.exit(0)
+        
+        print(f"\n📆 RUN HISTORY ({trends['runs']} runs)")
+        for run in trends['recent']:
+            print(f"   {run['timestamp']}: {run['fixes']} fixes in {run['files_changed']}/{run['files']} files")
+        print(f"   Trend: {trends['fixes_delta']:+d} fixes over the last {len(trends['recent'])} runs")
+        if trends['top_rules']:
+            print(f"\n🔁 MOST FREQUENT RULES:")
+            for rule, count in trends['top_rules']:
+                print(f"   {rule}: {count}")
+        if trends['dirtiest_directories']:
+            print(f"\n📂 DIRTIEST DIRECTORIES:")
+            for directory, count in trends['dirtiest_directories']:
+                print(f"   {directory}: {count} fixes")
+        sys.exit(0)
+    
+    if args.archive:
+        # Projet sans accès git : archive en entrée, archive corrigée en sortie
+        async def run_archive():
+            async with fixer.workspaces.allocate() as workspace:
+                with open(args.archive, 'rb') as f:
+                    return await ProjectArchive.process(fixer, f.read(), workspace.path)
+        try:
+            packed, report = asyncio.run(run_archive())
+        except (ArchiveError, TransactionError, OSError) as e:
+            print(f"❌ {e}")
+            sys.exit(1)
+        finally:
+            fixer.workspaces.cleanup()
+            fixer.shell_champion.cleanup()
+        output = args.archive_output or re.sub(r'(\.tar)?\.\w+$', '', args.archive) + '-fixed.zip'
+        with open(output, 'wb') as f:
+            f.write(packed)
+        print(f"📦 {output}: {len(report['files_written'])} files fixed"
+              f" ({report['summary']['total_fixes_applied']} fixes), report in {ProjectArchive.REPORT_NAME}")
+        sys.exit(0)
+    
+    if args.golden:
+        # Non-régression des correcteurs sur les fixtures entrée / golden
+        try:
+            golden = asyncio.run(GoldenSuite(args.golden).run(fixer, update=args.update_goldens))
+        except (FileNotFoundError, UnicodeDecodeError) as e:
+            print(f"❌ {e}")
+            sys.exit(1)
+        finally:
+            fixer.workspaces.cleanup()
+            fixer.shell_champion.cleanup()
+        
+        for failure in golden['failed']:
+            reason = 'missing golden' if failure['missing'] else 'output differs'
+            print(f"❌ {failure['case']}: {reason}")
+            print(failure['diff'])
+        for name in golden['updated']:
+            print(f"📝 {name}{GoldenSuite.SUFFIX} updated")
+        print(f"\n🥇 GOLDEN: {len(golden['passed'])}/{golden['cases']} passed"
+              + (f", {len(golden['updated'])} updated" if golden['updated'] else '')
+              + (f", {len(golden['failed'])} failed (run with --update-goldens to accept)" if golden['failed'] else ''))
+        sys.exit(1 if golden['failed'] else 0)
+    
+    if args.bench:
+        # Mode benchmark
+        try:
+            bench = asyncio.run(Benchmark(fixer).run(args.bench, args.languages))
+        finally:
+            fixer.workspaces.cleanup()
+            fixer.shell_champion.cleanup()
+        
+        print(f"\n⏱️ BENCHMARK ({bench['size']}: {bench['files']} files)")
+        print(f"   Throughput: {bench['files_per_second']:.1f} files/sec ({bench['elapsed']:.2f}s)")
+        print(f"   Peak memory: {bench['peak_memory_mb']:.1f} MB")
+        print(f"   Subprocesses: {bench['subprocess_calls']} calls, {bench['subprocess_time']:.2f}s")
+        for lang, stats in sorted(bench['by_language'].items()):
+            print(f"   {lang}: {stats['files']} files, {stats['ms_per_file']:.2f} ms/file")
+        
+        if args.bench_output:
+            with open(args.bench_output, 'w', encoding='utf-8') as f:
+                json.dump(bench, f, indent=2)
+        if args.bench_compare:
+            with open(args.bench_compare, 'r', encoding='utf-8') as f:
+                regressions = Benchmark.regressions(bench, json.load(f))
+            if regressions:
+                print(f"\n❌ Performance regressions:")
+                for regression in regressions:
+                    print(f"   - {regression}")
+                sys.exit(EXIT_THRESHOLD_EXCEEDED)
+        sys.exit(0)
     
     if args.server:
         # Mode serveur web
@@ -1019,13 +11026,25 @@ def main():
         print(f"📱 Interface: http://{args.host}:{args.port}")
         print(f"📚 API Docs: http://{args.host}:{args.port}/docs")
         
-        uvicorn.run(
+        if args.shutdown_timeout is not None:
+            fixer.shutdown_timeout = args.shutdown_timeout
+        
+        class DrainingServer(uvicorn.Server):
+            """Signal d'arrêt : refus immédiat des nouveaux travaux, puis arrêt gracieux d'uvicorn"""
+            
+            def handle_exit(self, sig, frame):
+                fixer.draining = True
+                super().handle_exit(sig, frame)
+        
+        DrainingServer(uvicorn.Config(
             fixer.app,
             host=args.host,
             port=args.port,
-            log_level="info"
+            log_level="info",
+            # Requêtes HTTP en cours : même délai que les travaux en file
+            timeout_graceful_shutdown=int(fixer.shutdown_timeout)
             # Removed loop parameter for Render compatibility
-        )
+        )).run()
     else:
         # Mode CLI
         async def run_cli():
@@ -1033,12 +11052,47 @@ def main():
             print(f"📂 Processing: {args.path}")
             
             path = Path(args.path)
-            if path.is_file():
+            
+            def stream_result(result: FixResult):
+                output.write(json.dumps(result_v1(result)) + '\n')
+                output.flush()
+            
+            if args.github:
+                # Mode API : `path` désigne un sous-dossier du repository distant
+                subdir = '' if args.path == '.' else args.path
+                try:
+                    report = await fixer.process_repository(ProcessOptions(
+                        github=args.github, ref=args.ref, subdir=subdir, branch=args.commit_branch,
+                        pr=args.pr, push=args.push, propose=args.propose, fork=args.fork))
+                except GitHubApiError as e:
+                    print(f"\n❌ {e}")
+                    return 1
+                results, proposal = report.results, report.proposal
+                commit_sha = None if args.propose else report.commit
+                if proposal:
+                    print(f"\n🗳️ Proposal {proposal['id']}: {proposal['files']} files on {proposal['branch']}"
+                          f" - approve with --approve {proposal['id']}")
+                
+                changed = [r for r in results if r.fixes_applied]
+                print(f"\n📊 {args.github}@{args.ref}: {len(results)} files processed, {len(changed)} with fixes")
+                fallback = report.pull_request
+                if commit_sha and fallback and fallback['reason'] == 'fork':
+                    print(f"🍴 No push access: committed {commit_sha[:12]} to {fallback['fork']}:{fallback['branch']}"
+                          f" and opened PR #{fallback['number']} against {fallback['base']}")
+                elif commit_sha and fallback:
+                    print(f"🔒 {fallback['base']} is protected: committed {commit_sha[:12]} to"
+                          f" {fallback['branch']} and opened PR #{fallback['number']}")
+                elif commit_sha:
+                    print(f"✅ Committed {commit_sha[:12]} to {args.commit_branch or f'PR #{args.pr}'}")
+            elif path.is_file():
                 # Fichier unique
                 with open(path, 'r', encoding='utf-8') as f:
                     content = f.read()
                 
                 result = await fixer.fix_file_content(str(path), content)
+                results = [result]
+                if args.stream:
+                    stream_result(result)
                 
                 print(f"\n📄 File: {result.file_path}")
                 print(f"🔤 Language: {result.language}")
@@ -1058,33 +11112,207 @@ def main():
                 
             else:
                 # Repository
-                results = await fixer.fix_repository(str(path))
+                if args.write_baseline:
+                    results = await fixer.fix_repository(str(path), use_baseline=False)
+                    baseline = Baseline.from_results(results, path)
+                    baseline_path = fixer.baseline_path(path)
+                    baseline.save(baseline_path)
+                    total = sum(sum(counts.values()) for counts in baseline.findings.values())
+                    print(f"\n📏 Baseline written: {baseline_path} ({total} findings in {len(baseline.findings)} files)")
+                    return 0
+                
+                if args.branches:
+                    # Plusieurs branches du même dépôt, en parallèle dans des worktrees
+                    branches = [b.strip() for b in args.branches.split(',') if b.strip()]
+                    by_branch = await fixer.fix_branches(str(path), branches)
+                    exit_code = 0
+                    for branch, results in by_branch.items():
+                        changed = [r for r in results if r.fixes_applied]
+                        print(f"\n🌿 {branch}: {len(changed)}/{len(results)} files to fix,"
+                              f" {sum(len(r.fixes_applied) for r in results)} fixes")
+                        for result in changed:
+                            print(f"   {Path(os.path.relpath(result.file_path, path)).as_posix()}: {len(result.fixes_applied)} fixes")
+                        for result in results:
+                            if result.error_code == WorktreeError.code:
+                                print(f"   ❌ {result.original_errors[0]}")
+                        if fixer.threshold_violations(results):
+                            exit_code = EXIT_THRESHOLD_EXCEEDED
+                    return exit_code
+                
+                results = (await fixer.process_repository(ProcessOptions(
+                    path=str(path), on_result=stream_result if args.stream else None))).results
+                if fixer.offline and results and results[0].error_code == OfflineError.code:
+                    print(f"\n❌ Offline mode: {results[0].original_errors[0]}")
+                    return EXIT_OFFLINE_UNSUPPORTED
+                
+                if fixer.history_enabled and not fixer.dry_run:
+                    history_path = path / RunHistory.DEFAULT_PATH
+                    history = RunHistory.load(history_path)
+                    history.record(results, path)
+                    try:
+                        history.save(history_path)
+                    except OSError as e:
+                        logger.warning("cannot save run history: %s", e, extra={'file_path': str(history_path)})
+                
+                if fixer.fix_log_path and not fixer.dry_run:
+                    entry = FixLog.entry(results, path, FixLog.local_commit(path))
+                    if entry is not None:
+                        try:
+                            FixLog.write(path / fixer.fix_log_path, entry)
+                        except OSError as e:
+                            logger.warning("cannot write fix log: %s", e, extra={'file_path': fixer.fix_log_path})
                 
                 print(f"\n📊 Repository Processing Complete")
                 print(f"📁 Files processed: {len(results)}")
                 
+                owners = CodeOwners.load(path) if (args.report or args.split_by_owner) else None
+                
                 if args.report:
-                    report = fixer.get_summary_report(results)
+                    report = fixer.get_summary_report(results, owners)
                     print(f"\n📈 SUMMARY REPORT")
                     print(f"   Success rate: {report['summary']['success_rate']:.1f}%")
                     print(f"   Total errors: {report['summary']['total_errors_found']}")
                     print(f"   Total fixes: {report['summary']['total_fixes_applied']}")
+                    print(f"   Diff: {report['diff_stats']['files_changed']} files changed,"
+                          f" +{report['diff_stats']['insertions']} -{report['diff_stats']['deletions']}")
                     print(f"   Avg time: {report['summary']['avg_processing_time']:.3f}s")
                     print(f"   Performance: {report['performance_metrics']['files_per_second']:.1f} files/sec")
                     
                     print(f"\n📋 BY LANGUAGE:")
                     for lang, stats in report['by_language'].items():
                         print(f"   {lang}: {stats['files']} files, {stats['success_rate']:.1f}% success")
+                    
+                    compile_check = report['compile_check']
+                    if compile_check:
+                        print(f"\n🏗️ COMPILE CHECK: {compile_check['status']}"
+                              f" ({compile_check.get('command') or compile_check.get('reason')})")
+                        for file_path in compile_check.get('files', []):
+                            print(f"   ↩️ rolled back: {file_path}")
+                    
+                    verification = report['verification']
+                    if verification:
+                        print(f"\n🧪 VERIFICATION: {verification['status']}"
+                              f" ({verification.get('command') or verification.get('reason')})")
+                    
+                    quarantine = report['quarantine']
+                    if quarantine and (quarantine['skipped'] or quarantine['added']):
+                        print(f"\n🚧 QUARANTINE: {len(quarantine['skipped'])} files skipped"
+                              f" (release with --clear-quarantine)")
+                        for file_path in quarantine['skipped']:
+                            print(f"   ⏭️ {file_path}")
+                        for file_path in quarantine['added']:
+                            print(f"   🆕 quarantined: {file_path}")
+                    
+                    if report['by_owner']:
+                        print(f"\n👥 BY OWNER:")
+                        for owner, stats in sorted(report['by_owner'].items()):
+                            print(f"   {owner}: {stats['files']} files, {stats['fixes']} fixes")
+                
+                if args.health:
+                    health = HealthScore.compute(results, path)
+                    print(f"\n💯 HEALTH SCORE: {health['score']}/100")
+                    print(f"   Files needing fixes: {health['files_needing_fixes']}/{health['files']}")
+                    print(f"   Severity-weighted fixes: {health['weighted_fixes']}")
+                    print(f"   Formatter config: {', '.join(health['formatter_configs']) or 'none'}")
+                
+                if args.split_by_owner:
+                    if owners is None:
+                        print("\n⚠️  No CODEOWNERS file found - cannot split by owner")
+                    else:
+                        for owner, owned in sorted(owners.group(results).items()):
+                            changed = [r for r in owned if r.fixes_applied]
+                            if not changed:
+                                continue
+                            print(f"\n👥 {owner} ({len(changed)} files to review)")
+                            for result in changed:
+                                print(f"   {Path(os.path.relpath(result.file_path, path)).as_posix()}: {len(result.fixes_applied)} fixes")
                 
                 successful = sum(1 for r in results if r.success)
                 print(f"\n🎯 {successful}/{len(results)} files processed successfully")
+            
+            if args.patch:
+                root = path.parent if path.is_file() else path
+                patch, patched_files = build_patch(results, root)
+                if args.patch == '-':
+                    output.write(patch)
+                    output.flush()
+                else:
+                    with open(args.patch, 'w', encoding='utf-8') as f:
+                        f.write(patch)
+                    print(f"\n🩹 Patch written: {args.patch} ({patched_files} files) - apply with `git apply`")
+            
+            violations = fixer.threshold_violations(results)
+            if args.write and not args.github and not violations:
+                # Toutes les corrections calculées et validées : écriture tout-ou-rien
+                try:
+                    written = FixTransaction(results).commit()
+                except TransactionError as e:
+                    print(f"\n❌ {e}")
+                    return 1
+                print(f"\n💾 {len(written)} files written")
+            
+            if args.interactive:
+                InteractiveReviewer().review(results)
+            
+            if violations:
+                print(f"\n❌ Failure thresholds exceeded:")
+                for violation in violations:
+                    print(f"   - {violation}")
+                if args.write:
+                    print("   Nothing written (--write)")
+                return EXIT_THRESHOLD_EXCEEDED
+            return 0
+        
+        # Exécution locale : progression enregistrée, reprise possible après interruption
+        fixer.run_manifests = not args.github
+        if args.resume:
+            if not re.fullmatch(r'[\w-]+', args.resume) or RunManifest.load(Path(args.path), args.resume) is None:
+                print(f"❌ No manifest for run {args.resume} in {Path(args.path) / RunManifest.DIRECTORY}")
+                sys.exit(1)
+            run_id_var.set(args.resume)
+        else:
+            new_run_id()
         
         # Exécution asynchrone
-        asyncio.run(run_cli())
+        try:
+            exit_code = asyncio.run(run_cli())
+        except KeyboardInterrupt:
+            print("\n⛔ Interrupted - in-flight work cancelled")
+            if RunManifest.path_for(Path(args.path), run_id_var.get()).is_file():
+                print(f"   Resume with --resume {run_id_var.get()}")
+            sys.exit(130)
+        finally:
+            fixer.workspaces.cleanup()
+            fixer.shell_champion.cleanup()
+            tracing.shutdown()
+        
+        sys.exit(exit_code)
+
+# === API PUBLIQUE ===
+async def process_repository(options: ProcessOptions,
+                             fixer: Optional[AutoSyntaxFixerILN3] = None) -> ProcessReport:
+    """Traitement complet d'un repository - point d'entrée des programmes qui intègrent le correcteur"""
+    return await (fixer or AutoSyntaxFixerILN3()).process_repository(options)
 
 if __name__ == "__main__":
     main()
 else:
     # Mode importation - création de l'instance pour serveur
+    configure_logging(os.environ.get('ASF_LOG_LEVEL', 'WARNING'),
+                      os.environ.get('ASF_LOG_FORMAT', 'text'))
     iln_fixer = AutoSyntaxFixerILN3()
+    if os.environ.get('ASF_REDIS_URL'):
+        iln_fixer.use_redis(os.environ['ASF_REDIS_URL'])
+    if os.environ.get('ASF_CONTAINERS'):
+        iln_fixer.shell_champion.use_containers(ContainerBackend.load(os.environ['ASF_CONTAINERS']))
+    if os.environ.get('ASF_REMOTE_FORMATTERS'):
+        iln_fixer.remote_formatters = RemoteFormatter.load(os.environ['ASF_REMOTE_FORMATTERS'])
+    if os.environ.get('OTEL_EXPORTER_OTLP_ENDPOINT'):
+        tracing.configure()
+    if os.environ.get('ASF_STRATEGY_POLICY'):
+        iln_fixer.strategy_policy = StrategyPolicy.from_spec(os.environ['ASF_STRATEGY_POLICY'])
+    if os.environ.get('ASF_OFFLINE') == '1':
+        iln_fixer.enable_offline()
+    if os.environ.get('ASF_CONCURRENCY'):
+        iln_fixer.use_concurrency(ConcurrencyProfile.from_spec(os.environ['ASF_CONCURRENCY']))
     app = iln_fixer.app
\ No newline at end of file
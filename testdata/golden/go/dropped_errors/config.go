@@ -0,0 +1,25 @@
+package config
+
+import (
+	"log"
+	"os"
+)
+
+func Load(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func Save(path string, data []byte) error {
+	err := os.WriteFile(path, data, 0o644)
+	log.Printf("saved %s", path)
+	return nil
+}
+
+func Count(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	return len(data), nil
+}
@@ -0,0 +1,7 @@
+package model
+
+type User struct {
+	ID int `yaml:"id" json:"id"`
+	Name string `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
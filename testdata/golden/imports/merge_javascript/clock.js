@@ -0,0 +1,9 @@
+import { useState } from 'react';
+import { format } from './date';
+import { useEffect } from 'react';
+
+export function Clock() {
+  const [now, setNow] = useState(new Date());
+  useEffect(() => setNow(new Date()), []);
+  return format(now);
+}
@@ -0,0 +1,9 @@
+import os
+from typing import List
+import sys
+from typing import Dict, Optional
+import os
+
+
+def main(args: List[str]) -> Optional[Dict[str, str]]:
+    return dict(os.environ) if sys.argv else None
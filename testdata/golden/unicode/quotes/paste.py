@@ -0,0 +1,3 @@
+﻿name = “world”
+greeting = ‘hello’​
+print("keep “quoted” text")  # don’t touch comments
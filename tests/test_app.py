@@ -0,0 +1,243 @@
+"""Tests unitaires : extraction d'archives, couches de configuration, API d'administration et propositions
+
+    python -m unittest discover -s tests
+"""
+
+import asyncio
+import io
+import os
+import sys
+import tempfile
+import unittest
+import zipfile
+from pathlib import Path
+from unittest import mock
+
+sys.path.insert(0, str(Path(__file__).resolve().parent.parent))
+
+# Base du serveur isolée (ProposalStore, ProjectStore...) avant l'import de l'application
+_DB_DIR = tempfile.TemporaryDirectory(prefix='asf-tests-')
+os.environ['ASF_DB'] = str(Path(_DB_DIR.name) / 'server.db')
+
+import app  # noqa: E402
+
+
+def zip_bytes(files):
+    buffer = io.BytesIO()
+    with zipfile.ZipFile(buffer, 'w') as archive:
+        for name, content in files.items():
+            archive.writestr(name, content)
+    return buffer.getvalue()
+
+
+class FakeRequest:
+    def __init__(self, headers=None):
+        self.headers = headers or {}
+
+
+class FakeGitHub:
+    """Client GitHub minimal : cible inchangée depuis la proposition (pas de rebase)"""
+
+    def __init__(self, head):
+        self.head = head
+        self.pull_requests = []
+        self.merged = []
+
+    async def head_commit(self, ref):
+        return self.head
+
+    async def diff_stats(self, base_sha, head_sha):
+        return app.DiffStats({'main.py': (1, 1)})
+
+    async def create_pull_request(self, branch, base, title, body):
+        self.pull_requests.append({'branch': branch, 'base': base, 'body': body})
+        return {'number': len(self.pull_requests)}
+
+    async def merge_pull_request(self, number, method):
+        self.merged.append((number, method))
+
+
+class SafeNameTest(unittest.TestCase):
+
+    def test_normalizes_relative_paths(self):
+        self.assertEqual(app.ProjectArchive._safe_name('src/main.py'), 'src/main.py')
+        self.assertEqual(app.ProjectArchive._safe_name('./src//main.py'), 'src/main.py')
+        self.assertEqual(app.ProjectArchive._safe_name('src\\main.py'), 'src/main.py')
+
+    def test_directories_are_skipped(self):
+        self.assertIsNone(app.ProjectArchive._safe_name('.'))
+        self.assertIsNone(app.ProjectArchive._safe_name('./'))
+
+    def test_rejects_paths_outside_the_tree(self):
+        for name in ('../evil.py', 'src/../../evil.py', '/etc/passwd', 'C:\\Windows\\evil.py',
+                     'src\\..\\..\\evil.py'):
+            with self.subTest(name=name), self.assertRaises(app.ArchiveError):
+                app.ProjectArchive._safe_name(name)
+
+
+class ArchiveLimitsTest(unittest.TestCase):
+
+    def setUp(self):
+        self.directory = tempfile.TemporaryDirectory()
+        self.destination = Path(self.directory.name)
+
+    def tearDown(self):
+        self.directory.cleanup()
+
+    def test_extracts_and_strips_single_root(self):
+        root = app.ProjectArchive.extract(zip_bytes({'project/a.py': 'x = 1\n', 'project/b/c.py': ''}),
+                                          self.destination)
+        self.assertEqual(root, self.destination / 'project')
+        self.assertEqual((root / 'a.py').read_text(), 'x = 1\n')
+
+    def test_rejects_traversal_member(self):
+        with self.assertRaises(app.ArchiveError):
+            app.ProjectArchive.extract(zip_bytes({'../evil.py': 'x'}), self.destination)
+        self.assertFalse((self.destination.parent / 'evil.py').exists())
+
+    def test_rejects_symlink_member(self):
+        buffer = io.BytesIO()
+        with zipfile.ZipFile(buffer, 'w') as archive:
+            info = zipfile.ZipInfo('link')
+            info.external_attr = 0o120777 << 16
+            archive.writestr(info, '/etc/passwd')
+        with self.assertRaises(app.ArchiveError):
+            app.ProjectArchive.extract(buffer.getvalue(), self.destination)
+
+    def test_member_count_limit(self):
+        with mock.patch.object(app.ProjectArchive, 'MAX_MEMBERS', 2):
+            with self.assertRaises(app.ArchiveError):
+                app.ProjectArchive.extract(zip_bytes({'a': '', 'b': '', 'c': ''}), self.destination)
+
+    def test_extracted_size_limit(self):
+        with mock.patch.object(app.ProjectArchive, 'MAX_EXTRACTED_SIZE', 10):
+            with self.assertRaises(app.ArchiveError):
+                app.ProjectArchive.extract(zip_bytes({'a': 'x' * 6, 'b': 'x' * 6}), self.destination)
+
+    def test_upload_size_limit(self):
+        with mock.patch.object(app.ProjectArchive, 'MAX_UPLOAD_SIZE', 10):
+            with self.assertRaises(app.ArchiveError):
+                app.ProjectArchive.extract(zip_bytes({'a': 'x'}), self.destination)
+
+    def test_rejects_empty_and_unknown_archives(self):
+        with self.assertRaises(app.ArchiveError):
+            app.ProjectArchive.extract(zip_bytes({}), self.destination)
+        with self.assertRaises(app.ArchiveError):
+            app.ProjectArchive.extract(b'not an archive', self.destination)
+
+
+class RepoLayerTest(unittest.TestCase):
+
+    REPO_CONFIG = {
+        'enabled_rules': ['missing_colon'],
+        'plugins': [{'name': 'fmt', 'language': 'python', 'command': ['fmt']}],
+        'verify': True,
+        'project_format': {'command': 'npm run format'},
+        'typescript': {'codefixes': True},
+        'compile_check': True
+    }
+
+    def layer(self, allow_repo_exec):
+        return app.ConfigLayers(Path('.'), environ={}, allow_repo_exec=allow_repo_exec,
+                                repo_config=dict(self.REPO_CONFIG)).repo_layer()
+
+    def test_exec_keys_dropped_by_default(self):
+        layer = self.layer(False)
+        self.assertEqual(layer, {'enabled_rules': ['missing_colon']})
+        for key in app.REPO_EXEC_KEYS:
+            self.assertNotIn(key, layer)
+
+    def test_exec_keys_kept_with_opt_in(self):
+        self.assertEqual(self.layer(True), self.REPO_CONFIG)
+
+    def test_operator_layers_are_not_filtered(self):
+        layers = app.ConfigLayers(Path('.'), cli={'compile_check': True}, environ={'ASF_VERIFY': 'true'},
+                                  repo_config=dict(self.REPO_CONFIG))
+        with mock.patch.object(app.ConfigLayers, 'user_path', return_value=Path('/nonexistent/config.yml')):
+            config, sources = layers.resolve()
+        self.assertTrue(config['compile_check'])
+        self.assertEqual(sources['compile_check'], 'cli')
+        self.assertTrue(config['verify'])
+        self.assertEqual(sources['verify'], 'env')
+        self.assertFalse(config['typescript'].get('codefixes', False))
+
+
+class AdminApiTest(unittest.TestCase):
+
+    @classmethod
+    def setUpClass(cls):
+        cls.fixer = app.AutoSyntaxFixerILN3()
+
+    def setUp(self):
+        self.fixer.admin_token = 's3cret'
+
+    def route(self, method, path):
+        return next(route.endpoint for route in self.fixer.app.routes
+                    if getattr(route, 'path', None) == path and method in getattr(route, 'methods', ()))
+
+    def propose(self):
+        app.new_run_id()
+        return self.fixer.proposals.create('owner/repo', 'main', 'base', 'asf/proposal-x', 'fix', 1)
+
+    def test_require_admin(self):
+        self.fixer._require_admin(FakeRequest({'authorization': 'Bearer s3cret'}))
+        for headers in ({}, {'authorization': 'Bearer wrong'}, {'authorization': 's3cret'}):
+            with self.subTest(headers=headers), self.assertRaises(app.HTTPException) as caught:
+                self.fixer._require_admin(FakeRequest(headers))
+            self.assertEqual(caught.exception.status_code, 401)
+
+    def test_admin_api_disabled_without_token(self):
+        self.fixer.admin_token = None
+        with self.assertRaises(app.HTTPException) as caught:
+            self.fixer._require_admin(FakeRequest({'authorization': 'Bearer None'}))
+        self.assertEqual(caught.exception.status_code, 404)
+
+    def test_proposal_routes_require_admin(self):
+        proposal = self.propose()
+        approve = self.route('POST', '/api/proposals/{proposal_id}/approve')
+        list_proposals = self.route('GET', '/api/proposals')
+        with self.assertRaises(app.HTTPException) as caught:
+            asyncio.run(approve(FakeRequest(), proposal['id'], {'approver': 'ops'}))
+        self.assertEqual(caught.exception.status_code, 401)
+        with self.assertRaises(app.HTTPException) as caught:
+            asyncio.run(list_proposals(FakeRequest(), None))
+        self.assertEqual(caught.exception.status_code, 401)
+        self.assertEqual(self.fixer.proposals.get(proposal['id'])['status'], 'proposed')
+
+    def test_approval_requires_approver_identity(self):
+        proposal = self.propose()
+        approve = self.route('POST', '/api/proposals/{proposal_id}/approve')
+        with self.assertRaises(app.HTTPException) as caught:
+            asyncio.run(approve(FakeRequest({'authorization': 'Bearer s3cret'}), proposal['id'], {}))
+        self.assertEqual(caught.exception.status_code, 400)
+        with self.assertRaises(app.ProposalError):
+            asyncio.run(self.fixer.approve_proposal(proposal['id']))
+        self.assertEqual(self.fixer.proposals.get(proposal['id'])['status'], 'proposed')
+
+    def test_approve_opens_pull_request(self):
+        proposal = self.propose()
+        github = FakeGitHub(head='base')
+        approve = self.route('POST', '/api/proposals/{proposal_id}/approve')
+        with mock.patch.object(self.fixer, 'github_client', return_value=github):
+            decided = asyncio.run(approve(FakeRequest({'authorization': 'Bearer s3cret'}), proposal['id'],
+                                          {'approver': 'ops', 'merge': True}))
+        self.assertEqual(decided['status'], 'merged')
+        self.assertEqual(decided['decided_by'], 'ops')
+        self.assertEqual(decided['pull_request'], 1)
+        self.assertIn('approved by ops', github.pull_requests[0]['body'])
+        self.assertEqual(github.merged, [(1, 'squash')])
+
+        # Seconde approbation refusée : proposition déjà traitée
+        with self.assertRaises(app.HTTPException) as caught:
+            asyncio.run(approve(FakeRequest({'authorization': 'Bearer s3cret'}), proposal['id'], {'approver': 'ops'}))
+        self.assertEqual(caught.exception.status_code, 409)
+
+    def test_unknown_proposal(self):
+        approve = self.route('POST', '/api/proposals/{proposal_id}/approve')
+        with self.assertRaises(app.HTTPException) as caught:
+            asyncio.run(approve(FakeRequest({'authorization': 'Bearer s3cret'}), 'missing', {'approver': 'ops'}))
+        self.assertEqual(caught.exception.status_code, 404)
+
+
+if __name__ == '__main__':
+    unittest.main()